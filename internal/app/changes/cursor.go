@@ -0,0 +1,60 @@
+package changes
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const cursorPrefix = "c1_"
+
+// Cursor is a position on main: Offset transactions of Commit have been
+// emitted, and an Offset of zero means all of them have.
+type Cursor struct {
+	Commit string
+	Offset int
+}
+
+func (c Cursor) String() string {
+	if c.Commit == "" {
+		return ""
+	}
+	raw := c.Commit + ":" + strconv.Itoa(c.Offset)
+	return cursorPrefix + base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseCursor accepts an empty string (start of history), a cursor returned
+// by a previous stream, or a bare commit hash meaning "after this commit".
+func ParseCursor(value string) (Cursor, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Cursor{}, nil
+	}
+	if isCommitHash(value) {
+		return Cursor{Commit: strings.ToLower(value)}, nil
+	}
+	encoded, ok := strings.CutPrefix(value, cursorPrefix)
+	if !ok {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, value)
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, value)
+	}
+	commit, offsetText, ok := strings.Cut(string(raw), ":")
+	offset, err := strconv.Atoi(offsetText)
+	if !ok || err != nil || offset < 0 || !isCommitHash(commit) {
+		return Cursor{}, fmt.Errorf("%w: %s", ErrInvalidCursor, value)
+	}
+	return Cursor{Commit: commit, Offset: offset}, nil
+}
+
+func isCommitHash(value string) bool {
+	if len(value) != 40 && len(value) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
@@ -0,0 +1,8 @@
+package changes
+
+import "errors"
+
+var ErrInvalidCursor = errors.New("invalid change cursor")
+var ErrCursorNotFound = errors.New("change cursor commit not found on main")
+var ErrFetchUnavailable = errors.New("fetch is not configured")
+var ErrInvalidInterval = errors.New("invalid follow interval")
@@ -0,0 +1,25 @@
+package changes
+
+import (
+	"context"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type Fetcher interface {
+	Fetch(ctx context.Context, repoPath string) error
+}
+
+type CommitSource interface {
+	ListCommitHashes(ctx context.Context, repoPath, sinceHash string) ([]string, error)
+	CommitTxs(ctx context.Context, repoPath, commitHash string) ([]indexapp.CommitTx, error)
+}
+
+type Decoder interface {
+	Decode(data []byte) (domain.Transaction, error)
+}
+
+type Hasher interface {
+	SumHex(data []byte) string
+}
@@ -0,0 +1,155 @@
+package changes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+)
+
+type Service struct {
+	fetcher Fetcher
+	source  CommitSource
+	decoder Decoder
+	hasher  Hasher
+}
+
+func NewService(fetcher Fetcher, source CommitSource, decoder Decoder, hasher Hasher) *Service {
+	return &Service{
+		fetcher: fetcher,
+		source:  source,
+		decoder: decoder,
+		hasher:  hasher,
+	}
+}
+
+// Stream emits every transaction after opts.Since in commit order and
+// returns the cursor to resume from, which also covers trailing commits that
+// carried no transactions. After an error, resume from the Cursor of the
+// last emitted event.
+func (s *Service) Stream(ctx context.Context, repoPath string, opts StreamOptions, emit func(Event) error) (string, error) {
+	cursor, err := ParseCursor(opts.Since)
+	if err != nil {
+		return "", err
+	}
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return "", err
+	}
+	if opts.Fetch {
+		if s.fetcher == nil {
+			return "", ErrFetchUnavailable
+		}
+		if err := s.fetcher.Fetch(ctx, absRepoPath); err != nil {
+			return "", err
+		}
+	}
+
+	if cursor.Offset > 0 {
+		if err := s.emitCommit(ctx, absRepoPath, cursor.Commit, cursor.Offset, emit); err != nil {
+			return "", err
+		}
+		cursor.Offset = 0
+	}
+
+	commits, err := s.source.ListCommitHashes(ctx, absRepoPath, cursor.Commit)
+	if err != nil {
+		if errors.Is(err, indexapp.ErrCommitNotFound) {
+			return "", fmt.Errorf("%w: %s", ErrCursorNotFound, cursor.Commit)
+		}
+		return "", err
+	}
+	for _, commit := range commits {
+		if err := s.emitCommit(ctx, absRepoPath, commit, 0, emit); err != nil {
+			return "", err
+		}
+		cursor = Cursor{Commit: commit}
+	}
+	return cursor.String(), nil
+}
+
+// Follow streams changes and then polls for new ones every interval until ctx
+// is cancelled.
+func (s *Service) Follow(ctx context.Context, repoPath string, opts FollowOptions, emit func(Event) error) error {
+	if opts.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	since := opts.Since
+	for {
+		next, err := s.Stream(ctx, repoPath, StreamOptions{Since: since, Fetch: opts.Fetch}, func(event Event) error {
+			since = event.Cursor
+			return emit(event)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		since = next
+
+		timer := time.NewTimer(opts.Interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
+
+// emitCommit emits the transactions of one commit, skipping the first skip.
+func (s *Service) emitCommit(ctx context.Context, repoPath, commit string, skip int, emit func(Event) error) error {
+	blobs, err := s.source.CommitTxs(ctx, repoPath, commit)
+	if err != nil {
+		return err
+	}
+	events := make([]Event, 0, len(blobs))
+	for _, blob := range blobs {
+		tx, err := s.decoder.Decode(blob.Bytes)
+		if err != nil {
+			return err
+		}
+		if err := tx.Validate(); err != nil {
+			return err
+		}
+		events = append(events, Event{
+			Commit:        commit,
+			Collection:    tx.Collection,
+			DocID:         tx.DocID,
+			Op:            tx.Op.String(),
+			TxID:          tx.TxID,
+			TxHash:        s.hasher.SumHex(blob.Bytes),
+			ParentHash:    tx.ParentHash,
+			Timestamp:     tx.Timestamp,
+			SchemaVersion: tx.SchemaVersion,
+			Payload:       tx.Snapshot,
+			Patch:         tx.Patch,
+		})
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Timestamp == events[j].Timestamp {
+			return events[i].TxID < events[j].TxID
+		}
+		return events[i].Timestamp < events[j].Timestamp
+	})
+
+	for i := skip; i < len(events); i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		position := Cursor{Commit: commit, Offset: i + 1}
+		if i == len(events)-1 {
+			position.Offset = 0
+		}
+		events[i].Cursor = position.String()
+		if err := emit(events[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package changes
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+var (
+	commitA = strings.Repeat("a", 40)
+	commitB = strings.Repeat("b", 40)
+	commitC = strings.Repeat("c", 40)
+)
+
+type fakeSource struct {
+	commits []string
+	txs     map[string][]indexapp.CommitTx
+}
+
+func (f fakeSource) ListCommitHashes(ctx context.Context, repoPath, sinceHash string) ([]string, error) {
+	if sinceHash == "" {
+		return f.commits, nil
+	}
+	for i, commit := range f.commits {
+		if commit == sinceHash {
+			return f.commits[i+1:], nil
+		}
+	}
+	return nil, indexapp.ErrCommitNotFound
+}
+
+func (f fakeSource) CommitTxs(ctx context.Context, repoPath, commitHash string) ([]indexapp.CommitTx, error) {
+	return f.txs[commitHash], nil
+}
+
+type mapDecoder map[string]domain.Transaction
+
+func (d mapDecoder) Decode(data []byte) (domain.Transaction, error) {
+	tx, ok := d[string(data)]
+	if !ok {
+		return domain.Transaction{}, errors.New("unknown tx")
+	}
+	return tx, nil
+}
+
+type testHasher struct{}
+
+func (testHasher) SumHex(data []byte) string {
+	return "hash:" + string(data)
+}
+
+func newTestService() *Service {
+	source := fakeSource{
+		commits: []string{commitA, commitB, commitC},
+		txs: map[string][]indexapp.CommitTx{
+			commitA: {{Bytes: []byte("t1")}},
+			commitB: {{Bytes: []byte("t3")}, {Bytes: []byte("t2")}},
+		},
+	}
+	decoder := mapDecoder{
+		"t1": {TxID: "t1", Timestamp: 1, Collection: "tasks", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{"n":1}`)},
+		"t2": {TxID: "t2", Timestamp: 2, Collection: "tasks", DocID: "a", Op: domain.TxOpPatch, Patch: []byte(`[]`), ParentHash: "hash:t1"},
+		"t3": {TxID: "t3", Timestamp: 3, Collection: "tasks", DocID: "b", Op: domain.TxOpDelete},
+	}
+	return NewService(nil, source, decoder, testHasher{})
+}
+
+func collect(t *testing.T, service *Service, since string) ([]Event, string) {
+	t.Helper()
+	var events []Event
+	next, err := service.Stream(context.Background(), t.TempDir(), StreamOptions{Since: since}, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream returned error: %v", err)
+	}
+	return events, next
+}
+
+func txIDs(events []Event) []string {
+	ids := make([]string, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.TxID)
+	}
+	return ids
+}
+
+func TestStreamEmitsTransactionsInCommitOrder(t *testing.T) {
+	service := newTestService()
+
+	events, next := collect(t, service, "")
+	if !reflect.DeepEqual(txIDs(events), []string{"t1", "t2", "t3"}) {
+		t.Fatalf("unexpected order: %v", txIDs(events))
+	}
+	patch := events[1]
+	if patch.Commit != commitB || patch.Op != "patch" || patch.TxHash != "hash:t2" || patch.ParentHash != "hash:t1" || string(patch.Patch) != "[]" {
+		t.Fatalf("unexpected patch event: %+v", patch)
+	}
+	if string(events[0].Payload) != `{"n":1}` {
+		t.Fatalf("expected the put snapshot as payload, got %+v", events[0])
+	}
+	if next != (Cursor{Commit: commitC}).String() {
+		t.Fatalf("expected the final cursor to cover the empty commit, got %q", next)
+	}
+
+	events, _ = collect(t, service, next)
+	if len(events) != 0 {
+		t.Fatalf("expected no events after the final cursor, got %v", txIDs(events))
+	}
+}
+
+func TestStreamResumesFromEventCursor(t *testing.T) {
+	service := newTestService()
+	all, _ := collect(t, service, "")
+
+	events, _ := collect(t, service, all[1].Cursor)
+	if !reflect.DeepEqual(txIDs(events), []string{"t3"}) {
+		t.Fatalf("expected to resume inside commit B, got %v", txIDs(events))
+	}
+	events, _ = collect(t, service, all[0].Cursor)
+	if !reflect.DeepEqual(txIDs(events), []string{"t2", "t3"}) {
+		t.Fatalf("expected to resume after commit A, got %v", txIDs(events))
+	}
+	events, _ = collect(t, service, commitA)
+	if !reflect.DeepEqual(txIDs(events), []string{"t2", "t3"}) {
+		t.Fatalf("expected a bare commit to mean after that commit, got %v", txIDs(events))
+	}
+}
+
+func TestStreamRejectsBadCursors(t *testing.T) {
+	service := newTestService()
+	emit := func(Event) error { return nil }
+
+	if _, err := service.Stream(context.Background(), t.TempDir(), StreamOptions{Since: "nope"}, emit); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+	unknown := strings.Repeat("f", 40)
+	if _, err := service.Stream(context.Background(), t.TempDir(), StreamOptions{Since: unknown}, emit); !errors.Is(err, ErrCursorNotFound) {
+		t.Fatalf("expected ErrCursorNotFound, got %v", err)
+	}
+	if _, err := service.Stream(context.Background(), t.TempDir(), StreamOptions{Fetch: true}, emit); !errors.Is(err, ErrFetchUnavailable) {
+		t.Fatalf("expected ErrFetchUnavailable, got %v", err)
+	}
+}
+
+func TestParseCursorRoundTrip(t *testing.T) {
+	cursor := Cursor{Commit: commitB, Offset: 2}
+	parsed, err := ParseCursor(cursor.String())
+	if err != nil || parsed != cursor {
+		t.Fatalf("expected %+v, got %+v (%v)", cursor, parsed, err)
+	}
+	if parsed, err := ParseCursor(strings.ToUpper(commitA)); err != nil || parsed != (Cursor{Commit: commitA}) {
+		t.Fatalf("expected a bare commit cursor, got %+v (%v)", parsed, err)
+	}
+}
+
+func TestFollowStopsOnCancel(t *testing.T) {
+	service := newTestService()
+	ctx, cancel := context.WithCancel(context.Background())
+	var ids []string
+	err := service.Follow(ctx, t.TempDir(), FollowOptions{Interval: time.Millisecond}, func(event Event) error {
+		ids = append(ids, event.TxID)
+		if len(ids) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Follow returned error: %v", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"t1", "t2", "t3"}) {
+		t.Fatalf("unexpected events: %v", ids)
+	}
+	if err := service.Follow(context.Background(), t.TempDir(), FollowOptions{}, func(Event) error { return nil }); !errors.Is(err, ErrInvalidInterval) {
+		t.Fatalf("expected ErrInvalidInterval, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+package changes
+
+import "time"
+
+// Event is one transaction as it was applied on main. Cursor resumes the
+// stream right after this event.
+type Event struct {
+	Cursor        string
+	Commit        string
+	Collection    string
+	DocID         string
+	Op            string
+	TxID          string
+	TxHash        string
+	ParentHash    string
+	Timestamp     int64
+	SchemaVersion string
+	Payload       []byte
+	Patch         []byte
+}
+
+type StreamOptions struct {
+	Since string
+	Fetch bool
+}
+
+type FollowOptions struct {
+	Since    string
+	Fetch    bool
+	Interval time.Duration
+}
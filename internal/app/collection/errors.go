@@ -6,3 +6,5 @@ var ErrCollectionRequired = errors.New("collection name is required")
 var ErrSchemaPathRequired = errors.New("schema path is required")
 var ErrInvalidCollectionName = errors.New("invalid collection name")
 var ErrSchemaInvalidJSON = errors.New("schema is not valid JSON")
+var ErrCollectionNotFound = errors.New("collection not found")
+var ErrTrustedKeysConflict = errors.New("trusted keys cannot be set and cleared at once")
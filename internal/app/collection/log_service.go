@@ -0,0 +1,61 @@
+package collection
+
+import (
+	"context"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type LogService struct {
+	store  HistoryStore
+	hasher Hasher
+}
+
+func NewLogService(store HistoryStore, hasher Hasher) *LogService {
+	return &LogService{
+		store:  store,
+		hasher: hasher,
+	}
+}
+
+func (s *LogService) Log(ctx context.Context, repoPath, collection string) ([]LogEntry, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return nil, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return nil, ErrInvalidCollectionName
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := s.store.ListSchemaChanges(ctx, absRepoPath, collection)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrCollectionNotFound
+	}
+
+	entries := make([]LogEntry, 0, len(changes))
+	for _, change := range changes {
+		entry := LogEntry{
+			CommitHash:  change.CommitHash,
+			Timestamp:   change.Timestamp,
+			Indexes:     change.Indexes,
+			FullText:    change.FullText,
+			TrustedKeys: change.TrustedKeys,
+			Removed:     change.Removed,
+		}
+		if !change.Removed {
+			entry.SchemaVersion = s.hasher.SumHex(change.Schema)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,62 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type fakeHistoryStore struct {
+	changes []SchemaChange
+	err     error
+}
+
+func (f fakeHistoryStore) ListSchemaChanges(ctx context.Context, repoPath, collection string) ([]SchemaChange, error) {
+	return f.changes, f.err
+}
+
+type fakeHasher struct{}
+
+func (fakeHasher) SumHex(data []byte) string {
+	return "sum:" + string(data)
+}
+
+func TestLogServiceRequiresName(t *testing.T) {
+	service := NewLogService(fakeHistoryStore{}, fakeHasher{})
+	_, err := service.Log(context.Background(), "repo", " ")
+	if !errors.Is(err, ErrCollectionRequired) {
+		t.Fatalf("expected ErrCollectionRequired, got %v", err)
+	}
+}
+
+func TestLogServiceNotFound(t *testing.T) {
+	service := NewLogService(fakeHistoryStore{}, fakeHasher{})
+	_, err := service.Log(context.Background(), "repo", "users")
+	if !errors.Is(err, ErrCollectionNotFound) {
+		t.Fatalf("expected ErrCollectionNotFound, got %v", err)
+	}
+}
+
+func TestLogServiceBuildsEntries(t *testing.T) {
+	now := time.Unix(10, 0).UTC()
+	store := fakeHistoryStore{changes: []SchemaChange{
+		{CommitHash: "c2", Timestamp: now, Schema: []byte(`{"v":2}`), Indexes: []string{"email"}},
+		{CommitHash: "c1", Timestamp: now.Add(-time.Minute), Schema: []byte(`{"v":1}`)},
+	}}
+	service := NewLogService(store, fakeHasher{})
+
+	entries, err := service.Log(context.Background(), "repo", "users")
+	if err != nil {
+		t.Fatalf("Log returned error: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if entries[0].CommitHash != "c2" || entries[0].SchemaVersion != `sum:{"v":2}` {
+		t.Fatalf("unexpected first entry: %+v", entries[0])
+	}
+	if len(entries[0].Indexes) != 1 || entries[0].Indexes[0] != "email" {
+		t.Fatalf("expected indexes to be preserved, got %v", entries[0].Indexes)
+	}
+}
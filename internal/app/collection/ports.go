@@ -11,5 +11,14 @@ type SchemaValidator interface {
 }
 
 type Store interface {
-	WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes []string) error
+	// WriteSchema leaves the trusted keys untouched when trustedKeys is nil.
+	WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes, fullText, trustedKeys []string) error
+}
+
+type HistoryStore interface {
+	ListSchemaChanges(ctx context.Context, repoPath, collection string) ([]SchemaChange, error)
+}
+
+type Hasher interface {
+	SumHex(data []byte) string
 }
@@ -27,7 +27,10 @@ func NewService(store Store, source SchemaSource, validator SchemaValidator) *Se
 	}
 }
 
-func (s *Service) Apply(ctx context.Context, repoPath, collection, schemaPath string, indexes []string) error {
+// Apply writes the schema and field lists of a collection. A nil
+// trustedKeys keeps the collection's current keys; an empty, non-nil list
+// removes them.
+func (s *Service) Apply(ctx context.Context, repoPath, collection, schemaPath string, indexes, fullText, trustedKeys []string) error {
 	collection = strings.TrimSpace(collection)
 	if collection == "" {
 		return ErrCollectionRequired
@@ -67,15 +70,33 @@ func (s *Service) Apply(ctx context.Context, repoPath, collection, schemaPath st
 		}
 	}
 
-	indexes = normalizeIndexes(indexes)
+	indexes = normalizeFields(indexes)
+	fullText = normalizeFields(fullText)
+	if trustedKeys != nil {
+		keys := make([]string, 0, len(trustedKeys))
+		for _, key := range trustedKeys {
+			if strings.TrimSpace(key) == "" {
+				continue
+			}
+			normalized, err := domain.NormalizePublicKey(key)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, normalized)
+		}
+		trustedKeys = normalizeFields(keys)
+		if trustedKeys == nil {
+			trustedKeys = []string{}
+		}
+	}
 
-	return s.store.WriteSchema(ctx, absRepoPath, collection, schema, indexes)
+	return s.store.WriteSchema(ctx, absRepoPath, collection, schema, indexes, fullText, trustedKeys)
 }
 
-func normalizeIndexes(indexes []string) []string {
+func normalizeFields(fields []string) []string {
 	seen := make(map[string]struct{})
 	var normalized []string
-	for _, item := range indexes {
+	for _, item := range fields {
 		item = strings.TrimSpace(item)
 		if item == "" {
 			continue
@@ -3,7 +3,10 @@ package collection
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 )
 
 type fakeSchemaSource struct {
@@ -19,13 +22,17 @@ type fakeCollectionStore struct {
 	collection string
 	schema     []byte
 	indexes    []string
+	fullText   []string
+	keys       []string
 	err        error
 }
 
-func (f *fakeCollectionStore) WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes []string) error {
+func (f *fakeCollectionStore) WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes, fullText, trustedKeys []string) error {
 	f.collection = collection
 	f.schema = schema
 	f.indexes = indexes
+	f.fullText = fullText
+	f.keys = trustedKeys
 	return f.err
 }
 
@@ -39,7 +46,7 @@ func (f fakeSchemaValidator) Validate(ctx context.Context, schema []byte) error
 
 func TestServiceRequiresName(t *testing.T) {
 	service := NewService(&fakeCollectionStore{}, &fakeSchemaSource{}, fakeSchemaValidator{})
-	err := service.Apply(context.Background(), "repo", " ", "schema.json", nil)
+	err := service.Apply(context.Background(), "repo", " ", "schema.json", nil, nil, nil)
 	if !errors.Is(err, ErrCollectionRequired) {
 		t.Fatalf("expected ErrCollectionRequired, got %v", err)
 	}
@@ -47,7 +54,7 @@ func TestServiceRequiresName(t *testing.T) {
 
 func TestServiceRejectsInvalidName(t *testing.T) {
 	service := NewService(&fakeCollectionStore{}, &fakeSchemaSource{}, fakeSchemaValidator{})
-	err := service.Apply(context.Background(), "repo", "users/../etc", "schema.json", nil)
+	err := service.Apply(context.Background(), "repo", "users/../etc", "schema.json", nil, nil, nil)
 	if !errors.Is(err, ErrInvalidCollectionName) {
 		t.Fatalf("expected ErrInvalidCollectionName, got %v", err)
 	}
@@ -55,7 +62,7 @@ func TestServiceRejectsInvalidName(t *testing.T) {
 
 func TestServiceRequiresSchemaPath(t *testing.T) {
 	service := NewService(&fakeCollectionStore{}, &fakeSchemaSource{}, fakeSchemaValidator{})
-	err := service.Apply(context.Background(), "repo", "users", " ", nil)
+	err := service.Apply(context.Background(), "repo", "users", " ", nil, nil, nil)
 	if !errors.Is(err, ErrSchemaPathRequired) {
 		t.Fatalf("expected ErrSchemaPathRequired, got %v", err)
 	}
@@ -63,7 +70,7 @@ func TestServiceRequiresSchemaPath(t *testing.T) {
 
 func TestServiceValidatesJSON(t *testing.T) {
 	service := NewService(&fakeCollectionStore{}, &fakeSchemaSource{data: []byte("{")}, fakeSchemaValidator{})
-	err := service.Apply(context.Background(), "repo", "users", "schema.json", nil)
+	err := service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, nil)
 	if !errors.Is(err, ErrSchemaInvalidJSON) {
 		t.Fatalf("expected ErrSchemaInvalidJSON, got %v", err)
 	}
@@ -72,7 +79,7 @@ func TestServiceValidatesJSON(t *testing.T) {
 func TestServiceNormalizesIndexes(t *testing.T) {
 	store := &fakeCollectionStore{}
 	service := NewService(store, &fakeSchemaSource{data: []byte(`{"type":"object"}`)}, fakeSchemaValidator{})
-	err := service.Apply(context.Background(), "repo", "users", "schema.json", []string{" email", "", "role", "email"})
+	err := service.Apply(context.Background(), "repo", "users", "schema.json", []string{" email", "", "role", "email"}, []string{"bio ", "bio"}, nil)
 	if err != nil {
 		t.Fatalf("Apply returned error: %v", err)
 	}
@@ -86,13 +93,41 @@ func TestServiceNormalizesIndexes(t *testing.T) {
 			t.Fatalf("expected %v, got %v", expected, store.indexes)
 		}
 	}
+	if len(store.fullText) != 1 || store.fullText[0] != "bio" {
+		t.Fatalf("expected full-text fields [bio], got %v", store.fullText)
+	}
+}
+
+func TestServiceNormalizesTrustedKeys(t *testing.T) {
+	store := &fakeCollectionStore{}
+	service := NewService(store, &fakeSchemaSource{data: []byte(`{}`)}, fakeSchemaValidator{})
+	key := strings.Repeat("ab", 32)
+	err := service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, []string{strings.ToUpper(key), " ", key})
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if len(store.keys) != 1 || store.keys[0] != key {
+		t.Fatalf("expected trusted keys [%s], got %v", key, store.keys)
+	}
+
+	if err := service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, nil); err != nil || store.keys != nil {
+		t.Fatalf("expected nil keys to be passed through to keep the current ones, got %v (%v)", store.keys, err)
+	}
+	if err := service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, []string{" "}); err != nil || store.keys == nil || len(store.keys) != 0 {
+		t.Fatalf("expected an empty list to clear the keys, got %#v (%v)", store.keys, err)
+	}
+
+	err = service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, []string{"not-a-key"})
+	if !errors.Is(err, domain.ErrInvalidPublicKey) {
+		t.Fatalf("expected ErrInvalidPublicKey, got %v", err)
+	}
 }
 
 func TestServiceRunsSchemaValidator(t *testing.T) {
 	validatorErr := errors.New("invalid schema")
 	service := NewService(&fakeCollectionStore{}, &fakeSchemaSource{data: []byte(`{"type":"object"}`)}, fakeSchemaValidator{err: validatorErr})
 
-	err := service.Apply(context.Background(), "repo", "users", "schema.json", nil)
+	err := service.Apply(context.Background(), "repo", "users", "schema.json", nil, nil, nil)
 	if !errors.Is(err, validatorErr) {
 		t.Fatalf("expected validator error, got %v", err)
 	}
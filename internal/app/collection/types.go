@@ -0,0 +1,23 @@
+package collection
+
+import "time"
+
+type SchemaChange struct {
+	CommitHash  string
+	Timestamp   time.Time
+	Schema      []byte
+	Indexes     []string
+	FullText    []string
+	TrustedKeys []string
+	Removed     bool
+}
+
+type LogEntry struct {
+	CommitHash    string
+	Timestamp     time.Time
+	SchemaVersion string
+	Indexes       []string
+	FullText      []string
+	TrustedKeys   []string
+	Removed       bool
+}
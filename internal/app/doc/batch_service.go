@@ -0,0 +1,259 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type BatchService struct {
+	readStore     ReadStore
+	writeStore    BatchWriteStore
+	canonicalizer Canonicalizer
+	encoder       Encoder
+	decoder       Decoder
+	patcher       Patcher
+	hasher        Hasher
+	clock         Clock
+	idGen         IDGenerator
+	schemas       SchemaStore
+	validator     DocumentValidator
+	layout        domain.StreamLayout
+	historyMode   domain.HistoryMode
+}
+
+type stagedStream struct {
+	head    string
+	doc     []byte
+	deleted bool
+}
+
+func NewBatchService(readStore ReadStore, writeStore BatchWriteStore, canonicalizer Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, hasher Hasher, clock Clock, idGen IDGenerator, schemas SchemaStore, validator DocumentValidator, layout domain.StreamLayout, historyMode domain.HistoryMode) *BatchService {
+	if layout == "" {
+		layout = domain.StreamLayoutFlat
+	}
+	layout = domain.NormalizeStreamLayout(layout)
+	historyMode = domain.NormalizeHistoryMode(historyMode)
+	return &BatchService{
+		readStore:     readStore,
+		writeStore:    writeStore,
+		canonicalizer: canonicalizer,
+		encoder:       encoder,
+		decoder:       decoder,
+		patcher:       patcher,
+		hasher:        hasher,
+		clock:         clock,
+		idGen:         idGen,
+		schemas:       schemas,
+		validator:     validator,
+		layout:        layout,
+		historyMode:   historyMode,
+	}
+}
+
+func (s *BatchService) Apply(ctx context.Context, repoPath string, ops []BatchOp) (BatchResult, error) {
+	if len(ops) == 0 {
+		return BatchResult{}, ErrBatchEmpty
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	staged := make(map[string]*stagedStream)
+	writes := make([]TxWrite, 0, len(ops))
+	var lastTimestamp int64
+	for i, op := range ops {
+		write, err := s.stage(ctx, absRepoPath, op, staged, &lastTimestamp)
+		if err != nil {
+			return BatchResult{}, fmt.Errorf("batch op %d: %w", i+1, err)
+		}
+		writes = append(writes, write)
+	}
+
+	commitHash, err := s.writeStore.PutTxs(ctx, absRepoPath, writes)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	result := BatchResult{
+		CommitHash: commitHash,
+		Txs:        make([]PutResult, 0, len(writes)),
+	}
+	for _, write := range writes {
+		result.Txs = append(result.Txs, PutResult{
+			CommitHash: commitHash,
+			TxHash:     write.TxHash,
+			TxID:       write.Tx.TxID,
+		})
+	}
+	return result, nil
+}
+
+func (s *BatchService) stage(ctx context.Context, repoPath string, op BatchOp, staged map[string]*stagedStream, lastTimestamp *int64) (TxWrite, error) {
+	collection := strings.TrimSpace(op.Collection)
+	if collection == "" {
+		return TxWrite{}, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return TxWrite{}, ErrInvalidCollection
+	}
+
+	docID := strings.TrimSpace(op.DocID)
+	if docID == "" {
+		return TxWrite{}, ErrDocIDRequired
+	}
+
+	if op.Op != domain.TxOpDelete && len(op.Payload) == 0 {
+		return TxWrite{}, ErrPayloadRequired
+	}
+
+	streamPath := domain.StreamPath(s.layout, collection, docID)
+	statePath := domain.StatePath(s.layout, collection, docID)
+	current, err := s.loadStaged(ctx, repoPath, statePath, streamPath, staged)
+	if err != nil {
+		return TxWrite{}, err
+	}
+
+	txID, err := s.idGen.NewID()
+	if err != nil {
+		return TxWrite{}, err
+	}
+	timestamp := s.clock.Now().UnixNano()
+	if timestamp <= *lastTimestamp {
+		timestamp = *lastTimestamp + 1
+	}
+	*lastTimestamp = timestamp
+
+	tx := domain.Transaction{
+		TxID:       txID,
+		Timestamp:  timestamp,
+		Collection: collection,
+		DocID:      docID,
+		Op:         op.Op,
+	}
+	if s.historyMode != domain.HistoryModeAmend {
+		tx.ParentHash = current.head
+	}
+
+	var updatedDoc []byte
+	switch op.Op {
+	case domain.TxOpPut:
+		canonical, err := s.canonicalizer.Canonicalize(ctx, op.Payload)
+		if err != nil {
+			return TxWrite{}, err
+		}
+		tx.Snapshot = canonical
+		updatedDoc = canonical
+	case domain.TxOpPatch:
+		if current.doc == nil && !current.deleted {
+			return TxWrite{}, ErrDocNotFound
+		}
+		if current.deleted {
+			return TxWrite{}, ErrDocDeleted
+		}
+		if s.patcher == nil {
+			return TxWrite{}, ErrPatchUnsupported
+		}
+		canonicalPatch, err := s.canonicalizer.Canonicalize(ctx, op.Payload)
+		if err != nil {
+			return TxWrite{}, err
+		}
+		patched, err := s.patcher.Apply(ctx, current.doc, canonicalPatch)
+		if err != nil {
+			return TxWrite{}, err
+		}
+		updatedDoc, err = s.canonicalizer.Canonicalize(ctx, patched)
+		if err != nil {
+			return TxWrite{}, err
+		}
+		if s.historyMode == domain.HistoryModeAmend {
+			tx.Op = domain.TxOpMerge
+			tx.Snapshot = updatedDoc
+		} else {
+			tx.Patch = canonicalPatch
+		}
+	case domain.TxOpDelete:
+		if current.doc == nil && !current.deleted {
+			return TxWrite{}, ErrDocNotFound
+		}
+		if current.deleted {
+			return TxWrite{}, ErrDocDeleted
+		}
+	default:
+		return TxWrite{}, domain.ErrInvalidOp
+	}
+
+	if updatedDoc != nil {
+		tx.SchemaVersion, err = enforceSchema(ctx, s.schemas, s.validator, s.hasher, repoPath, collection, updatedDoc)
+		if err != nil {
+			return TxWrite{}, err
+		}
+	}
+
+	encoded, err := s.encoder.Encode(tx)
+	if err != nil {
+		return TxWrite{}, err
+	}
+	txHash := s.hasher.SumHex(encoded)
+
+	stateTx := tx
+	stateTx.ParentHash = ""
+	if stateTx.Op == domain.TxOpPatch {
+		stateTx.Op = domain.TxOpMerge
+		stateTx.Patch = nil
+		stateTx.Snapshot = updatedDoc
+	}
+	stateEncoded, err := s.encoder.Encode(stateTx)
+	if err != nil {
+		return TxWrite{}, err
+	}
+
+	current.head = txHash
+	current.doc = updatedDoc
+	current.deleted = op.Op == domain.TxOpDelete
+
+	return TxWrite{
+		RepoPath:     repoPath,
+		StreamPath:   streamPath,
+		TxBytes:      encoded,
+		TxHash:       txHash,
+		Tx:           tx,
+		StatePath:    statePath,
+		StateTxBytes: stateEncoded,
+		StateTxHash:  s.hasher.SumHex(stateEncoded),
+		StateTx:      stateTx,
+	}, nil
+}
+
+func (s *BatchService) loadStaged(ctx context.Context, repoPath, statePath, streamPath string, staged map[string]*stagedStream) (*stagedStream, error) {
+	if current, ok := staged[streamPath]; ok {
+		return current, nil
+	}
+
+	current := &stagedStream{}
+	head, err := s.readStore.LoadStreamHead(ctx, repoPath, streamPath)
+	if err != nil {
+		return nil, err
+	}
+	current.head = head
+	if head != "" {
+		doc, err := loadCurrentDoc(ctx, s.readStore, s.decoder, s.hasher, s.patcher, repoPath, statePath, streamPath, head)
+		switch {
+		case errors.Is(err, ErrDocDeleted):
+			current.deleted = true
+		case err != nil:
+			return nil, err
+		default:
+			current.doc = doc
+		}
+	}
+
+	staged[streamPath] = current
+	return current, nil
+}
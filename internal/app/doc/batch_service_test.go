@@ -0,0 +1,116 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func newTestBatchService(store *memStore) *BatchService {
+	return NewBatchService(store, store, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, sha256Hasher{}, &tickClock{}, &seqIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+}
+
+func TestBatchRequiresOps(t *testing.T) {
+	service := newTestBatchService(newMemStore())
+	_, err := service.Apply(context.Background(), "repo", nil)
+	if !errors.Is(err, ErrBatchEmpty) {
+		t.Fatalf("expected ErrBatchEmpty, got %v", err)
+	}
+}
+
+func TestBatchAppliesAllOpsInOneCommit(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	service := newTestBatchService(store)
+
+	seed, err := service.Apply(ctx, "repo", []BatchOp{
+		{Op: domain.TxOpPut, Collection: "accounts", DocID: "a", Payload: []byte(`{"balance":10}`)},
+		{Op: domain.TxOpPut, Collection: "accounts", DocID: "b", Payload: []byte(`{"balance":0}`)},
+	})
+	if err != nil {
+		t.Fatalf("seed Apply returned error: %v", err)
+	}
+	if store.commits != 1 {
+		t.Fatalf("expected 1 commit, got %d", store.commits)
+	}
+
+	result, err := service.Apply(ctx, "repo", []BatchOp{
+		{Op: domain.TxOpPatch, Collection: "accounts", DocID: "a", Payload: []byte(`{"balance":5}`)},
+		{Op: domain.TxOpPatch, Collection: "accounts", DocID: "b", Payload: []byte(`{"balance":5}`)},
+		{Op: domain.TxOpPatch, Collection: "accounts", DocID: "b", Payload: []byte(`{"note":"transfer"}`)},
+	})
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	if store.commits != 2 {
+		t.Fatalf("expected 2 commits, got %d", store.commits)
+	}
+	if len(result.Txs) != 3 {
+		t.Fatalf("expected 3 tx results, got %d", len(result.Txs))
+	}
+
+	streamB := domain.StreamPath(domain.StreamLayoutFlat, "accounts", "b")
+	txs, err := store.LoadStreamTxs(ctx, "repo", streamB)
+	if err != nil {
+		t.Fatalf("LoadStreamTxs returned error: %v", err)
+	}
+	if len(txs) != 3 {
+		t.Fatalf("expected 3 txs for b, got %d", len(txs))
+	}
+	last, err := jsonCodec{}.Decode(txs[2].Bytes)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if last.ParentHash != result.Txs[1].TxHash {
+		t.Fatalf("expected staged parent %s, got %s", result.Txs[1].TxHash, last.ParentHash)
+	}
+	if last.ParentHash == seed.Txs[1].TxHash {
+		t.Fatalf("expected second patch to chain on the staged tx")
+	}
+
+	stateB, err := store.LoadHeadTx(ctx, "repo", domain.StatePath(domain.StreamLayoutFlat, "accounts", "b"))
+	if err != nil {
+		t.Fatalf("LoadHeadTx returned error: %v", err)
+	}
+	stateTx, err := jsonCodec{}.Decode(stateB.Bytes)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if string(stateTx.Snapshot) != `{"balance":5,"note":"transfer"}` {
+		t.Fatalf("unexpected state snapshot %s", stateTx.Snapshot)
+	}
+}
+
+func TestBatchWritesNothingWhenAnyOpFails(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	service := newTestBatchService(store)
+
+	_, err := service.Apply(ctx, "repo", []BatchOp{
+		{Op: domain.TxOpPut, Collection: "accounts", DocID: "a", Payload: []byte(`{"balance":10}`)},
+		{Op: domain.TxOpPatch, Collection: "accounts", DocID: "missing", Payload: []byte(`{"balance":5}`)},
+	})
+	if !errors.Is(err, ErrDocNotFound) {
+		t.Fatalf("expected ErrDocNotFound, got %v", err)
+	}
+	if store.commits != 0 {
+		t.Fatalf("expected no commits, got %d", store.commits)
+	}
+}
+
+func TestBatchRejectsDeleteOfDeletedDoc(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	service := newTestBatchService(store)
+
+	_, err := service.Apply(ctx, "repo", []BatchOp{
+		{Op: domain.TxOpPut, Collection: "accounts", DocID: "a", Payload: []byte(`{"balance":10}`)},
+		{Op: domain.TxOpDelete, Collection: "accounts", DocID: "a"},
+		{Op: domain.TxOpDelete, Collection: "accounts", DocID: "a"},
+	})
+	if !errors.Is(err, ErrDocDeleted) {
+		t.Fatalf("expected ErrDocDeleted, got %v", err)
+	}
+}
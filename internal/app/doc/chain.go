@@ -2,7 +2,9 @@ package doc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 )
@@ -48,6 +50,14 @@ func buildTxChain(headHash string, index map[string]txChainEntry) ([]txChainEntr
 	return chain, nil
 }
 
+// resolveTxRef accepts either a tx hash (any case) or a tx id.
+func resolveTxRef(index map[string]txChainEntry, ref string) (string, error) {
+	if hash := strings.ToLower(ref); index[hash].Hash != "" {
+		return hash, nil
+	}
+	return selectTargetHash(index, ref, "")
+}
+
 func rehydrateChain(ctx context.Context, chain []txChainEntry, patcher Patcher) ([]byte, domain.Transaction, error) {
 	var doc []byte
 	for i := len(chain) - 1; i >= 0; i-- {
@@ -72,7 +82,7 @@ func rehydrateChain(ctx context.Context, chain []txChainEntry, patcher Patcher)
 			}
 			doc = updated
 		case domain.TxOpDelete:
-			return nil, domain.Transaction{}, ErrDocDeleted
+			doc = nil
 		case domain.TxOpMerge:
 			if len(tx.Snapshot) > 0 {
 				doc = tx.Snapshot
@@ -94,9 +104,88 @@ func rehydrateChain(ctx context.Context, chain []txChainEntry, patcher Patcher)
 		}
 	}
 
+	if len(chain) > 0 && chain[0].Tx.Op == domain.TxOpDelete {
+		return nil, domain.Transaction{}, ErrDocDeleted
+	}
 	if doc == nil {
 		return nil, domain.Transaction{}, ErrDocNotFound
 	}
 
 	return doc, chain[0].Tx, nil
 }
+
+// findMergeBase walks the parent DAG (including merge parents) and returns the
+// closest ancestor of theirs that is also reachable from ours.
+func findMergeBase(oursHead, theirsHead string, index map[string]txChainEntry) string {
+	ancestors := make(map[string]struct{})
+	queue := []string{oursHead}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := ancestors[current]; ok {
+			continue
+		}
+		ancestors[current] = struct{}{}
+		if entry, ok := index[current]; ok {
+			queue = append(queue, entry.Tx.Parents()...)
+		}
+	}
+
+	visited := make(map[string]struct{})
+	queue = []string{theirsHead}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		visited[current] = struct{}{}
+		if _, ok := ancestors[current]; ok {
+			return current
+		}
+		if entry, ok := index[current]; ok {
+			queue = append(queue, entry.Tx.Parents()...)
+		}
+	}
+	return ""
+}
+
+func loadCurrentDoc(ctx context.Context, store ReadStore, decoder Decoder, hasher Hasher, patcher Patcher, repoPath, statePath, streamPath, headHash string) ([]byte, error) {
+	stateBlob, err := store.LoadHeadTx(ctx, repoPath, statePath)
+	if err != nil && !errors.Is(err, ErrDocNotFound) {
+		return nil, err
+	}
+	if err == nil && len(stateBlob.Bytes) > 0 {
+		stateTx, err := decoder.Decode(stateBlob.Bytes)
+		if err == nil {
+			switch stateTx.Op {
+			case domain.TxOpDelete:
+				return nil, ErrDocDeleted
+			case domain.TxOpPut, domain.TxOpMerge:
+				if len(stateTx.Snapshot) > 0 {
+					return stateTx.Snapshot, nil
+				}
+			}
+		}
+	}
+
+	txBlobs, err := store.LoadStreamTxs(ctx, repoPath, streamPath)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := buildTxIndex(txBlobs, decoder, hasher)
+	if err != nil {
+		return nil, err
+	}
+	chain, err := buildTxChain(headHash, index)
+	if err != nil {
+		return nil, err
+	}
+
+	currentDoc, _, err := rehydrateChain(ctx, chain, patcher)
+	if err != nil {
+		return nil, err
+	}
+	return currentDoc, nil
+}
@@ -39,7 +39,7 @@ func NewDeleteService(writeStore WriteStore, readStore ReadStore, encoder Encode
 	}
 }
 
-func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID string) (PutResult, error) {
+func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID string, opts WriteOptions) (PutResult, error) {
 	collection = strings.TrimSpace(collection)
 	if collection == "" {
 		return PutResult{}, ErrCollectionRequired
@@ -53,6 +53,11 @@ func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID
 		return PutResult{}, ErrDocIDRequired
 	}
 
+	opts, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return PutResult{}, err
+	}
+
 	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
 	if err != nil {
 		return PutResult{}, err
@@ -63,6 +68,13 @@ func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID
 	if err != nil {
 		return PutResult{}, err
 	}
+	currentHead := ""
+	if len(headBlob.Bytes) > 0 {
+		currentHead = s.hasher.SumHex(headBlob.Bytes)
+	}
+	if err := checkExpectedHead(currentHead, opts); err != nil {
+		return PutResult{}, err
+	}
 	if len(headBlob.Bytes) == 0 {
 		return PutResult{}, ErrDocNotFound
 	}
@@ -77,7 +89,7 @@ func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID
 
 	parentHash := ""
 	if s.historyMode != domain.HistoryModeAmend {
-		parentHash = s.hasher.SumHex(headBlob.Bytes)
+		parentHash = currentHead
 	}
 	txID, err := s.idGen.NewID()
 	if err != nil {
@@ -120,6 +132,8 @@ func (s *DeleteService) Delete(ctx context.Context, repoPath, collection, docID
 		StateTxBytes: stateEncoded,
 		StateTxHash:  stateHash,
 		StateTx:      stateTx,
+		IfMatch:      opts.IfMatch,
+		IfNoneMatch:  opts.IfNoneMatch,
 	})
 	if err != nil {
 		return PutResult{}, err
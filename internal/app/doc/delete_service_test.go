@@ -54,7 +54,7 @@ func (s stubDecoder) Decode(data []byte) (domain.Transaction, error) {
 
 func TestDeleteRequiresCollection(t *testing.T) {
 	service := NewDeleteService(&fakeDeleteStore{}, &fakeDeleteStore{}, &fakeEncoder{}, stubDecoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Delete(context.Background(), "repo", " ", "doc")
+	_, err := service.Delete(context.Background(), "repo", " ", "doc", WriteOptions{})
 	if !errors.Is(err, ErrCollectionRequired) {
 		t.Fatalf("expected ErrCollectionRequired, got %v", err)
 	}
@@ -62,7 +62,7 @@ func TestDeleteRequiresCollection(t *testing.T) {
 
 func TestDeleteRejectsInvalidCollection(t *testing.T) {
 	service := NewDeleteService(&fakeDeleteStore{}, &fakeDeleteStore{}, &fakeEncoder{}, stubDecoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Delete(context.Background(), "repo", "users/..", "doc")
+	_, err := service.Delete(context.Background(), "repo", "users/..", "doc", WriteOptions{})
 	if !errors.Is(err, ErrInvalidCollection) {
 		t.Fatalf("expected ErrInvalidCollection, got %v", err)
 	}
@@ -70,7 +70,7 @@ func TestDeleteRejectsInvalidCollection(t *testing.T) {
 
 func TestDeleteRequiresDocID(t *testing.T) {
 	service := NewDeleteService(&fakeDeleteStore{}, &fakeDeleteStore{}, &fakeEncoder{}, stubDecoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Delete(context.Background(), "repo", "users", " ")
+	_, err := service.Delete(context.Background(), "repo", "users", " ", WriteOptions{})
 	if !errors.Is(err, ErrDocIDRequired) {
 		t.Fatalf("expected ErrDocIDRequired, got %v", err)
 	}
@@ -81,7 +81,7 @@ func TestDeleteReturnsErrDocDeleted(t *testing.T) {
 	decoder := stubDecoder{tx: domain.Transaction{Op: domain.TxOpDelete}}
 	service := NewDeleteService(store, store, &fakeEncoder{}, decoder, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
-	_, err := service.Delete(context.Background(), "repo", "users", "doc")
+	_, err := service.Delete(context.Background(), "repo", "users", "doc", WriteOptions{})
 	if !errors.Is(err, ErrDocDeleted) {
 		t.Fatalf("expected ErrDocDeleted, got %v", err)
 	}
@@ -96,7 +96,7 @@ func TestDeleteWritesTx(t *testing.T) {
 	idGen := fakeIDGen{id: "01H123"}
 	service := NewDeleteService(store, store, encoder, decoder, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
-	result, err := service.Delete(context.Background(), "repo", "users", "doc")
+	result, err := service.Delete(context.Background(), "repo", "users", "doc", WriteOptions{})
 	if err != nil {
 		t.Fatalf("Delete returned error: %v", err)
 	}
@@ -118,7 +118,7 @@ func TestDeleteAmendSkipsParentHash(t *testing.T) {
 	idGen := fakeIDGen{id: "01H123"}
 	service := NewDeleteService(store, store, encoder, decoder, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAmend)
 
-	_, err := service.Delete(context.Background(), "repo", "users", "doc")
+	_, err := service.Delete(context.Background(), "repo", "users", "doc", WriteOptions{})
 	if err != nil {
 		t.Fatalf("Delete returned error: %v", err)
 	}
@@ -0,0 +1,125 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type DiffService struct {
+	store   ReadStore
+	decoder Decoder
+	hasher  Hasher
+	patcher Patcher
+	differ  Differ
+	layout  domain.StreamLayout
+}
+
+func NewDiffService(store ReadStore, decoder Decoder, hasher Hasher, patcher Patcher, differ Differ, layout domain.StreamLayout) *DiffService {
+	if layout == "" {
+		layout = domain.StreamLayoutFlat
+	}
+	layout = domain.NormalizeStreamLayout(layout)
+	return &DiffService{
+		store:   store,
+		decoder: decoder,
+		hasher:  hasher,
+		patcher: patcher,
+		differ:  differ,
+		layout:  layout,
+	}
+}
+
+// Diff compares the document at two transactions. To defaults to the stream
+// head and From to the parent of To; a missing or deleted side diffs as absent.
+func (s *DiffService) Diff(ctx context.Context, repoPath, collection, docID string, opts DiffOptions) (DiffResult, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return DiffResult{}, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return DiffResult{}, ErrInvalidCollection
+	}
+
+	docID = strings.TrimSpace(docID)
+	if docID == "" {
+		return DiffResult{}, ErrDocIDRequired
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	streamPath := domain.StreamPath(s.layout, collection, docID)
+	headHash, err := s.store.LoadStreamHead(ctx, absRepoPath, streamPath)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	if headHash == "" {
+		return DiffResult{}, ErrDocNotFound
+	}
+
+	txBlobs, err := s.store.LoadStreamTxs(ctx, absRepoPath, streamPath)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	index, err := buildTxIndex(txBlobs, s.decoder, s.hasher)
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	toHash := headHash
+	if ref := strings.TrimSpace(opts.To); ref != "" {
+		toHash, err = resolveTxRef(index, ref)
+		if err != nil {
+			return DiffResult{}, err
+		}
+	}
+	fromHash := index[toHash].Tx.ParentHash
+	if ref := strings.TrimSpace(opts.From); ref != "" {
+		fromHash, err = resolveTxRef(index, ref)
+		if err != nil {
+			return DiffResult{}, err
+		}
+	}
+
+	fromDoc, err := s.docAt(ctx, fromHash, index)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	toDoc, err := s.docAt(ctx, toHash, index)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	ops, err := s.differ.Diff(ctx, fromDoc, toDoc)
+	if err != nil {
+		return DiffResult{}, err
+	}
+
+	return DiffResult{
+		FromHash: fromHash,
+		FromTxID: index[fromHash].Tx.TxID,
+		ToHash:   toHash,
+		ToTxID:   index[toHash].Tx.TxID,
+		Ops:      ops,
+	}, nil
+}
+
+func (s *DiffService) docAt(ctx context.Context, hash string, index map[string]txChainEntry) ([]byte, error) {
+	if hash == "" {
+		return nil, nil
+	}
+	chain, err := buildTxChain(hash, index)
+	if err != nil {
+		return nil, err
+	}
+	doc, _, err := rehydrateChain(ctx, chain, s.patcher)
+	if errors.Is(err, ErrDocDeleted) {
+		return nil, nil
+	}
+	return doc, err
+}
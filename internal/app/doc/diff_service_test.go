@@ -0,0 +1,70 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// wholeDiffer reports the two documents as a single replace.
+type wholeDiffer struct{}
+
+func (wholeDiffer) Diff(ctx context.Context, from, to []byte) ([]PatchOp, error) {
+	return []PatchOp{{Op: "replace", Path: "", Old: from, Value: to}}, nil
+}
+
+func TestDiffDefaultsAndRefs(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	clock := &tickClock{}
+	ids := &seqIDGen{}
+	put := NewPutService(store, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, clock, ids, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	first, err := put.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{})
+	if err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	patch := NewPatchService(store, store, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, sha256Hasher{}, clock, ids, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	second, err := patch.Patch(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{})
+	if err != nil {
+		t.Fatalf("Patch returned error: %v", err)
+	}
+	del := NewDeleteService(store, store, jsonCodec{}, jsonCodec{}, sha256Hasher{}, clock, ids, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	third, err := del.Delete(ctx, "repo", "users", "doc", WriteOptions{})
+	if err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	service := NewDiffService(store, jsonCodec{}, sha256Hasher{}, mergePatcher{}, wholeDiffer{}, domain.StreamLayoutFlat)
+	tests := []struct {
+		name       string
+		opts       DiffOptions
+		from, to   string
+		oldV, newV string
+	}{
+		{name: "head", opts: DiffOptions{}, from: second.TxHash, to: third.TxHash, oldV: `{"v":2}`, newV: ""},
+		{name: "to", opts: DiffOptions{To: second.TxID}, from: first.TxHash, to: second.TxHash, oldV: `{"v":1}`, newV: `{"v":2}`},
+		{name: "first", opts: DiffOptions{To: first.TxHash}, from: "", to: first.TxHash, oldV: "", newV: `{"v":1}`},
+		{name: "range", opts: DiffOptions{From: first.TxID, To: third.TxID}, from: first.TxHash, to: third.TxHash, oldV: `{"v":1}`, newV: ""},
+	}
+	for _, tt := range tests {
+		result, err := service.Diff(ctx, "repo", "users", "doc", tt.opts)
+		if err != nil {
+			t.Fatalf("%s: Diff returned error: %v", tt.name, err)
+		}
+		if result.FromHash != tt.from || result.ToHash != tt.to {
+			t.Fatalf("%s: unexpected range %s..%s", tt.name, result.FromHash, result.ToHash)
+		}
+		if len(result.Ops) != 1 || string(result.Ops[0].Old) != tt.oldV || string(result.Ops[0].Value) != tt.newV {
+			t.Fatalf("%s: unexpected ops %+v", tt.name, result.Ops)
+		}
+	}
+
+	if _, err := service.Diff(ctx, "repo", "users", "doc", DiffOptions{From: "missing"}); !errors.Is(err, ErrTxNotFound) {
+		t.Fatalf("expected ErrTxNotFound, got %v", err)
+	}
+	if _, err := service.Diff(ctx, "repo", "users", "other", DiffOptions{}); !errors.Is(err, ErrDocNotFound) {
+		t.Fatalf("expected ErrDocNotFound, got %v", err)
+	}
+}
@@ -12,3 +12,14 @@ var ErrPatchUnsupported = errors.New("patch operations not supported")
 var ErrTxReferenceRequired = errors.New("tx id or tx hash is required")
 var ErrTxReferenceAmbiguous = errors.New("tx id and tx hash cannot be used together")
 var ErrTxNotFound = errors.New("transaction not found")
+var ErrReadPointAmbiguous = errors.New("at-tx, at-commit and as-of cannot be combined")
+var ErrHistoryUnavailable = errors.New("historical reads are not available")
+var ErrPreconditionAmbiguous = errors.New("if-match and if-none-match cannot be used together")
+var ErrPatchConflict = errors.New("patch no longer applies to the current document")
+var ErrRefNotFound = errors.New("ref not found")
+var ErrMergeRefRequired = errors.New("merge source ref is required")
+var ErrMergeRequiresHistory = errors.New("merge requires append history mode")
+var ErrMergeDeleted = errors.New("cannot merge a deleted document")
+var ErrSyncPathConflict = errors.New("path changed on both sides outside document streams")
+var ErrBatchEmpty = errors.New("batch has no operations")
+var ErrInvalidLimit = errors.New("limit must be zero or positive")
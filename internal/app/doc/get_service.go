@@ -11,22 +11,26 @@ import (
 
 type GetService struct {
 	store   ReadStore
+	history HistoryReadStore
 	decoder Decoder
 	hasher  Hasher
 	patcher Patcher
+	trust   TrustStore
 	layout  domain.StreamLayout
 }
 
-func NewGetService(store ReadStore, decoder Decoder, hasher Hasher, patcher Patcher, layout domain.StreamLayout) *GetService {
+func NewGetService(store ReadStore, history HistoryReadStore, decoder Decoder, hasher Hasher, patcher Patcher, trust TrustStore, layout domain.StreamLayout) *GetService {
 	if layout == "" {
 		layout = domain.StreamLayoutFlat
 	}
 	layout = domain.NormalizeStreamLayout(layout)
 	return &GetService{
 		store:   store,
+		history: history,
 		decoder: decoder,
 		hasher:  hasher,
 		patcher: patcher,
+		trust:   trust,
 		layout:  layout,
 	}
 }
@@ -62,14 +66,24 @@ func (s *GetService) Get(ctx context.Context, repoPath, collection, docID string
 		if err != nil {
 			return GetResult{}, err
 		}
+		if err := s.checkSigner(ctx, absRepoPath, stateTx); err != nil {
+			return GetResult{}, err
+		}
 		switch stateTx.Op {
 		case domain.TxOpDelete:
 			return GetResult{}, ErrDocDeleted
 		case domain.TxOpPut, domain.TxOpMerge:
 			if len(stateTx.Snapshot) > 0 {
+				headHash, err := s.store.LoadStreamHead(ctx, absRepoPath, streamPath)
+				if err != nil {
+					return GetResult{}, err
+				}
+				if headHash == "" {
+					headHash = s.hasher.SumHex(stateBlob.Bytes)
+				}
 				return GetResult{
 					Payload: stateTx.Snapshot,
-					TxHash:  s.hasher.SumHex(stateBlob.Bytes),
+					TxHash:  headHash,
 					TxID:    stateTx.TxID,
 					Op:      stateTx.Op,
 				}, nil
@@ -98,6 +112,9 @@ func (s *GetService) Get(ctx context.Context, repoPath, collection, docID string
 	if err != nil {
 		return GetResult{}, err
 	}
+	if err := s.checkSigner(ctx, absRepoPath, chain[0].Tx); err != nil {
+		return GetResult{}, err
+	}
 
 	doc, headTx, err := rehydrateChain(ctx, chain, s.patcher)
 	if err != nil {
@@ -111,3 +128,125 @@ func (s *GetService) Get(ctx context.Context, repoPath, collection, docID string
 		Op:      headTx.Op,
 	}, nil
 }
+
+// GetAt reads a document as it was at a transaction, a commit or a point in
+// time. With no read point set it behaves like Get.
+func (s *GetService) GetAt(ctx context.Context, repoPath, collection, docID string, opts ReadOptions) (GetResult, error) {
+	atTx := strings.TrimSpace(opts.AtTx)
+	atCommit := strings.TrimSpace(opts.AtCommit)
+	points := 0
+	for _, set := range []bool{atTx != "", atCommit != "", !opts.AsOf.IsZero()} {
+		if set {
+			points++
+		}
+	}
+	if points > 1 {
+		return GetResult{}, ErrReadPointAmbiguous
+	}
+	if points == 0 {
+		return s.Get(ctx, repoPath, collection, docID)
+	}
+
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return GetResult{}, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return GetResult{}, ErrInvalidCollection
+	}
+
+	docID = strings.TrimSpace(docID)
+	if docID == "" {
+		return GetResult{}, ErrDocIDRequired
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return GetResult{}, err
+	}
+	streamPath := domain.StreamPath(s.layout, collection, docID)
+
+	if atTx != "" {
+		txBlobs, err := s.store.LoadStreamTxs(ctx, absRepoPath, streamPath)
+		if err != nil {
+			return GetResult{}, err
+		}
+		if len(txBlobs) == 0 {
+			return GetResult{}, ErrDocNotFound
+		}
+		index, err := buildTxIndex(txBlobs, s.decoder, s.hasher)
+		if err != nil {
+			return GetResult{}, err
+		}
+		target, err := resolveTxRef(index, atTx)
+		if err != nil {
+			return GetResult{}, err
+		}
+		return s.rehydrateAt(ctx, absRepoPath, target, index, "")
+	}
+
+	if s.history == nil {
+		return GetResult{}, ErrHistoryUnavailable
+	}
+	if atCommit == "" {
+		atCommit, err = s.history.CommitAsOf(ctx, absRepoPath, opts.AsOf)
+		if err != nil {
+			return GetResult{}, err
+		}
+		if atCommit == "" {
+			return GetResult{}, ErrDocNotFound
+		}
+	}
+
+	headHash, err := s.history.LoadStreamHeadAt(ctx, absRepoPath, atCommit, streamPath)
+	if err != nil {
+		return GetResult{}, err
+	}
+	if headHash == "" {
+		return GetResult{}, ErrDocNotFound
+	}
+	txBlobs, err := s.history.LoadStreamTxsAt(ctx, absRepoPath, atCommit, streamPath)
+	if err != nil {
+		return GetResult{}, err
+	}
+	index, err := buildTxIndex(txBlobs, s.decoder, s.hasher)
+	if err != nil {
+		return GetResult{}, err
+	}
+	return s.rehydrateAt(ctx, absRepoPath, headHash, index, atCommit)
+}
+
+func (s *GetService) rehydrateAt(ctx context.Context, absRepoPath, headHash string, index map[string]txChainEntry, commitHash string) (GetResult, error) {
+	chain, err := buildTxChain(headHash, index)
+	if err != nil {
+		return GetResult{}, err
+	}
+	if err := s.checkSigner(ctx, absRepoPath, chain[0].Tx); err != nil {
+		return GetResult{}, err
+	}
+	doc, headTx, err := rehydrateChain(ctx, chain, s.patcher)
+	if err != nil {
+		return GetResult{}, err
+	}
+	return GetResult{
+		Payload:    doc,
+		TxHash:     headHash,
+		TxID:       headTx.TxID,
+		Op:         headTx.Op,
+		CommitHash: commitHash,
+	}, nil
+}
+
+// checkSigner applies the collection's trusted keys to the transaction a read
+// is served from. Each transaction commits to its parent's hash, so a trusted
+// head also vouches for the chain behind it.
+func (s *GetService) checkSigner(ctx context.Context, absRepoPath string, tx domain.Transaction) error {
+	if s.trust == nil {
+		return nil
+	}
+	keys, err := s.trust.LoadTrustedKeys(ctx, absRepoPath, tx.Collection)
+	if err != nil {
+		return err
+	}
+	return domain.CheckSigner(keys, tx)
+}
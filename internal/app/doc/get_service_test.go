@@ -3,7 +3,9 @@ package doc
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 )
@@ -57,7 +59,7 @@ func (f fakePatcher) Apply(ctx context.Context, doc, patch []byte) ([]byte, erro
 }
 
 func TestGetRequiresCollection(t *testing.T) {
-	service := NewGetService(fakeReadStore{}, fakeDecoder{}, fakeHasher{}, fakePatcher{}, domain.StreamLayoutFlat)
+	service := NewGetService(fakeReadStore{}, nil, fakeDecoder{}, fakeHasher{}, fakePatcher{}, nil, domain.StreamLayoutFlat)
 	_, err := service.Get(context.Background(), "repo", " ", "doc")
 	if !errors.Is(err, ErrCollectionRequired) {
 		t.Fatalf("expected ErrCollectionRequired, got %v", err)
@@ -65,7 +67,7 @@ func TestGetRequiresCollection(t *testing.T) {
 }
 
 func TestGetRejectsInvalidCollection(t *testing.T) {
-	service := NewGetService(fakeReadStore{}, fakeDecoder{}, fakeHasher{}, fakePatcher{}, domain.StreamLayoutFlat)
+	service := NewGetService(fakeReadStore{}, nil, fakeDecoder{}, fakeHasher{}, fakePatcher{}, nil, domain.StreamLayoutFlat)
 	_, err := service.Get(context.Background(), "repo", "users/..", "doc")
 	if !errors.Is(err, ErrInvalidCollection) {
 		t.Fatalf("expected ErrInvalidCollection, got %v", err)
@@ -73,7 +75,7 @@ func TestGetRejectsInvalidCollection(t *testing.T) {
 }
 
 func TestGetRequiresDocID(t *testing.T) {
-	service := NewGetService(fakeReadStore{}, fakeDecoder{}, fakeHasher{}, fakePatcher{}, domain.StreamLayoutFlat)
+	service := NewGetService(fakeReadStore{}, nil, fakeDecoder{}, fakeHasher{}, fakePatcher{}, nil, domain.StreamLayoutFlat)
 	_, err := service.Get(context.Background(), "repo", "users", " ")
 	if !errors.Is(err, ErrDocIDRequired) {
 		t.Fatalf("expected ErrDocIDRequired, got %v", err)
@@ -92,7 +94,7 @@ func TestGetReturnsPayload(t *testing.T) {
 			Snapshot: []byte(`{"a":1}`),
 		},
 	}
-	service := NewGetService(store, decoder, fakeHasher{sum: "hash1"}, fakePatcher{}, domain.StreamLayoutFlat)
+	service := NewGetService(store, nil, decoder, fakeHasher{sum: "hash1"}, fakePatcher{}, nil, domain.StreamLayoutFlat)
 
 	result, err := service.Get(context.Background(), "repo", "users", "doc")
 	if err != nil {
@@ -112,10 +114,102 @@ func TestGetDeleteReturnsError(t *testing.T) {
 		tx:       []TxBlob{{Bytes: []byte("tx")}},
 	}
 	decoder := fakeDecoder{tx: domain.Transaction{Op: domain.TxOpDelete}}
-	service := NewGetService(store, decoder, fakeHasher{sum: "hash1"}, fakePatcher{}, domain.StreamLayoutFlat)
+	service := NewGetService(store, nil, decoder, fakeHasher{sum: "hash1"}, fakePatcher{}, nil, domain.StreamLayoutFlat)
 
 	_, err := service.Get(context.Background(), "repo", "users", "doc")
 	if !errors.Is(err, ErrDocDeleted) {
 		t.Fatalf("expected ErrDocDeleted, got %v", err)
 	}
 }
+
+type fakeTrustStore map[string][]string
+
+func (f fakeTrustStore) LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return f[collection], nil
+}
+
+func TestGetAppliesTrustedKeys(t *testing.T) {
+	store := fakeReadStore{
+		headHash: "hash1",
+		tx:       []TxBlob{{Bytes: []byte("tx")}},
+	}
+	tx := domain.Transaction{TxID: "01H123", Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	trust := fakeTrustStore{"users": {"aa"}}
+
+	service := NewGetService(store, nil, fakeDecoder{tx: tx}, fakeHasher{sum: "hash1"}, fakePatcher{}, trust, domain.StreamLayoutFlat)
+	if _, err := service.Get(context.Background(), "repo", "users", "doc"); !errors.Is(err, domain.ErrUnsignedTx) {
+		t.Fatalf("expected ErrUnsignedTx, got %v", err)
+	}
+
+	tx.Signer = "bb"
+	service = NewGetService(store, nil, fakeDecoder{tx: tx}, fakeHasher{sum: "hash1"}, fakePatcher{}, trust, domain.StreamLayoutFlat)
+	if _, err := service.Get(context.Background(), "repo", "users", "doc"); !errors.Is(err, domain.ErrUntrustedSigner) {
+		t.Fatalf("expected ErrUntrustedSigner, got %v", err)
+	}
+
+	tx.Signer = "aa"
+	service = NewGetService(store, nil, fakeDecoder{tx: tx}, fakeHasher{sum: "hash1"}, fakePatcher{}, trust, domain.StreamLayoutFlat)
+	if result, err := service.Get(context.Background(), "repo", "users", "doc"); err != nil || string(result.Payload) != `{"a":1}` {
+		t.Fatalf("expected the trusted read to succeed, got %+v (%v)", result, err)
+	}
+}
+
+// historyMemStore serves snapshots of a memStore as commits.
+type historyMemStore struct {
+	refMemStore
+	asOf map[time.Time]string
+}
+
+func (h historyMemStore) CommitAsOf(ctx context.Context, repoPath string, asOf time.Time) (string, error) {
+	return h.asOf[asOf], nil
+}
+
+func TestGetAtReadsPastStates(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	ids := &seqIDGen{}
+	put := NewPutService(store, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, &tickClock{}, ids, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	first, err := put.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{})
+	if err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	snapshot := store.fork()
+	if _, err := put.Put(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	history := historyMemStore{refMemStore: refMemStore{"c1": snapshot}, asOf: map[time.Time]string{asOf: "c1"}}
+	service := NewGetService(store, history, jsonCodec{}, sha256Hasher{}, mergePatcher{}, nil, domain.StreamLayoutFlat)
+
+	tests := []struct {
+		name   string
+		opts   ReadOptions
+		commit string
+	}{
+		{name: "tx hash", opts: ReadOptions{AtTx: strings.ToUpper(first.TxHash)}},
+		{name: "tx id", opts: ReadOptions{AtTx: first.TxID}},
+		{name: "commit", opts: ReadOptions{AtCommit: "c1"}, commit: "c1"},
+		{name: "as of", opts: ReadOptions{AsOf: asOf}, commit: "c1"},
+	}
+	for _, tt := range tests {
+		result, err := service.GetAt(ctx, "repo", "users", "doc", tt.opts)
+		if err != nil {
+			t.Fatalf("%s: GetAt returned error: %v", tt.name, err)
+		}
+		if string(result.Payload) != `{"v":1}` || result.TxHash != first.TxHash || result.CommitHash != tt.commit {
+			t.Fatalf("%s: unexpected result: %+v", tt.name, result)
+		}
+	}
+
+	current, err := service.GetAt(ctx, "repo", "users", "doc", ReadOptions{})
+	if err != nil || string(current.Payload) != `{"v":2}` {
+		t.Fatalf("expected current state, got %s (%v)", current.Payload, err)
+	}
+	if _, err := service.GetAt(ctx, "repo", "users", "doc", ReadOptions{AsOf: asOf.Add(-time.Hour)}); !errors.Is(err, ErrDocNotFound) {
+		t.Fatalf("expected ErrDocNotFound before the first commit, got %v", err)
+	}
+	if _, err := service.GetAt(ctx, "repo", "users", "doc", ReadOptions{AtTx: first.TxID, AtCommit: "c1"}); !errors.Is(err, ErrReadPointAmbiguous) {
+		t.Fatalf("expected ErrReadPointAmbiguous, got %v", err)
+	}
+}
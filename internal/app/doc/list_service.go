@@ -0,0 +1,95 @@
+package doc
+
+import (
+	"context"
+	"iter"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type ListService struct {
+	scanner StateScanner
+	decoder Decoder
+}
+
+func NewListService(scanner StateScanner, decoder Decoder) *ListService {
+	return &ListService{
+		scanner: scanner,
+		decoder: decoder,
+	}
+}
+
+// Scan streams the documents of a collection in stream-hash order. Entries
+// are read from the state subtree, so no index is required. Limit is ignored.
+func (s *ListService) Scan(ctx context.Context, repoPath, collection string, opts ListOptions) iter.Seq2[ListEntry, error] {
+	return func(yield func(ListEntry, error) bool) {
+		collection = strings.TrimSpace(collection)
+		if collection == "" {
+			yield(ListEntry{}, ErrCollectionRequired)
+			return
+		}
+		if !domain.IsValidCollectionName(collection) {
+			yield(ListEntry{}, ErrInvalidCollection)
+			return
+		}
+		absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+		if err != nil {
+			yield(ListEntry{}, err)
+			return
+		}
+
+		for state, err := range s.scanner.ScanStates(ctx, absRepoPath, collection, strings.TrimSpace(opts.After)) {
+			if err != nil {
+				yield(ListEntry{}, err)
+				return
+			}
+			tx, err := s.decoder.Decode(state.Blob.Bytes)
+			if err != nil {
+				yield(ListEntry{}, err)
+				return
+			}
+			if !strings.HasPrefix(tx.DocID, opts.Prefix) {
+				continue
+			}
+			entry := ListEntry{
+				DocID:   tx.DocID,
+				TxID:    tx.TxID,
+				Op:      tx.Op,
+				Deleted: tx.Op == domain.TxOpDelete,
+				Cursor:  state.Key,
+			}
+			if entry.Deleted && !opts.IncludeDeleted {
+				continue
+			}
+			if !entry.Deleted {
+				entry.Payload = tx.Snapshot
+			}
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+// List returns one page of Scan. NextCursor is set when more entries remain
+// and can be passed back as After.
+func (s *ListService) List(ctx context.Context, repoPath, collection string, opts ListOptions) (ListResult, error) {
+	if opts.Limit < 0 {
+		return ListResult{}, ErrInvalidLimit
+	}
+
+	var result ListResult
+	for entry, err := range s.Scan(ctx, repoPath, collection, opts) {
+		if err != nil {
+			return ListResult{}, err
+		}
+		if opts.Limit > 0 && len(result.Entries) == opts.Limit {
+			result.NextCursor = result.Entries[len(result.Entries)-1].Cursor
+			break
+		}
+		result.Entries = append(result.Entries, entry)
+	}
+	return result, nil
+}
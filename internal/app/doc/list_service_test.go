@@ -0,0 +1,69 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestListPagesThroughCollection(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	clock := &tickClock{}
+	ids := &seqIDGen{}
+	put := NewPutService(store, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, clock, ids, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	for _, docID := range []string{"usr_1", "usr_2", "usr_3", "org_1"} {
+		if _, err := put.Put(ctx, "repo", "users", docID, []byte(`{"id":"`+docID+`"}`), WriteOptions{}); err != nil {
+			t.Fatalf("Put returned error: %v", err)
+		}
+	}
+	if _, err := put.Put(ctx, "repo", "orgs", "usr_9", []byte(`{}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	del := NewDeleteService(store, store, jsonCodec{}, jsonCodec{}, sha256Hasher{}, clock, ids, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	if _, err := del.Delete(ctx, "repo", "users", "usr_2", WriteOptions{}); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+
+	service := NewListService(store, jsonCodec{})
+	collect := func(opts ListOptions) map[string]ListEntry {
+		seen := make(map[string]ListEntry)
+		for {
+			page, err := service.List(ctx, "repo", "users", opts)
+			if err != nil {
+				t.Fatalf("List returned error: %v", err)
+			}
+			if len(page.Entries) > opts.Limit {
+				t.Fatalf("page exceeds limit: %d", len(page.Entries))
+			}
+			for _, entry := range page.Entries {
+				if _, dup := seen[entry.DocID]; dup {
+					t.Fatalf("duplicate entry %s across pages", entry.DocID)
+				}
+				seen[entry.DocID] = entry
+			}
+			if page.NextCursor == "" {
+				return seen
+			}
+			opts.After = page.NextCursor
+		}
+	}
+
+	live := collect(ListOptions{Prefix: "usr_", Limit: 1})
+	if len(live) != 2 || string(live["usr_1"].Payload) != `{"id":"usr_1"}` || live["usr_3"].TxID == "" {
+		t.Fatalf("unexpected live entries: %+v", live)
+	}
+	all := collect(ListOptions{Limit: 2, IncludeDeleted: true})
+	if len(all) != 4 || !all["usr_2"].Deleted || all["usr_2"].Payload != nil {
+		t.Fatalf("unexpected entries with deleted: %+v", all)
+	}
+
+	if _, err := service.List(ctx, "repo", "users", ListOptions{Limit: -1}); !errors.Is(err, ErrInvalidLimit) {
+		t.Fatalf("expected ErrInvalidLimit, got %v", err)
+	}
+	if _, err := service.List(ctx, "repo", "", ListOptions{}); !errors.Is(err, ErrCollectionRequired) {
+		t.Fatalf("expected ErrCollectionRequired, got %v", err)
+	}
+}
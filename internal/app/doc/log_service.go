@@ -74,11 +74,12 @@ func (s *LogService) Log(ctx context.Context, repoPath, collection, docID string
 	ordered := make([]LogEntry, 0, len(chain))
 	for _, entry := range chain {
 		ordered = append(ordered, LogEntry{
-			TxID:       entry.Tx.TxID,
-			TxHash:     entry.Hash,
-			ParentHash: entry.Tx.ParentHash,
-			Timestamp:  entry.Tx.Timestamp,
-			Op:         entry.Tx.Op,
+			TxID:         entry.Tx.TxID,
+			TxHash:       entry.Hash,
+			ParentHash:   entry.Tx.ParentHash,
+			MergeParents: entry.Tx.MergeParents,
+			Timestamp:    entry.Tx.Timestamp,
+			Op:           entry.Tx.Op,
 		})
 	}
 
@@ -0,0 +1,245 @@
+package doc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// memStore is an in-memory ledger used by tests that need real hash chains.
+type memStore struct {
+	mu      sync.Mutex
+	streams map[string][]TxBlob
+	heads   map[string]TxBlob
+	commits int
+	amend   bool
+}
+
+func newMemStore() *memStore {
+	return &memStore{
+		streams: make(map[string][]TxBlob),
+		heads:   make(map[string]TxBlob),
+	}
+}
+
+func (m *memStore) LoadStreamHead(ctx context.Context, repoPath, streamPath string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	head, ok := m.heads[streamPath]
+	if !ok {
+		return "", nil
+	}
+	return sha256Hasher{}.SumHex(head.Bytes), nil
+}
+
+func (m *memStore) LoadHeadTx(ctx context.Context, repoPath, streamPath string) (TxBlob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	head, ok := m.heads[streamPath]
+	if !ok {
+		return TxBlob{}, ErrDocNotFound
+	}
+	return head, nil
+}
+
+func (m *memStore) LoadStreamTxs(ctx context.Context, repoPath, streamPath string) ([]TxBlob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]TxBlob(nil), m.streams[streamPath]...), nil
+}
+
+func (m *memStore) PutTx(ctx context.Context, write TxWrite) (PutResult, error) {
+	commit, err := m.PutTxs(ctx, write.RepoPath, []TxWrite{write})
+	if err != nil {
+		return PutResult{}, err
+	}
+	return PutResult{CommitHash: commit, TxHash: write.TxHash, TxID: write.Tx.TxID}, nil
+}
+
+func (m *memStore) PutTxs(ctx context.Context, repoPath string, writes []TxWrite) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	staged := make(map[string]string)
+	for _, write := range writes {
+		current, ok := staged[write.StreamPath]
+		if !ok {
+			if head, exists := m.heads[write.StreamPath]; exists {
+				current = sha256Hasher{}.SumHex(head.Bytes)
+			}
+		}
+		if !m.amend && current != write.Tx.ParentHash {
+			return "", domain.ErrHeadChanged
+		}
+		if write.IfMatch != "" && current != write.IfMatch {
+			return "", domain.ErrHeadChanged
+		}
+		if write.IfNoneMatch && current != "" {
+			return "", domain.ErrHeadChanged
+		}
+		staged[write.StreamPath] = write.TxHash
+	}
+
+	for _, write := range writes {
+		for _, imported := range write.ImportTxs {
+			if !m.hasTx(write.StreamPath, imported.Bytes) {
+				m.streams[write.StreamPath] = append(m.streams[write.StreamPath], imported)
+			}
+		}
+		blob := TxBlob{Path: write.StreamPath + "/" + write.Tx.TxID, Bytes: write.TxBytes}
+		if m.amend {
+			m.streams[write.StreamPath] = []TxBlob{blob}
+		} else {
+			m.streams[write.StreamPath] = append(m.streams[write.StreamPath], blob)
+		}
+		m.heads[write.StreamPath] = blob
+		if write.StatePath != "" {
+			m.heads[write.StatePath] = TxBlob{Path: write.StatePath, Bytes: write.StateTxBytes}
+		}
+	}
+	m.commits++
+	return fmt.Sprintf("commit%d", m.commits), nil
+}
+
+func (m *memStore) ScanStates(ctx context.Context, repoPath, collection, after string) iter.Seq2[StateEntry, error] {
+	m.mu.Lock()
+	var entries []StateEntry
+	root := path.Join(domain.StateRoot, collection) + "/"
+	for statePath, blob := range m.heads {
+		key, ok := strings.CutPrefix(path.Base(statePath), "DOC_")
+		if ok && strings.HasPrefix(statePath, root) && key > after {
+			entries = append(entries, StateEntry{Key: key, Blob: blob})
+		}
+	}
+	m.mu.Unlock()
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	return func(yield func(StateEntry, error) bool) {
+		for _, entry := range entries {
+			if !yield(entry, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (m *memStore) hasTx(streamPath string, data []byte) bool {
+	for _, blob := range m.streams[streamPath] {
+		if string(blob.Bytes) == string(data) {
+			return true
+		}
+	}
+	return false
+}
+
+// fork copies the ledger so tests can diverge two replicas from a shared base.
+func (m *memStore) fork() *memStore {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clone := newMemStore()
+	clone.amend = m.amend
+	for path, blobs := range m.streams {
+		clone.streams[path] = append([]TxBlob(nil), blobs...)
+	}
+	for path, head := range m.heads {
+		clone.heads[path] = head
+	}
+	return clone
+}
+
+// refMemStore serves other replicas as named refs.
+type refMemStore map[string]*memStore
+
+func (r refMemStore) LoadStreamHeadAt(ctx context.Context, repoPath, ref, streamPath string) (string, error) {
+	store, ok := r[ref]
+	if !ok {
+		return "", ErrRefNotFound
+	}
+	return store.LoadStreamHead(ctx, repoPath, streamPath)
+}
+
+func (r refMemStore) LoadStreamTxsAt(ctx context.Context, repoPath, ref, streamPath string) ([]TxBlob, error) {
+	store, ok := r[ref]
+	if !ok {
+		return nil, ErrRefNotFound
+	}
+	return store.LoadStreamTxs(ctx, repoPath, streamPath)
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Encode(tx domain.Transaction) ([]byte, error) {
+	return json.Marshal(tx)
+}
+
+func (jsonCodec) Decode(data []byte) (domain.Transaction, error) {
+	var tx domain.Transaction
+	err := json.Unmarshal(data, &tx)
+	return tx, err
+}
+
+type sha256Hasher struct{}
+
+func (sha256Hasher) SumHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// mergePatcher applies a JSON object as a shallow merge patch.
+type mergePatcher struct{}
+
+func (mergePatcher) Apply(ctx context.Context, doc, patch []byte) ([]byte, error) {
+	var base map[string]any
+	if err := json.Unmarshal(doc, &base); err != nil {
+		return nil, err
+	}
+	var delta map[string]any
+	if err := json.Unmarshal(patch, &delta); err != nil {
+		return nil, err
+	}
+	for key, value := range delta {
+		if strings.HasPrefix(key, "-") {
+			if _, ok := base[key[1:]]; !ok {
+				return nil, fmt.Errorf("missing field %s", key[1:])
+			}
+			delete(base, key[1:])
+			continue
+		}
+		base[key] = value
+	}
+	return json.Marshal(base)
+}
+
+type tickClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *tickClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(time.Millisecond)
+	return c.now
+}
+
+type seqIDGen struct {
+	mu   sync.Mutex
+	next int
+}
+
+func (g *seqIDGen) NewID() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.next++
+	return fmt.Sprintf("tx%04d", g.next), nil
+}
@@ -0,0 +1,253 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type MergeService struct {
+	writeStore    WriteStore
+	readStore     ReadStore
+	refStore      RefReadStore
+	canonicalizer Canonicalizer
+	encoder       Encoder
+	decoder       Decoder
+	patcher       Patcher
+	merger        Merger
+	hasher        Hasher
+	clock         Clock
+	idGen         IDGenerator
+	schemas       SchemaStore
+	validator     DocumentValidator
+	layout        domain.StreamLayout
+	historyMode   domain.HistoryMode
+}
+
+func NewMergeService(writeStore WriteStore, readStore ReadStore, refStore RefReadStore, canonicalizer Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, merger Merger, hasher Hasher, clock Clock, idGen IDGenerator, schemas SchemaStore, validator DocumentValidator, layout domain.StreamLayout, historyMode domain.HistoryMode) *MergeService {
+	if layout == "" {
+		layout = domain.StreamLayoutFlat
+	}
+	layout = domain.NormalizeStreamLayout(layout)
+	historyMode = domain.NormalizeHistoryMode(historyMode)
+	return &MergeService{
+		writeStore:    writeStore,
+		readStore:     readStore,
+		refStore:      refStore,
+		canonicalizer: canonicalizer,
+		encoder:       encoder,
+		decoder:       decoder,
+		patcher:       patcher,
+		merger:        merger,
+		hasher:        hasher,
+		clock:         clock,
+		idGen:         idGen,
+		schemas:       schemas,
+		validator:     validator,
+		layout:        layout,
+		historyMode:   historyMode,
+	}
+}
+
+func (s *MergeService) Merge(ctx context.Context, repoPath, collection, docID string, opts MergeOptions) (MergeResult, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return MergeResult{}, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return MergeResult{}, ErrInvalidCollection
+	}
+
+	docID = strings.TrimSpace(docID)
+	if docID == "" {
+		return MergeResult{}, ErrDocIDRequired
+	}
+
+	theirsRef := strings.TrimSpace(opts.Theirs)
+	if theirsRef == "" {
+		return MergeResult{}, ErrMergeRefRequired
+	}
+	if s.historyMode == domain.HistoryModeAmend {
+		return MergeResult{}, ErrMergeRequiresHistory
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return MergeResult{}, err
+	}
+
+	write, result, err := s.Plan(ctx, absRepoPath, collection, docID, theirsRef)
+	if err != nil || result.UpToDate {
+		return result, err
+	}
+
+	putResult, err := s.writeStore.PutTx(ctx, write)
+	if err != nil {
+		return MergeResult{}, err
+	}
+	if putResult.TxHash == "" {
+		putResult.TxHash = write.TxHash
+	}
+	if putResult.TxID == "" {
+		putResult.TxID = write.Tx.TxID
+	}
+	result.PutResult = putResult
+	return result, nil
+}
+
+// Plan builds the merge transaction for one stream without writing it, so
+// callers such as sync can commit several merges together.
+func (s *MergeService) Plan(ctx context.Context, absRepoPath, collection, docID, theirsRef string) (TxWrite, MergeResult, error) {
+	streamPath := domain.StreamPath(s.layout, collection, docID)
+	oursHead, err := s.readStore.LoadStreamHead(ctx, absRepoPath, streamPath)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	theirsHead, err := s.refStore.LoadStreamHeadAt(ctx, absRepoPath, theirsRef, streamPath)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+
+	result := MergeResult{OursHash: oursHead, TheirsHash: theirsHead}
+	if theirsHead == "" || theirsHead == oursHead {
+		result.UpToDate = true
+		return TxWrite{}, result, nil
+	}
+
+	var oursTxs []TxBlob
+	if oursHead != "" {
+		oursTxs, err = s.readStore.LoadStreamTxs(ctx, absRepoPath, streamPath)
+		if err != nil {
+			return TxWrite{}, MergeResult{}, err
+		}
+	}
+	theirsTxs, err := s.refStore.LoadStreamTxsAt(ctx, absRepoPath, theirsRef, streamPath)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+
+	index, err := buildTxIndex(oursTxs, s.decoder, s.hasher)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	var imports []TxBlob
+	for _, blob := range theirsTxs {
+		hash := s.hasher.SumHex(blob.Bytes)
+		if _, ok := index[hash]; ok {
+			continue
+		}
+		tx, err := s.decoder.Decode(blob.Bytes)
+		if err != nil {
+			return TxWrite{}, MergeResult{}, err
+		}
+		index[hash] = txChainEntry{Hash: hash, Tx: tx}
+		imports = append(imports, blob)
+	}
+
+	if oursHead != "" {
+		result.BaseHash = findMergeBase(oursHead, theirsHead, index)
+		if result.BaseHash == theirsHead {
+			result.UpToDate = true
+			return TxWrite{}, result, nil
+		}
+	}
+
+	baseDoc, err := s.docAt(ctx, result.BaseHash, index)
+	if err != nil && !errors.Is(err, ErrDocDeleted) {
+		return TxWrite{}, MergeResult{}, err
+	}
+	oursDoc, err := s.docAt(ctx, oursHead, index)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, mergeSideErr(err)
+	}
+	theirsDoc, err := s.docAt(ctx, theirsHead, index)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, mergeSideErr(err)
+	}
+
+	outcome, err := s.merger.Merge(ctx, baseDoc, oursDoc, theirsDoc)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	merged, err := s.canonicalizer.Canonicalize(ctx, outcome.Doc)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	result.Conflicts = outcome.Conflicts
+
+	// Conflicting documents carry _conflicts until resolved, so the schema is
+	// only enforced on clean merges.
+	var schemaVersion string
+	if len(outcome.Conflicts) == 0 {
+		schemaVersion, err = enforceSchema(ctx, s.schemas, s.validator, s.hasher, absRepoPath, collection, merged)
+		if err != nil {
+			return TxWrite{}, MergeResult{}, err
+		}
+	}
+
+	txID, err := s.idGen.NewID()
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	tx := domain.Transaction{
+		TxID:          txID,
+		Timestamp:     s.clock.Now().UnixNano(),
+		Collection:    collection,
+		DocID:         docID,
+		Op:            domain.TxOpMerge,
+		Snapshot:      merged,
+		ParentHash:    oursHead,
+		MergeParents:  []string{theirsHead},
+		SchemaVersion: schemaVersion,
+	}
+	encoded, err := s.encoder.Encode(tx)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+	txHash := s.hasher.SumHex(encoded)
+
+	stateTx := tx
+	stateTx.ParentHash = ""
+	stateTx.MergeParents = nil
+	stateEncoded, err := s.encoder.Encode(stateTx)
+	if err != nil {
+		return TxWrite{}, MergeResult{}, err
+	}
+
+	result.TxHash = txHash
+	result.TxID = txID
+	return TxWrite{
+		RepoPath:     absRepoPath,
+		StreamPath:   streamPath,
+		TxBytes:      encoded,
+		TxHash:       txHash,
+		Tx:           tx,
+		StatePath:    domain.StatePath(s.layout, collection, docID),
+		StateTxBytes: stateEncoded,
+		StateTxHash:  s.hasher.SumHex(stateEncoded),
+		StateTx:      stateTx,
+		ImportTxs:    imports,
+	}, result, nil
+}
+
+func (s *MergeService) docAt(ctx context.Context, head string, index map[string]txChainEntry) ([]byte, error) {
+	if head == "" {
+		return nil, nil
+	}
+	chain, err := buildTxChain(head, index)
+	if err != nil {
+		return nil, err
+	}
+	doc, _, err := rehydrateChain(ctx, chain, s.patcher)
+	return doc, err
+}
+
+func mergeSideErr(err error) error {
+	if errors.Is(err, ErrDocDeleted) {
+		return ErrMergeDeleted
+	}
+	return err
+}
@@ -0,0 +1,140 @@
+package doc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// shallowMerger resolves top-level fields the way a 3-way merger would.
+type shallowMerger struct{}
+
+func (shallowMerger) Merge(ctx context.Context, base, ours, theirs []byte) (MergeOutcome, error) {
+	var b, o, t map[string]any
+	_ = json.Unmarshal(base, &b)
+	if err := json.Unmarshal(ours, &o); err != nil {
+		return MergeOutcome{}, err
+	}
+	if err := json.Unmarshal(theirs, &t); err != nil {
+		return MergeOutcome{}, err
+	}
+	var conflicts []string
+	for key, value := range t {
+		switch {
+		case reflect.DeepEqual(o[key], value), reflect.DeepEqual(b[key], value):
+		case reflect.DeepEqual(b[key], o[key]):
+			o[key] = value
+		default:
+			conflicts = append(conflicts, "/"+key)
+		}
+	}
+	out, err := json.Marshal(o)
+	return MergeOutcome{Doc: out, Conflicts: conflicts}, err
+}
+
+type mergeFixture struct {
+	local  *memStore
+	remote *memStore
+	merge  *MergeService
+	get    *GetService
+	patch  func(store *memStore, payload string)
+}
+
+func newMergeFixture(t *testing.T) mergeFixture {
+	t.Helper()
+	ctx := context.Background()
+	local := newMemStore()
+	clock := &tickClock{}
+	ids := &seqIDGen{}
+	put := NewPutService(local, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, clock, ids, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	if _, err := put.Put(ctx, "repo", "users", "doc", []byte(`{"a":1,"b":1,"s":"x"}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	remote := local.fork()
+
+	return mergeFixture{
+		local:  local,
+		remote: remote,
+		merge:  NewMergeService(local, local, refMemStore{"origin": remote}, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, shallowMerger{}, sha256Hasher{}, clock, ids, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend),
+		get:    NewGetService(local, nil, jsonCodec{}, sha256Hasher{}, mergePatcher{}, nil, domain.StreamLayoutFlat),
+		patch: func(store *memStore, payload string) {
+			svc := NewPatchService(store, store, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, sha256Hasher{}, clock, ids, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+			if _, err := svc.Patch(ctx, "repo", "users", "doc", []byte(payload), WriteOptions{}); err != nil {
+				t.Fatalf("Patch returned error: %v", err)
+			}
+		},
+	}
+}
+
+func TestMergeCombinesDivergentHeads(t *testing.T) {
+	ctx := context.Background()
+	f := newMergeFixture(t)
+	streamPath := domain.StreamPath(domain.StreamLayoutFlat, "users", "doc")
+	baseHead, _ := f.local.LoadStreamHead(ctx, "repo", streamPath)
+	f.patch(f.local, `{"a":2}`)
+	f.patch(f.remote, `{"b":2}`)
+
+	result, err := f.merge.Merge(ctx, "repo", "users", "doc", MergeOptions{Theirs: "origin"})
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if result.UpToDate || len(result.Conflicts) != 0 {
+		t.Fatalf("unexpected merge result: %+v", result)
+	}
+
+	if result.BaseHash != baseHead {
+		t.Fatalf("expected base %s, got %s", baseHead, result.BaseHash)
+	}
+	head, _ := f.local.LoadHeadTx(ctx, "repo", streamPath)
+	tx, _ := jsonCodec{}.Decode(head.Bytes)
+	if tx.Op != domain.TxOpMerge || tx.ParentHash != result.OursHash || !reflect.DeepEqual(tx.MergeParents, []string{result.TheirsHash}) {
+		t.Fatalf("unexpected merge tx: %+v", tx)
+	}
+	txs, _ := f.local.LoadStreamTxs(ctx, "repo", streamPath)
+	if len(txs) != 4 {
+		t.Fatalf("expected theirs tx to be imported (4 txs), got %d", len(txs))
+	}
+
+	got, err := f.get.Get(ctx, "repo", "users", "doc")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if string(got.Payload) != `{"a":2,"b":2,"s":"x"}` {
+		t.Fatalf("unexpected merged doc: %s", got.Payload)
+	}
+
+	again, err := f.merge.Merge(ctx, "repo", "users", "doc", MergeOptions{Theirs: "origin"})
+	if err != nil {
+		t.Fatalf("second Merge returned error: %v", err)
+	}
+	if !again.UpToDate {
+		t.Fatalf("expected second merge to be up to date, got %+v", again)
+	}
+}
+
+func TestMergeReportsConflicts(t *testing.T) {
+	ctx := context.Background()
+	f := newMergeFixture(t)
+	f.patch(f.local, `{"s":"ours"}`)
+	f.patch(f.remote, `{"s":"theirs"}`)
+
+	result, err := f.merge.Merge(ctx, "repo", "users", "doc", MergeOptions{Theirs: "origin"})
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if !reflect.DeepEqual(result.Conflicts, []string{"/s"}) {
+		t.Fatalf("expected conflict on /s, got %v", result.Conflicts)
+	}
+}
+
+func TestMergeRequiresRef(t *testing.T) {
+	f := newMergeFixture(t)
+	_, err := f.merge.Merge(context.Background(), "repo", "users", "doc", MergeOptions{})
+	if !errors.Is(err, ErrMergeRefRequired) {
+		t.Fatalf("expected ErrMergeRefRequired, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// racingStore lets a competing writer land right before the next PutTx.
+type racingStore struct {
+	*memStore
+	race func()
+}
+
+func (r *racingStore) PutTx(ctx context.Context, write TxWrite) (PutResult, error) {
+	if race := r.race; race != nil {
+		r.race = nil
+		race()
+	}
+	return r.memStore.PutTx(ctx, write)
+}
+
+func newRetryFixture(t *testing.T, mode domain.HistoryMode, retries int) (*racingStore, *PatchService, *PutService) {
+	t.Helper()
+	store := &racingStore{memStore: newMemStore()}
+	store.amend = mode == domain.HistoryModeAmend
+	putSvc := NewPutService(store.memStore, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, &tickClock{}, &seqIDGen{}, nil, nil, domain.StreamLayoutFlat, mode)
+	patchSvc := NewPatchService(store, store, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, sha256Hasher{}, &tickClock{}, &seqIDGen{next: 100}, nil, nil, RetryPolicy{MaxRetries: retries}, domain.StreamLayoutFlat, mode)
+	if _, err := putSvc.Put(context.Background(), "repo", "users", "doc", []byte(`{"a":1,"b":1}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	return store, patchSvc, putSvc
+}
+
+func TestPatchRebasesOnConcurrentWrite(t *testing.T) {
+	for _, mode := range []domain.HistoryMode{domain.HistoryModeAppend, domain.HistoryModeAmend} {
+		t.Run(string(mode), func(t *testing.T) {
+			ctx := context.Background()
+			store, patchSvc, putSvc := newRetryFixture(t, mode, 3)
+			store.race = func() {
+				if _, err := putSvc.Put(ctx, "repo", "users", "doc", []byte(`{"a":1,"b":2}`), WriteOptions{}); err != nil {
+					t.Fatalf("competing Put returned error: %v", err)
+				}
+			}
+
+			if _, err := patchSvc.Patch(ctx, "repo", "users", "doc", []byte(`{"a":5}`), WriteOptions{}); err != nil {
+				t.Fatalf("Patch returned error: %v", err)
+			}
+
+			got, err := NewGetService(store, nil, jsonCodec{}, sha256Hasher{}, mergePatcher{}, nil, domain.StreamLayoutFlat).Get(ctx, "repo", "users", "doc")
+			if err != nil {
+				t.Fatalf("Get returned error: %v", err)
+			}
+			if string(got.Payload) != `{"a":5,"b":2}` {
+				t.Fatalf("expected rebased document, got %s", got.Payload)
+			}
+		})
+	}
+}
+
+func TestPatchReportsConflictWhenRebaseFails(t *testing.T) {
+	ctx := context.Background()
+	store, patchSvc, putSvc := newRetryFixture(t, domain.HistoryModeAppend, 3)
+	store.race = func() {
+		if _, err := putSvc.Put(ctx, "repo", "users", "doc", []byte(`{"a":1}`), WriteOptions{}); err != nil {
+			t.Fatalf("competing Put returned error: %v", err)
+		}
+	}
+
+	_, err := patchSvc.Patch(ctx, "repo", "users", "doc", []byte(`{"-b":null}`), WriteOptions{})
+	if !errors.Is(err, ErrPatchConflict) {
+		t.Fatalf("expected ErrPatchConflict, got %v", err)
+	}
+}
+
+func TestPatchWithoutRetriesSurfacesHeadChanged(t *testing.T) {
+	ctx := context.Background()
+	store, patchSvc, putSvc := newRetryFixture(t, domain.HistoryModeAppend, 0)
+	store.race = func() {
+		if _, err := putSvc.Put(ctx, "repo", "users", "doc", []byte(`{"a":2}`), WriteOptions{}); err != nil {
+			t.Fatalf("competing Put returned error: %v", err)
+		}
+	}
+
+	_, err := patchSvc.Patch(ctx, "repo", "users", "doc", []byte(`{"a":5}`), WriteOptions{})
+	if !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package doc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
@@ -19,11 +20,14 @@ type PatchService struct {
 	hasher        Hasher
 	clock         Clock
 	idGen         IDGenerator
+	schemas       SchemaStore
+	validator     DocumentValidator
+	retry         RetryPolicy
 	layout        domain.StreamLayout
 	historyMode   domain.HistoryMode
 }
 
-func NewPatchService(writeStore WriteStore, readStore ReadStore, canonicalizer Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, hasher Hasher, clock Clock, idGen IDGenerator, layout domain.StreamLayout, historyMode domain.HistoryMode) *PatchService {
+func NewPatchService(writeStore WriteStore, readStore ReadStore, canonicalizer Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, hasher Hasher, clock Clock, idGen IDGenerator, schemas SchemaStore, validator DocumentValidator, retry RetryPolicy, layout domain.StreamLayout, historyMode domain.HistoryMode) *PatchService {
 	if layout == "" {
 		layout = domain.StreamLayoutFlat
 	}
@@ -39,12 +43,15 @@ func NewPatchService(writeStore WriteStore, readStore ReadStore, canonicalizer C
 		hasher:        hasher,
 		clock:         clock,
 		idGen:         idGen,
+		schemas:       schemas,
+		validator:     validator,
+		retry:         retry,
 		layout:        layout,
 		historyMode:   historyMode,
 	}
 }
 
-func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID string, patch []byte) (PutResult, error) {
+func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID string, patch []byte, opts WriteOptions) (PutResult, error) {
 	collection = strings.TrimSpace(collection)
 	if collection == "" {
 		return PutResult{}, ErrCollectionRequired
@@ -62,16 +69,48 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 		return PutResult{}, ErrPayloadRequired
 	}
 
+	opts, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return PutResult{}, err
+	}
+
 	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
 	if err != nil {
 		return PutResult{}, err
 	}
 
+	canonicalPatch, err := s.canonicalizer.Canonicalize(ctx, patch)
+	if err != nil {
+		return PutResult{}, err
+	}
+
+	retries := s.retry.MaxRetries
+	if opts.hasPrecondition() || retries < 0 {
+		retries = 0
+	}
+	for attempt := 0; ; attempt++ {
+		result, err := s.patchOnce(ctx, absRepoPath, collection, docID, canonicalPatch, opts)
+		if attempt > 0 && isPatchRebaseFailure(err) {
+			return PutResult{}, fmt.Errorf("%w: %w", ErrPatchConflict, err)
+		}
+		if !errors.Is(err, domain.ErrHeadChanged) || attempt >= retries {
+			return result, err
+		}
+		if err := s.retry.wait(ctx, attempt); err != nil {
+			return PutResult{}, err
+		}
+	}
+}
+
+func (s *PatchService) patchOnce(ctx context.Context, absRepoPath, collection, docID string, canonicalPatch []byte, opts WriteOptions) (PutResult, error) {
 	streamPath := domain.StreamPath(s.layout, collection, docID)
 	headHash, err := s.readStore.LoadStreamHead(ctx, absRepoPath, streamPath)
 	if err != nil {
 		return PutResult{}, err
 	}
+	if err := checkExpectedHead(headHash, opts); err != nil {
+		return PutResult{}, err
+	}
 	if headHash == "" {
 		return PutResult{}, ErrDocNotFound
 	}
@@ -81,15 +120,15 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 		return PutResult{}, err
 	}
 
-	canonicalPatch, err := s.canonicalizer.Canonicalize(ctx, patch)
-	if err != nil {
-		return PutResult{}, err
-	}
-
 	if s.patcher == nil {
 		return PutResult{}, ErrPatchUnsupported
 	}
 	updatedDoc, err := s.patcher.Apply(ctx, currentDoc, canonicalPatch)
+	if err != nil {
+		return PutResult{}, &patchApplyError{err: err}
+	}
+
+	schemaVersion, err := enforceSchema(ctx, s.schemas, s.validator, s.hasher, absRepoPath, collection, updatedDoc)
 	if err != nil {
 		return PutResult{}, err
 	}
@@ -100,11 +139,13 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 	}
 
 	tx := domain.Transaction{
-		TxID:       txID,
-		Timestamp:  s.clock.Now().UnixNano(),
-		Collection: collection,
-		DocID:      docID,
+		TxID:          txID,
+		Timestamp:     s.clock.Now().UnixNano(),
+		Collection:    collection,
+		DocID:         docID,
+		SchemaVersion: schemaVersion,
 	}
+	ifMatch := opts.IfMatch
 	if s.historyMode == domain.HistoryModeAmend {
 		snapshot, err := s.canonicalizer.Canonicalize(ctx, updatedDoc)
 		if err != nil {
@@ -112,6 +153,9 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 		}
 		tx.Op = domain.TxOpMerge
 		tx.Snapshot = snapshot
+		if ifMatch == "" {
+			ifMatch = headHash
+		}
 	} else {
 		tx.Op = domain.TxOpPatch
 		tx.Patch = canonicalPatch
@@ -138,6 +182,8 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 		StateTxBytes: stateEncoded,
 		StateTxHash:  stateHash,
 		StateTx:      stateTx,
+		IfMatch:      ifMatch,
+		IfNoneMatch:  opts.IfNoneMatch,
 	})
 	if err != nil {
 		return PutResult{}, err
@@ -154,44 +200,7 @@ func (s *PatchService) Patch(ctx context.Context, repoPath, collection, docID st
 }
 
 func (s *PatchService) loadCurrentDoc(ctx context.Context, repoPath, collection, docID, streamPath, headHash string) ([]byte, error) {
-	statePath := domain.StatePath(s.layout, collection, docID)
-	stateBlob, err := s.readStore.LoadHeadTx(ctx, repoPath, statePath)
-	if err != nil && !errors.Is(err, ErrDocNotFound) {
-		return nil, err
-	}
-	if err == nil && len(stateBlob.Bytes) > 0 {
-		stateTx, err := s.decoder.Decode(stateBlob.Bytes)
-		if err == nil {
-			switch stateTx.Op {
-			case domain.TxOpDelete:
-				return nil, ErrDocDeleted
-			case domain.TxOpPut, domain.TxOpMerge:
-				if len(stateTx.Snapshot) > 0 {
-					return stateTx.Snapshot, nil
-				}
-			}
-		}
-	}
-
-	txBlobs, err := s.readStore.LoadStreamTxs(ctx, repoPath, streamPath)
-	if err != nil {
-		return nil, err
-	}
-
-	index, err := buildTxIndex(txBlobs, s.decoder, s.hasher)
-	if err != nil {
-		return nil, err
-	}
-	chain, err := buildTxChain(headHash, index)
-	if err != nil {
-		return nil, err
-	}
-
-	currentDoc, _, err := rehydrateChain(ctx, chain, s.patcher)
-	if err != nil {
-		return nil, err
-	}
-	return currentDoc, nil
+	return loadCurrentDoc(ctx, s.readStore, s.decoder, s.hasher, s.patcher, repoPath, domain.StatePath(s.layout, collection, docID), streamPath, headHash)
 }
 
 func (s *PatchService) buildStateTx(ctx context.Context, historyTx domain.Transaction, updatedDoc []byte) (domain.Transaction, []byte, string, error) {
@@ -82,9 +82,9 @@ func (m patchHasher) SumHex(data []byte) string {
 
 func TestPatchRequiresPayload(t *testing.T) {
 	store := &fakePatchStore{}
-	service := NewPatchService(store, store, fakeCanonicalizer{}, &fakeEncoder{}, patchDecoder{}, &recordingPatcher{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	service := NewPatchService(store, store, fakeCanonicalizer{}, &fakeEncoder{}, patchDecoder{}, &recordingPatcher{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
-	_, err := service.Patch(context.Background(), "repo", "users", "doc", nil)
+	_, err := service.Patch(context.Background(), "repo", "users", "doc", nil, WriteOptions{})
 	if !errors.Is(err, ErrPayloadRequired) {
 		t.Fatalf("expected ErrPayloadRequired, got %v", err)
 	}
@@ -117,8 +117,8 @@ func TestPatchWritesTx(t *testing.T) {
 	idGen := fakeIDGen{id: "01HPATCH"}
 	canonicalizer := fakeCanonicalizer{out: []byte(`[{"op":"replace","path":"/a","value":2}]`)}
 
-	service := NewPatchService(store, store, canonicalizer, encoder, decoder, patcher, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	result, err := service.Patch(context.Background(), "repo", "users", "doc", []byte(`[]`))
+	service := NewPatchService(store, store, canonicalizer, encoder, decoder, patcher, hasher, clock, idGen, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	result, err := service.Patch(context.Background(), "repo", "users", "doc", []byte(`[]`), WriteOptions{})
 	if err != nil {
 		t.Fatalf("Patch returned error: %v", err)
 	}
@@ -164,8 +164,8 @@ func TestPatchAmendWritesSnapshot(t *testing.T) {
 	idGen := fakeIDGen{id: "01HPATCH"}
 	canonicalizer := fakeCanonicalizer{out: []byte(`{"a":2}`)}
 
-	service := NewPatchService(store, store, canonicalizer, encoder, decoder, patcher, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAmend)
-	result, err := service.Patch(context.Background(), "repo", "users", "doc", []byte(`[]`))
+	service := NewPatchService(store, store, canonicalizer, encoder, decoder, patcher, hasher, clock, idGen, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAmend)
+	result, err := service.Patch(context.Background(), "repo", "users", "doc", []byte(`[]`), WriteOptions{})
 	if err != nil {
 		t.Fatalf("Patch returned error: %v", err)
 	}
@@ -183,3 +183,40 @@ func TestPatchAmendWritesSnapshot(t *testing.T) {
 		t.Fatalf("unexpected result: %+v", result)
 	}
 }
+
+func TestPatchRejectsSchemaViolation(t *testing.T) {
+	store := &fakePatchStore{
+		headHash: "hash1",
+		tx:       []TxBlob{{Bytes: []byte("tx1")}},
+	}
+	decoder := patchDecoder{
+		values: map[string]domain.Transaction{
+			"tx1": {
+				TxID:       "01HBASE",
+				Timestamp:  1,
+				Collection: "users",
+				DocID:      "doc",
+				Op:         domain.TxOpPut,
+				Snapshot:   []byte(`{"a":1}`),
+			},
+		},
+	}
+	hasher := patchHasher{values: map[string]string{"tx1": "hash1"}}
+	patcher := &recordingPatcher{out: []byte(`{"a":"two"}`)}
+	validator := &fakeDocValidator{err: &domain.SchemaViolationError{
+		Violations: []domain.SchemaViolation{{Pointer: "/a", Reason: "expected integer"}},
+	}}
+	canonicalizer := fakeCanonicalizer{out: []byte(`[{"op":"replace","path":"/a","value":"two"}]`)}
+
+	service := NewPatchService(store, store, canonicalizer, &fakeEncoder{}, decoder, patcher, hasher, fakeClock{}, fakeIDGen{id: "01HPATCH"}, fakeSchemaStore{schema: []byte(`{}`)}, validator, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	_, err := service.Patch(context.Background(), "repo", "users", "doc", []byte(`[]`), WriteOptions{})
+	if !errors.Is(err, domain.ErrSchemaViolation) {
+		t.Fatalf("expected ErrSchemaViolation, got %v", err)
+	}
+	if string(validator.received) != `{"a":"two"}` {
+		t.Fatalf("expected patched document to be validated, got %s", validator.received)
+	}
+	if store.received.TxHash != "" {
+		t.Fatalf("expected no write on schema violation")
+	}
+}
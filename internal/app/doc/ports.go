@@ -2,6 +2,7 @@ package doc
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
@@ -23,6 +24,10 @@ type Patcher interface {
 	Apply(ctx context.Context, doc, patch []byte) ([]byte, error)
 }
 
+type Differ interface {
+	Diff(ctx context.Context, from, to []byte) ([]PatchOp, error)
+}
+
 type Hasher interface {
 	SumHex(data []byte) string
 }
@@ -45,3 +50,49 @@ type ReadStore interface {
 	LoadHeadTx(ctx context.Context, repoPath, streamPath string) (TxBlob, error)
 	LoadStreamTxs(ctx context.Context, repoPath, streamPath string) ([]TxBlob, error)
 }
+
+type StateScanner interface {
+	ScanStates(ctx context.Context, repoPath, collection, after string) iter.Seq2[StateEntry, error]
+}
+
+type SchemaStore interface {
+	LoadSchema(ctx context.Context, repoPath, collection string) ([]byte, error)
+}
+
+type TrustStore interface {
+	LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error)
+}
+
+type DocumentValidator interface {
+	ValidateDocument(ctx context.Context, schema, doc []byte) error
+}
+
+type RefReadStore interface {
+	LoadStreamHeadAt(ctx context.Context, repoPath, ref, streamPath string) (string, error)
+	LoadStreamTxsAt(ctx context.Context, repoPath, ref, streamPath string) ([]TxBlob, error)
+}
+
+type HistoryReadStore interface {
+	RefReadStore
+	CommitAsOf(ctx context.Context, repoPath string, asOf time.Time) (string, error)
+}
+
+type Merger interface {
+	Merge(ctx context.Context, base, ours, theirs []byte) (MergeOutcome, error)
+}
+
+type BatchWriteStore interface {
+	PutTxs(ctx context.Context, repoPath string, writes []TxWrite) (string, error)
+}
+
+type MergePlanner interface {
+	Plan(ctx context.Context, absRepoPath, collection, docID, theirsRef string) (TxWrite, MergeResult, error)
+}
+
+type SyncStore interface {
+	FetchRemote(ctx context.Context, repoPath string) (string, error)
+	CompareRemote(ctx context.Context, repoPath, ref string) (SyncDivergence, error)
+	FastForward(ctx context.Context, repoPath string, divergence SyncDivergence) error
+	CommitMerge(ctx context.Context, repoPath string, divergence SyncDivergence, writes []TxWrite) (string, error)
+	Push(ctx context.Context, repoPath string) error
+}
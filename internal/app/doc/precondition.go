@@ -0,0 +1,29 @@
+package doc
+
+import (
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func normalizeWriteOptions(opts WriteOptions) (WriteOptions, error) {
+	opts.IfMatch = strings.ToLower(strings.TrimSpace(opts.IfMatch))
+	if opts.IfMatch != "" && opts.IfNoneMatch {
+		return WriteOptions{}, ErrPreconditionAmbiguous
+	}
+	return opts, nil
+}
+
+func (o WriteOptions) hasPrecondition() bool {
+	return o.IfMatch != "" || o.IfNoneMatch
+}
+
+func checkExpectedHead(currentHead string, opts WriteOptions) error {
+	if opts.IfMatch != "" && currentHead != opts.IfMatch {
+		return domain.ErrHeadChanged
+	}
+	if opts.IfNoneMatch && currentHead != "" {
+		return domain.ErrHeadChanged
+	}
+	return nil
+}
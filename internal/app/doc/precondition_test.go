@@ -0,0 +1,113 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func newTestPutService(store *memStore, mode domain.HistoryMode) *PutService {
+	return NewPutService(store, passCanonicalizer{}, jsonCodec{}, sha256Hasher{}, &tickClock{}, &seqIDGen{}, nil, nil, domain.StreamLayoutFlat, mode)
+}
+
+func TestPutIfMatchRejectsStaleHead(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	service := newTestPutService(store, domain.HistoryModeAppend)
+
+	first, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{})
+	if err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if _, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{IfMatch: first.TxHash}); err != nil {
+		t.Fatalf("Put with current head returned error: %v", err)
+	}
+
+	_, err = service.Put(ctx, "repo", "users", "doc", []byte(`{"v":3}`), WriteOptions{IfMatch: first.TxHash})
+	if !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+	if store.commits != 2 {
+		t.Fatalf("expected 2 commits, got %d", store.commits)
+	}
+}
+
+func TestPutIfMatchInAmendMode(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	store.amend = true
+	service := newTestPutService(store, domain.HistoryModeAmend)
+
+	first, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{})
+	if err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if _, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+
+	_, err = service.Put(ctx, "repo", "users", "doc", []byte(`{"v":3}`), WriteOptions{IfMatch: first.TxHash})
+	if !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+}
+
+func TestPutIfNoneMatchRejectsExistingStream(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	service := newTestPutService(store, domain.HistoryModeAppend)
+
+	if _, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{IfNoneMatch: true}); err != nil {
+		t.Fatalf("Put on new stream returned error: %v", err)
+	}
+	_, err := service.Put(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{IfNoneMatch: true})
+	if !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+}
+
+func TestPutRejectsAmbiguousPrecondition(t *testing.T) {
+	service := newTestPutService(newMemStore(), domain.HistoryModeAppend)
+	_, err := service.Put(context.Background(), "repo", "users", "doc", []byte(`{}`), WriteOptions{IfMatch: "abc", IfNoneMatch: true})
+	if !errors.Is(err, ErrPreconditionAmbiguous) {
+		t.Fatalf("expected ErrPreconditionAmbiguous, got %v", err)
+	}
+}
+
+func TestPatchAndDeleteIfMatchUseGetHash(t *testing.T) {
+	ctx := context.Background()
+	store := newMemStore()
+	putSvc := newTestPutService(store, domain.HistoryModeAppend)
+	patchSvc := NewPatchService(store, store, passCanonicalizer{}, jsonCodec{}, jsonCodec{}, mergePatcher{}, sha256Hasher{}, &tickClock{}, &seqIDGen{}, nil, nil, RetryPolicy{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	deleteSvc := NewDeleteService(store, store, jsonCodec{}, jsonCodec{}, sha256Hasher{}, &tickClock{}, &seqIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	getSvc := NewGetService(store, nil, jsonCodec{}, sha256Hasher{}, mergePatcher{}, nil, domain.StreamLayoutFlat)
+
+	if _, err := putSvc.Put(ctx, "repo", "users", "doc", []byte(`{"v":1}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	stale, err := getSvc.Get(ctx, "repo", "users", "doc")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	patched, err := patchSvc.Patch(ctx, "repo", "users", "doc", []byte(`{"v":2}`), WriteOptions{IfMatch: stale.TxHash})
+	if err != nil {
+		t.Fatalf("Patch returned error: %v", err)
+	}
+
+	current, err := getSvc.Get(ctx, "repo", "users", "doc")
+	if err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if current.TxHash != patched.TxHash {
+		t.Fatalf("expected Get to report stream head %s, got %s", patched.TxHash, current.TxHash)
+	}
+
+	if _, err := deleteSvc.Delete(ctx, "repo", "users", "doc", WriteOptions{IfMatch: stale.TxHash}); !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+	if _, err := deleteSvc.Delete(ctx, "repo", "users", "doc", WriteOptions{IfMatch: current.TxHash}); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+}
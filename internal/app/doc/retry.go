@@ -0,0 +1,67 @@
+package doc
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 5,
+		BaseDelay:  10 * time.Millisecond,
+		MaxDelay:   500 * time.Millisecond,
+	}
+}
+
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	delay := p.backoff(attempt)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// backoff doubles the base delay per attempt, caps it at MaxDelay and picks a
+// random point in the upper half so competing writers spread out.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+	delay := p.BaseDelay << min(attempt, 16)
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+type patchApplyError struct {
+	err error
+}
+
+func (e *patchApplyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *patchApplyError) Unwrap() error {
+	return e.err
+}
+
+func isPatchRebaseFailure(err error) bool {
+	var applyErr *patchApplyError
+	return errors.As(err, &applyErr) || errors.Is(err, ErrDocDeleted) || errors.Is(err, ErrDocNotFound)
+}
@@ -18,11 +18,13 @@ type RevertService struct {
 	hasher      Hasher
 	clock       Clock
 	idGen       IDGenerator
+	schemas     SchemaStore
+	validator   DocumentValidator
 	layout      domain.StreamLayout
 	historyMode domain.HistoryMode
 }
 
-func NewRevertService(readStore ReadStore, writeStore WriteStore, canonical Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, hasher Hasher, clock Clock, idGen IDGenerator, layout domain.StreamLayout, historyMode domain.HistoryMode) *RevertService {
+func NewRevertService(readStore ReadStore, writeStore WriteStore, canonical Canonicalizer, encoder Encoder, decoder Decoder, patcher Patcher, hasher Hasher, clock Clock, idGen IDGenerator, schemas SchemaStore, validator DocumentValidator, layout domain.StreamLayout, historyMode domain.HistoryMode) *RevertService {
 	if layout == "" {
 		layout = domain.StreamLayoutFlat
 	}
@@ -38,6 +40,8 @@ func NewRevertService(readStore ReadStore, writeStore WriteStore, canonical Cano
 		hasher:      hasher,
 		clock:       clock,
 		idGen:       idGen,
+		schemas:     schemas,
+		validator:   validator,
 		layout:      layout,
 		historyMode: historyMode,
 	}
@@ -98,7 +102,7 @@ func (s *RevertService) Revert(ctx context.Context, repoPath, collection, docID
 	targetEntry := index[targetHash]
 	if targetEntry.Tx.Op == domain.TxOpDelete {
 		deleteSvc := NewDeleteService(s.writeStore, s.readStore, s.encoder, s.decoder, s.hasher, s.clock, s.idGen, s.layout, s.historyMode)
-		return deleteSvc.Delete(ctx, absRepoPath, collection, docID)
+		return deleteSvc.Delete(ctx, absRepoPath, collection, docID, WriteOptions{})
 	}
 
 	chain, err := buildTxChain(targetHash, index)
@@ -111,8 +115,8 @@ func (s *RevertService) Revert(ctx context.Context, repoPath, collection, docID
 		return PutResult{}, err
 	}
 
-	putSvc := NewPutService(s.writeStore, s.canonical, s.encoder, s.hasher, s.clock, s.idGen, s.layout, s.historyMode)
-	return putSvc.Put(ctx, absRepoPath, collection, docID, doc)
+	putSvc := NewPutService(s.writeStore, s.canonical, s.encoder, s.hasher, s.clock, s.idGen, s.schemas, s.validator, s.layout, s.historyMode)
+	return putSvc.Put(ctx, absRepoPath, collection, docID, doc, WriteOptions{})
 }
 
 func selectTargetHash(index map[string]txChainEntry, txID, txHash string) (string, error) {
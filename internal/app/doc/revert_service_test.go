@@ -49,7 +49,7 @@ func (dataHasher) SumHex(data []byte) string {
 
 func TestRevertRequiresReference(t *testing.T) {
 	store := &revertStore{}
-	service := NewRevertService(store, store, passCanonicalizer{}, &fakeEncoder{}, mapDecoder{}, nil, dataHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	service := NewRevertService(store, store, passCanonicalizer{}, &fakeEncoder{}, mapDecoder{}, nil, dataHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
 	_, err := service.Revert(context.Background(), "repo", "users", "u1", RevertOptions{})
 	if !errors.Is(err, ErrTxReferenceRequired) {
@@ -90,7 +90,7 @@ func TestRevertUsesTargetSnapshot(t *testing.T) {
 	clock := fakeClock{now: time.Unix(1, 0).UTC()}
 	idGen := fakeIDGen{id: "01HREV"}
 
-	service := NewRevertService(store, store, passCanonicalizer{}, encoder, decoder, nil, dataHasher{}, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	service := NewRevertService(store, store, passCanonicalizer{}, encoder, decoder, nil, dataHasher{}, clock, idGen, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
 	_, err := service.Revert(context.Background(), "repo", "users", "u1", RevertOptions{TxID: "tx1"})
 	if err != nil {
@@ -0,0 +1,32 @@
+package doc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func enforceSchema(ctx context.Context, schemas SchemaStore, validator DocumentValidator, hasher Hasher, repoPath, collection string, doc []byte) (string, error) {
+	if schemas == nil || validator == nil {
+		return "", nil
+	}
+
+	schema, err := schemas.LoadSchema(ctx, repoPath, collection)
+	if err != nil {
+		return "", err
+	}
+	if len(schema) == 0 {
+		return "", nil
+	}
+
+	if err := validator.ValidateDocument(ctx, schema, doc); err != nil {
+		var violationErr *domain.SchemaViolationError
+		if errors.As(err, &violationErr) && violationErr.Collection == "" {
+			violationErr.Collection = collection
+		}
+		return "", err
+	}
+
+	return hasher.SumHex(schema), nil
+}
@@ -15,11 +15,13 @@ type PutService struct {
 	hasher        Hasher
 	clock         Clock
 	idGen         IDGenerator
+	schemas       SchemaStore
+	validator     DocumentValidator
 	layout        domain.StreamLayout
 	historyMode   domain.HistoryMode
 }
 
-func NewPutService(store WriteStore, canonicalizer Canonicalizer, encoder Encoder, hasher Hasher, clock Clock, idGen IDGenerator, layout domain.StreamLayout, historyMode domain.HistoryMode) *PutService {
+func NewPutService(store WriteStore, canonicalizer Canonicalizer, encoder Encoder, hasher Hasher, clock Clock, idGen IDGenerator, schemas SchemaStore, validator DocumentValidator, layout domain.StreamLayout, historyMode domain.HistoryMode) *PutService {
 	if layout == "" {
 		layout = domain.StreamLayoutFlat
 	}
@@ -32,12 +34,14 @@ func NewPutService(store WriteStore, canonicalizer Canonicalizer, encoder Encode
 		hasher:        hasher,
 		clock:         clock,
 		idGen:         idGen,
+		schemas:       schemas,
+		validator:     validator,
 		layout:        layout,
 		historyMode:   historyMode,
 	}
 }
 
-func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string, payload []byte) (PutResult, error) {
+func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string, payload []byte, opts WriteOptions) (PutResult, error) {
 	collection = strings.TrimSpace(collection)
 	if collection == "" {
 		return PutResult{}, ErrCollectionRequired
@@ -55,6 +59,11 @@ func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string
 		return PutResult{}, ErrPayloadRequired
 	}
 
+	opts, err := normalizeWriteOptions(opts)
+	if err != nil {
+		return PutResult{}, err
+	}
+
 	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
 	if err != nil {
 		return PutResult{}, err
@@ -62,12 +71,17 @@ func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string
 
 	streamPath := domain.StreamPath(s.layout, collection, docID)
 	parentHash := ""
-	if s.historyMode != domain.HistoryModeAmend {
-		var err error
-		parentHash, err = s.store.LoadStreamHead(ctx, absRepoPath, streamPath)
+	if s.historyMode != domain.HistoryModeAmend || opts.hasPrecondition() {
+		headHash, err := s.store.LoadStreamHead(ctx, absRepoPath, streamPath)
 		if err != nil {
 			return PutResult{}, err
 		}
+		if err := checkExpectedHead(headHash, opts); err != nil {
+			return PutResult{}, err
+		}
+		if s.historyMode != domain.HistoryModeAmend {
+			parentHash = headHash
+		}
 	}
 
 	canonical, err := s.canonicalizer.Canonicalize(ctx, payload)
@@ -75,19 +89,25 @@ func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string
 		return PutResult{}, err
 	}
 
+	schemaVersion, err := enforceSchema(ctx, s.schemas, s.validator, s.hasher, absRepoPath, collection, canonical)
+	if err != nil {
+		return PutResult{}, err
+	}
+
 	txID, err := s.idGen.NewID()
 	if err != nil {
 		return PutResult{}, err
 	}
 
 	tx := domain.Transaction{
-		TxID:       txID,
-		Timestamp:  s.clock.Now().UnixNano(),
-		Collection: collection,
-		DocID:      docID,
-		Op:         domain.TxOpPut,
-		Snapshot:   canonical,
-		ParentHash: parentHash,
+		TxID:          txID,
+		Timestamp:     s.clock.Now().UnixNano(),
+		Collection:    collection,
+		DocID:         docID,
+		Op:            domain.TxOpPut,
+		Snapshot:      canonical,
+		ParentHash:    parentHash,
+		SchemaVersion: schemaVersion,
 	}
 
 	encoded, err := s.encoder.Encode(tx)
@@ -119,6 +139,8 @@ func (s *PutService) Put(ctx context.Context, repoPath, collection, docID string
 		StateTxBytes: stateEncoded,
 		StateTxHash:  stateTxHash,
 		StateTx:      stateTx,
+		IfMatch:      opts.IfMatch,
+		IfNoneMatch:  opts.IfNoneMatch,
 	})
 	if err != nil {
 		return PutResult{}, err
@@ -85,32 +85,32 @@ func (f fakeIDGen) NewID() (string, error) {
 }
 
 func TestPutRequiresCollection(t *testing.T) {
-	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Put(context.Background(), "repo", " ", "doc", []byte(`{}`))
+	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	_, err := service.Put(context.Background(), "repo", " ", "doc", []byte(`{}`), WriteOptions{})
 	if !errors.Is(err, ErrCollectionRequired) {
 		t.Fatalf("expected ErrCollectionRequired, got %v", err)
 	}
 }
 
 func TestPutRejectsInvalidCollection(t *testing.T) {
-	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Put(context.Background(), "repo", "users/..", "doc", []byte(`{}`))
+	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	_, err := service.Put(context.Background(), "repo", "users/..", "doc", []byte(`{}`), WriteOptions{})
 	if !errors.Is(err, ErrInvalidCollection) {
 		t.Fatalf("expected ErrInvalidCollection, got %v", err)
 	}
 }
 
 func TestPutRequiresDocID(t *testing.T) {
-	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Put(context.Background(), "repo", "users", " ", []byte(`{}`))
+	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	_, err := service.Put(context.Background(), "repo", "users", " ", []byte(`{}`), WriteOptions{})
 	if !errors.Is(err, ErrDocIDRequired) {
 		t.Fatalf("expected ErrDocIDRequired, got %v", err)
 	}
 }
 
 func TestPutRequiresPayload(t *testing.T) {
-	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, domain.StreamLayoutFlat, domain.HistoryModeAppend)
-	_, err := service.Put(context.Background(), "repo", "users", "doc", nil)
+	service := NewPutService(&fakeStore{}, fakeCanonicalizer{}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{}, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	_, err := service.Put(context.Background(), "repo", "users", "doc", nil, WriteOptions{})
 	if !errors.Is(err, ErrPayloadRequired) {
 		t.Fatalf("expected ErrPayloadRequired, got %v", err)
 	}
@@ -123,9 +123,9 @@ func TestPutBuildsTxAndWrites(t *testing.T) {
 	hasher := fakeHasher{sum: "hash"}
 	clock := fakeClock{now: time.Unix(1, 2).UTC()}
 	idGen := fakeIDGen{id: "01H123"}
-	service := NewPutService(store, fakeCanonicalizer{out: canonical}, encoder, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+	service := NewPutService(store, fakeCanonicalizer{out: canonical}, encoder, hasher, clock, idGen, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAppend)
 
-	result, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"b":2}`))
+	result, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"b":2}`), WriteOptions{})
 	if err != nil {
 		t.Fatalf("Put returned error: %v", err)
 	}
@@ -161,9 +161,9 @@ func TestPutAmendSkipsParentHash(t *testing.T) {
 	hasher := fakeHasher{sum: "hash"}
 	clock := fakeClock{now: time.Unix(1, 2).UTC()}
 	idGen := fakeIDGen{id: "01H123"}
-	service := NewPutService(store, fakeCanonicalizer{out: canonical}, encoder, hasher, clock, idGen, domain.StreamLayoutFlat, domain.HistoryModeAmend)
+	service := NewPutService(store, fakeCanonicalizer{out: canonical}, encoder, hasher, clock, idGen, nil, nil, domain.StreamLayoutFlat, domain.HistoryModeAmend)
 
-	_, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"b":2}`))
+	_, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"b":2}`), WriteOptions{})
 	if err != nil {
 		t.Fatalf("Put returned error: %v", err)
 	}
@@ -172,3 +172,78 @@ func TestPutAmendSkipsParentHash(t *testing.T) {
 		t.Fatalf("expected empty parent hash, got %q", encoder.tx.ParentHash)
 	}
 }
+
+type fakeSchemaStore struct {
+	schema []byte
+	err    error
+}
+
+func (f fakeSchemaStore) LoadSchema(ctx context.Context, repoPath, collection string) ([]byte, error) {
+	return f.schema, f.err
+}
+
+type fakeDocValidator struct {
+	err      error
+	received []byte
+}
+
+func (f *fakeDocValidator) ValidateDocument(ctx context.Context, schema, doc []byte) error {
+	f.received = doc
+	return f.err
+}
+
+func TestPutRejectsSchemaViolation(t *testing.T) {
+	store := &fakeStore{}
+	validator := &fakeDocValidator{err: &domain.SchemaViolationError{
+		Violations: []domain.SchemaViolation{{Pointer: "/name", Reason: "expected string"}},
+	}}
+	service := NewPutService(store, fakeCanonicalizer{out: []byte(`{"name":1}`)}, &fakeEncoder{}, fakeHasher{}, fakeClock{}, fakeIDGen{id: "01H123"}, fakeSchemaStore{schema: []byte(`{}`)}, validator, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+
+	_, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"name":1}`), WriteOptions{})
+	if !errors.Is(err, domain.ErrSchemaViolation) {
+		t.Fatalf("expected ErrSchemaViolation, got %v", err)
+	}
+	var violationErr *domain.SchemaViolationError
+	if !errors.As(err, &violationErr) {
+		t.Fatalf("expected SchemaViolationError, got %T", err)
+	}
+	if violationErr.Collection != "users" {
+		t.Fatalf("expected collection %q, got %q", "users", violationErr.Collection)
+	}
+	if store.received.TxHash != "" {
+		t.Fatalf("expected no write on schema violation")
+	}
+}
+
+func TestPutSetsSchemaVersion(t *testing.T) {
+	store := &fakeStore{}
+	canonical := []byte(`{"name":"Ada"}`)
+	validator := &fakeDocValidator{}
+	service := NewPutService(store, fakeCanonicalizer{out: canonical}, &fakeEncoder{out: []byte("encoded")}, fakeHasher{sum: "schema-hash"}, fakeClock{}, fakeIDGen{id: "01H123"}, fakeSchemaStore{schema: []byte(`{"type":"object"}`)}, validator, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+
+	if _, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{"name":"Ada"}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if !bytes.Equal(validator.received, canonical) {
+		t.Fatalf("expected canonical payload to be validated")
+	}
+	if store.received.Tx.SchemaVersion != "schema-hash" {
+		t.Fatalf("expected schema version %q, got %q", "schema-hash", store.received.Tx.SchemaVersion)
+	}
+	if store.received.StateTx.SchemaVersion != "schema-hash" {
+		t.Fatalf("expected state schema version %q, got %q", "schema-hash", store.received.StateTx.SchemaVersion)
+	}
+}
+
+func TestPutSkipsValidationWithoutSchema(t *testing.T) {
+	store := &fakeStore{}
+	validator := &fakeDocValidator{err: errors.New("should not validate")}
+	service := NewPutService(store, fakeCanonicalizer{out: []byte(`{}`)}, &fakeEncoder{}, fakeHasher{sum: "hash"}, fakeClock{}, fakeIDGen{id: "01H123"}, fakeSchemaStore{}, validator, domain.StreamLayoutFlat, domain.HistoryModeAppend)
+
+	if _, err := service.Put(context.Background(), "repo", "users", "doc1", []byte(`{}`), WriteOptions{}); err != nil {
+		t.Fatalf("Put returned error: %v", err)
+	}
+	if store.received.Tx.SchemaVersion != "" {
+		t.Fatalf("expected empty schema version, got %q", store.received.Tx.SchemaVersion)
+	}
+}
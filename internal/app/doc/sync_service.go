@@ -0,0 +1,113 @@
+package doc
+
+import (
+	"context"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type SyncService struct {
+	store       SyncStore
+	readStore   ReadStore
+	decoder     Decoder
+	planner     MergePlanner
+	historyMode domain.HistoryMode
+}
+
+func NewSyncService(store SyncStore, readStore ReadStore, decoder Decoder, planner MergePlanner, historyMode domain.HistoryMode) *SyncService {
+	historyMode = domain.NormalizeHistoryMode(historyMode)
+	return &SyncService{
+		store:       store,
+		readStore:   readStore,
+		decoder:     decoder,
+		planner:     planner,
+		historyMode: historyMode,
+	}
+}
+
+func (s *SyncService) Sync(ctx context.Context, repoPath string) (SyncResult, error) {
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	ref, err := s.store.FetchRemote(ctx, absRepoPath)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if ref == "" {
+		return SyncResult{Action: SyncActionNoRemote}, nil
+	}
+
+	divergence, err := s.store.CompareRemote(ctx, absRepoPath, ref)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	result := SyncResult{
+		LocalHead:  divergence.LocalHead,
+		RemoteHead: divergence.RemoteHead,
+	}
+
+	switch {
+	case divergence.LocalHead == divergence.RemoteHead:
+		result.Action = SyncActionUpToDate
+		result.CommitHash = divergence.LocalHead
+		return result, nil
+	case divergence.LocalHead == "" || divergence.BaseHead == divergence.LocalHead:
+		if err := s.store.FastForward(ctx, absRepoPath, divergence); err != nil {
+			return SyncResult{}, err
+		}
+		result.Action = SyncActionFastForward
+		result.CommitHash = divergence.RemoteHead
+		return result, nil
+	case divergence.RemoteHead == "" || divergence.BaseHead == divergence.RemoteHead:
+		if err := s.store.Push(ctx, absRepoPath); err != nil {
+			return SyncResult{}, err
+		}
+		result.Action = SyncActionPush
+		result.CommitHash = divergence.LocalHead
+		return result, nil
+	}
+
+	if s.historyMode == domain.HistoryModeAmend {
+		return SyncResult{}, ErrMergeRequiresHistory
+	}
+
+	writes := make([]TxWrite, 0, len(divergence.Streams))
+	for _, streamPath := range divergence.Streams {
+		blob, err := s.readStore.LoadHeadTx(ctx, absRepoPath, streamPath)
+		if err != nil {
+			return SyncResult{}, err
+		}
+		head, err := s.decoder.Decode(blob.Bytes)
+		if err != nil {
+			return SyncResult{}, err
+		}
+		write, merged, err := s.planner.Plan(ctx, absRepoPath, head.Collection, head.DocID, ref)
+		if err != nil {
+			return SyncResult{}, err
+		}
+		if merged.UpToDate {
+			continue
+		}
+		writes = append(writes, write)
+		result.Merged = append(result.Merged, SyncStreamResult{
+			Collection: head.Collection,
+			DocID:      head.DocID,
+			TxHash:     write.TxHash,
+			Conflicts:  merged.Conflicts,
+		})
+	}
+
+	commitHash, err := s.store.CommitMerge(ctx, absRepoPath, divergence, writes)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if err := s.store.Push(ctx, absRepoPath); err != nil {
+		return SyncResult{}, err
+	}
+	result.Action = SyncActionMerge
+	result.CommitHash = commitHash
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package doc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type fakeSyncStore struct {
+	ref         string
+	divergence  SyncDivergence
+	fastForward bool
+	pushed      bool
+	merged      []TxWrite
+}
+
+func (f *fakeSyncStore) FetchRemote(ctx context.Context, repoPath string) (string, error) {
+	return f.ref, nil
+}
+
+func (f *fakeSyncStore) CompareRemote(ctx context.Context, repoPath, ref string) (SyncDivergence, error) {
+	return f.divergence, nil
+}
+
+func (f *fakeSyncStore) FastForward(ctx context.Context, repoPath string, divergence SyncDivergence) error {
+	f.fastForward = true
+	return nil
+}
+
+func (f *fakeSyncStore) CommitMerge(ctx context.Context, repoPath string, divergence SyncDivergence, writes []TxWrite) (string, error) {
+	f.merged = writes
+	return "merge-commit", nil
+}
+
+func (f *fakeSyncStore) Push(ctx context.Context, repoPath string) error {
+	f.pushed = true
+	return nil
+}
+
+func TestSyncMergesStreamsTouchedOnBothSides(t *testing.T) {
+	ctx := context.Background()
+	f := newMergeFixture(t)
+	f.patch(f.local, `{"a":2}`)
+	f.patch(f.remote, `{"s":"theirs"}`)
+
+	streamPath := domain.StreamPath(domain.StreamLayoutFlat, "users", "doc")
+	store := &fakeSyncStore{ref: "origin", divergence: SyncDivergence{
+		Ref:        "origin",
+		LocalHead:  "local",
+		RemoteHead: "remote",
+		BaseHead:   "base",
+		Streams:    []string{streamPath},
+	}}
+	service := NewSyncService(store, f.local, jsonCodec{}, f.merge, domain.HistoryModeAppend)
+
+	result, err := service.Sync(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if result.Action != SyncActionMerge || result.CommitHash != "merge-commit" || !store.pushed {
+		t.Fatalf("unexpected sync result: %+v (pushed=%v)", result, store.pushed)
+	}
+	if len(store.merged) != 1 || store.merged[0].Tx.Op != domain.TxOpMerge {
+		t.Fatalf("expected one merge write, got %+v", store.merged)
+	}
+	if len(result.Merged) != 1 || result.Merged[0].DocID != "doc" || result.Merged[0].TxHash != store.merged[0].TxHash {
+		t.Fatalf("unexpected merged streams: %+v", result.Merged)
+	}
+}
+
+func TestSyncFastForwardsAndPushes(t *testing.T) {
+	tests := []struct {
+		name       string
+		divergence SyncDivergence
+		want       SyncAction
+	}{
+		{name: "behind", divergence: SyncDivergence{LocalHead: "a", RemoteHead: "b", BaseHead: "a"}, want: SyncActionFastForward},
+		{name: "empty local", divergence: SyncDivergence{RemoteHead: "b"}, want: SyncActionFastForward},
+		{name: "ahead", divergence: SyncDivergence{LocalHead: "b", RemoteHead: "a", BaseHead: "a"}, want: SyncActionPush},
+		{name: "empty remote", divergence: SyncDivergence{LocalHead: "a"}, want: SyncActionPush},
+		{name: "equal", divergence: SyncDivergence{LocalHead: "a", RemoteHead: "a"}, want: SyncActionUpToDate},
+	}
+	for _, tt := range tests {
+		store := &fakeSyncStore{ref: "origin", divergence: tt.divergence}
+		service := NewSyncService(store, newMemStore(), jsonCodec{}, nil, domain.HistoryModeAppend)
+		result, err := service.Sync(context.Background(), "repo")
+		if err != nil {
+			t.Fatalf("%s: Sync returned error: %v", tt.name, err)
+		}
+		if result.Action != tt.want {
+			t.Fatalf("%s: expected %s, got %s", tt.name, tt.want, result.Action)
+		}
+		if store.fastForward != (tt.want == SyncActionFastForward) || store.pushed != (tt.want == SyncActionPush) {
+			t.Fatalf("%s: unexpected store calls (fastForward=%v pushed=%v)", tt.name, store.fastForward, store.pushed)
+		}
+	}
+}
+
+func TestSyncWithoutRemote(t *testing.T) {
+	service := NewSyncService(&fakeSyncStore{}, newMemStore(), jsonCodec{}, nil, domain.HistoryModeAppend)
+	result, err := service.Sync(context.Background(), "repo")
+	if err != nil {
+		t.Fatalf("Sync returned error: %v", err)
+	}
+	if result.Action != SyncActionNoRemote {
+		t.Fatalf("expected no_remote, got %s", result.Action)
+	}
+}
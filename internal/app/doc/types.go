@@ -1,6 +1,11 @@
 package doc
 
-import "github.com/osvaldoandrade/ledgerdb/internal/domain"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
 
 type PutResult struct {
 	CommitHash string
@@ -9,10 +14,17 @@ type PutResult struct {
 }
 
 type GetResult struct {
-	Payload []byte
-	TxHash  string
-	TxID    string
-	Op      domain.TxOp
+	Payload    []byte
+	TxHash     string
+	TxID       string
+	Op         domain.TxOp
+	CommitHash string
+}
+
+type ReadOptions struct {
+	AtTx     string
+	AtCommit string
+	AsOf     time.Time
 }
 
 type TxWrite struct {
@@ -25,6 +37,9 @@ type TxWrite struct {
 	StateTxBytes []byte
 	StateTxHash  string
 	StateTx      domain.Transaction
+	IfMatch      string
+	IfNoneMatch  bool
+	ImportTxs    []TxBlob
 }
 
 type TxBlob struct {
@@ -33,14 +48,133 @@ type TxBlob struct {
 }
 
 type LogEntry struct {
-	TxID       string
-	TxHash     string
-	ParentHash string
-	Timestamp  int64
-	Op         domain.TxOp
+	TxID         string
+	TxHash       string
+	ParentHash   string
+	MergeParents []string
+	Timestamp    int64
+	Op           domain.TxOp
+}
+
+type WriteOptions struct {
+	IfMatch     string
+	IfNoneMatch bool
 }
 
 type RevertOptions struct {
 	TxID   string
 	TxHash string
 }
+
+type MergeOptions struct {
+	Theirs string
+}
+
+type MergeOutcome struct {
+	Doc       []byte
+	Conflicts []string
+}
+
+type MergeResult struct {
+	PutResult
+	BaseHash   string
+	OursHash   string
+	TheirsHash string
+	Conflicts  []string
+	UpToDate   bool
+}
+
+type SyncAction string
+
+const (
+	SyncActionNoRemote    SyncAction = "no_remote"
+	SyncActionUpToDate    SyncAction = "up_to_date"
+	SyncActionFastForward SyncAction = "fast_forward"
+	SyncActionPush        SyncAction = "push"
+	SyncActionMerge       SyncAction = "merge"
+)
+
+type SyncDivergence struct {
+	Ref        string
+	LocalHead  string
+	RemoteHead string
+	BaseHead   string
+	Streams    []string
+}
+
+type SyncStreamResult struct {
+	Collection string
+	DocID      string
+	TxHash     string
+	Conflicts  []string
+}
+
+type SyncResult struct {
+	Action     SyncAction
+	LocalHead  string
+	RemoteHead string
+	CommitHash string
+	Merged     []SyncStreamResult
+}
+
+type BatchOp struct {
+	Op         domain.TxOp
+	Collection string
+	DocID      string
+	Payload    []byte
+}
+
+type BatchResult struct {
+	CommitHash string
+	Txs        []PutResult
+}
+
+type DiffOptions struct {
+	From string
+	To   string
+}
+
+// PatchOp is one RFC 6902 operation. Old carries the replaced or removed
+// value for display and is not part of the serialized patch.
+type PatchOp struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Old   json.RawMessage `json:"-"`
+}
+
+type DiffResult struct {
+	FromHash string
+	FromTxID string
+	ToHash   string
+	ToTxID   string
+	Ops      []PatchOp
+}
+
+// StateEntry is a document's current state tx; Key is its stream hash and
+// doubles as the scan cursor.
+type StateEntry struct {
+	Key  string
+	Blob TxBlob
+}
+
+type ListOptions struct {
+	Prefix         string
+	After          string
+	Limit          int
+	IncludeDeleted bool
+}
+
+type ListEntry struct {
+	DocID   string
+	TxID    string
+	Op      domain.TxOp
+	Deleted bool
+	Payload []byte
+	Cursor  string
+}
+
+type ListResult struct {
+	Entries    []ListEntry
+	NextCursor string
+}
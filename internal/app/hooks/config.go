@@ -0,0 +1,60 @@
+package hooks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+var knownOps = map[string]bool{"put": true, "patch": true, "delete": true, "merge": true}
+
+func (c Config) Validate() error {
+	if c.MaxAttempts < 0 {
+		return fmt.Errorf("%w: max attempts must be >= 0", ErrInvalidHook)
+	}
+	if c.Backoff < 0 {
+		return fmt.Errorf("%w: backoff must be >= 0", ErrInvalidHook)
+	}
+	names := make(map[string]bool, len(c.Hooks))
+	for _, hook := range c.Hooks {
+		name := strings.TrimSpace(hook.Name)
+		if name == "" {
+			return fmt.Errorf("%w: name is required", ErrInvalidHook)
+		}
+		if names[name] {
+			return fmt.Errorf("%w: duplicate name %s", ErrInvalidHook, name)
+		}
+		names[name] = true
+		if (hook.URL == "") == (len(hook.Command) == 0) {
+			return fmt.Errorf("%w: %s needs exactly one of url or command", ErrInvalidHook, name)
+		}
+		if hook.URL != "" {
+			parsed, err := url.Parse(hook.URL)
+			if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+				return fmt.Errorf("%w: %s has invalid url %q", ErrInvalidHook, name, hook.URL)
+			}
+		}
+		for _, op := range hook.Ops {
+			if !knownOps[op] {
+				return fmt.Errorf("%w: %s has unknown op %q", ErrInvalidHook, name, op)
+			}
+		}
+	}
+	return nil
+}
+
+func (h Hook) matches(collection, op string) bool {
+	return matchAny(h.Collections, collection) && matchAny(h.Ops, op)
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package hooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+)
+
+type Dispatcher struct {
+	source     EventSource
+	sender     Sender
+	checkpoint Checkpoint
+	sleep      func(ctx context.Context, wait time.Duration) error
+	now        func() time.Time
+}
+
+func NewDispatcher(source EventSource, sender Sender, checkpoint Checkpoint) *Dispatcher {
+	return &Dispatcher{
+		source:     source,
+		sender:     sender,
+		checkpoint: checkpoint,
+		sleep:      sleepContext,
+		now:        time.Now,
+	}
+}
+
+// Dispatch delivers every transaction committed after the checkpoint to the
+// matching hooks, in commit order. The checkpoint advances only past events
+// that every matching hook accepted, so a failed delivery is retried, with
+// the events after it, on the next call.
+func (d *Dispatcher) Dispatch(ctx context.Context, repoPath string, cfg Config) (DispatchResult, error) {
+	if err := cfg.Validate(); err != nil {
+		return DispatchResult{}, err
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = DefaultBackoff
+	}
+
+	since, err := d.checkpoint.Load(ctx)
+	if err != nil {
+		return DispatchResult{}, err
+	}
+	result := DispatchResult{Cursor: since}
+	next, err := d.source.Stream(ctx, repoPath, changesapp.StreamOptions{Since: since}, func(event changesapp.Event) error {
+		result.Events++
+		delivered := 0
+		for _, hook := range cfg.Hooks {
+			if !hook.matches(event.Collection, event.Op) {
+				continue
+			}
+			if err := d.deliver(ctx, cfg, hook, event); err != nil {
+				return err
+			}
+			delivered++
+		}
+		if delivered > 0 {
+			if err := d.checkpoint.Save(ctx, event.Cursor); err != nil {
+				return err
+			}
+			result.Cursor = event.Cursor
+			result.Delivered += delivered
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	if next != result.Cursor {
+		if err := d.checkpoint.Save(ctx, next); err != nil {
+			return result, err
+		}
+		result.Cursor = next
+	}
+	return result, nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, cfg Config, hook Hook, event changesapp.Event) error {
+	body, err := json.Marshal(Payload{
+		Hook:          hook.Name,
+		Cursor:        event.Cursor,
+		Commit:        event.Commit,
+		Collection:    event.Collection,
+		DocID:         event.DocID,
+		Op:            event.Op,
+		TxID:          event.TxID,
+		TxHash:        event.TxHash,
+		ParentHash:    event.ParentHash,
+		Timestamp:     event.Timestamp,
+		SchemaVersion: event.SchemaVersion,
+		Payload:       event.Payload,
+		Patch:         event.Patch,
+	})
+	if err != nil {
+		return err
+	}
+	delivery := Delivery{
+		Hook: hook,
+		ID:   event.TxHash,
+		Body: body,
+	}
+
+	wait := cfg.Backoff
+	for attempt := 1; ; attempt++ {
+		// Every attempt is signed afresh so a retry after a long backoff
+		// still falls inside the receiver's tolerance window.
+		delivery.Attempt = attempt
+		delivery.Timestamp = d.now().Unix()
+		delivery.Signature = Sign(hook.Secret, delivery.Timestamp, body)
+		err := d.sender.Send(ctx, delivery)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= cfg.MaxAttempts {
+			return fmt.Errorf("%w: %s for tx %s after %d attempts: %v", ErrDeliveryFailed, hook.Name, event.TxHash, attempt, err)
+		}
+		if err := d.sleep(ctx, wait); err != nil {
+			return err
+		}
+		wait *= 2
+		if wait > MaxBackoff {
+			wait = MaxBackoff
+		}
+	}
+}
+
+// Sign returns the hex HMAC-SHA256 of "<timestamp>.<body>", or "" when
+// secret is empty. Binding the timestamp lets receivers reject replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	if secret == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
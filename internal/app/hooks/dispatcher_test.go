@@ -0,0 +1,204 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+)
+
+type fakeSource struct {
+	events []changesapp.Event
+	final  string
+}
+
+func (f fakeSource) Stream(ctx context.Context, repoPath string, opts changesapp.StreamOptions, emit func(changesapp.Event) error) (string, error) {
+	start := 0
+	for i, event := range f.events {
+		if event.Cursor == opts.Since {
+			start = i + 1
+		}
+	}
+	if opts.Since == f.final {
+		start = len(f.events)
+	}
+	for _, event := range f.events[start:] {
+		if err := emit(event); err != nil {
+			return "", err
+		}
+	}
+	return f.final, nil
+}
+
+type fakeSender struct {
+	failures   map[string]int
+	deliveries []Delivery
+}
+
+func (f *fakeSender) Send(ctx context.Context, delivery Delivery) error {
+	f.deliveries = append(f.deliveries, delivery)
+	key := delivery.Hook.Name + "/" + delivery.ID
+	if f.failures[key] > 0 {
+		f.failures[key]--
+		return errors.New("unavailable")
+	}
+	return nil
+}
+
+type memCheckpoint struct {
+	cursor string
+	saves  int
+}
+
+func (m *memCheckpoint) Load(ctx context.Context) (string, error) {
+	return m.cursor, nil
+}
+
+func (m *memCheckpoint) Save(ctx context.Context, cursor string) error {
+	m.cursor = cursor
+	m.saves++
+	return nil
+}
+
+func testSource() fakeSource {
+	return fakeSource{
+		events: []changesapp.Event{
+			{Cursor: "e1", Collection: "tasks", DocID: "a", Op: "put", TxHash: "h1", Payload: []byte(`{"n":1}`)},
+			{Cursor: "e2", Collection: "users", DocID: "u", Op: "put", TxHash: "h2", Payload: []byte(`{}`)},
+			{Cursor: "e3", Collection: "tasks", DocID: "a", Op: "delete", TxHash: "h3"},
+		},
+		final: "end",
+	}
+}
+
+func newTestDispatcher(source fakeSource, sender *fakeSender, checkpoint *memCheckpoint, waits *[]time.Duration) *Dispatcher {
+	dispatcher := NewDispatcher(source, sender, checkpoint)
+	now := time.Unix(1700000000, 0)
+	dispatcher.now = func() time.Time { return now }
+	dispatcher.sleep = func(ctx context.Context, wait time.Duration) error {
+		*waits = append(*waits, wait)
+		now = now.Add(wait)
+		return nil
+	}
+	return dispatcher
+}
+
+func deliveredIDs(deliveries []Delivery) []string {
+	ids := make([]string, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.Hook.Name+"/"+delivery.ID)
+	}
+	return ids
+}
+
+func TestDispatchFiltersAndSigns(t *testing.T) {
+	sender := &fakeSender{}
+	checkpoint := &memCheckpoint{}
+	var waits []time.Duration
+	dispatcher := newTestDispatcher(testSource(), sender, checkpoint, &waits)
+	cfg := Config{Hooks: []Hook{
+		{Name: "tasks", Collections: []string{"tasks"}, URL: "http://127.0.0.1/hook", Secret: "s3cret"},
+		{Name: "deletes", Ops: []string{"delete"}, Command: []string{"true"}},
+	}}
+
+	result, err := dispatcher.Dispatch(context.Background(), "repo", cfg)
+	if err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if want := []string{"tasks/h1", "tasks/h3", "deletes/h3"}; !reflect.DeepEqual(deliveredIDs(sender.deliveries), want) {
+		t.Fatalf("expected %v, got %v", want, deliveredIDs(sender.deliveries))
+	}
+	if result.Events != 3 || result.Delivered != 3 || result.Cursor != "end" || checkpoint.cursor != "end" {
+		t.Fatalf("unexpected result %+v (checkpoint %q)", result, checkpoint.cursor)
+	}
+
+	first := sender.deliveries[0]
+	if first.Timestamp != 1700000000 || first.Signature != Sign("s3cret", first.Timestamp, first.Body) || first.Signature == "" {
+		t.Fatalf("expected an HMAC signature over the timestamp, got %d %q", first.Timestamp, first.Signature)
+	}
+	if Sign("s3cret", first.Timestamp+1, first.Body) == first.Signature {
+		t.Fatalf("expected the signature to depend on the timestamp")
+	}
+	if sender.deliveries[2].Signature != "" {
+		t.Fatalf("expected no signature without a secret")
+	}
+	var payload Payload
+	if err := json.Unmarshal(first.Body, &payload); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if payload.Hook != "tasks" || payload.DocID != "a" || payload.Cursor != "e1" || string(payload.Payload) != `{"n":1}` {
+		t.Fatalf("unexpected payload %+v", payload)
+	}
+
+	sender.deliveries = nil
+	result, err = dispatcher.Dispatch(context.Background(), "repo", cfg)
+	if err != nil || result.Events != 0 || len(sender.deliveries) != 0 {
+		t.Fatalf("expected nothing after the checkpoint, got %+v, %v", result, err)
+	}
+}
+
+func TestDispatchRetriesWithBackoff(t *testing.T) {
+	sender := &fakeSender{failures: map[string]int{"tasks/h1": 2}}
+	checkpoint := &memCheckpoint{}
+	var waits []time.Duration
+	dispatcher := newTestDispatcher(testSource(), sender, checkpoint, &waits)
+	cfg := Config{Hooks: []Hook{{Name: "tasks", Collections: []string{"tasks"}, URL: "http://127.0.0.1/hook"}}, Backoff: time.Second}
+
+	if _, err := dispatcher.Dispatch(context.Background(), "repo", cfg); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if want := []time.Duration{time.Second, 2 * time.Second}; !reflect.DeepEqual(waits, want) {
+		t.Fatalf("expected backoff %v, got %v", want, waits)
+	}
+	if sender.deliveries[2].Attempt != 3 {
+		t.Fatalf("expected the third attempt to succeed, got %+v", sender.deliveries[2])
+	}
+	if sender.deliveries[2].Timestamp != sender.deliveries[0].Timestamp+3 {
+		t.Fatalf("expected each attempt to carry its own timestamp, got %+v", sender.deliveries)
+	}
+}
+
+func TestDispatchKeepsCheckpointOnFailure(t *testing.T) {
+	sender := &fakeSender{failures: map[string]int{"tasks/h3": 10}}
+	checkpoint := &memCheckpoint{}
+	var waits []time.Duration
+	dispatcher := newTestDispatcher(testSource(), sender, checkpoint, &waits)
+	cfg := Config{Hooks: []Hook{{Name: "tasks", Collections: []string{"tasks"}, URL: "http://127.0.0.1/hook"}}, MaxAttempts: 2}
+
+	_, err := dispatcher.Dispatch(context.Background(), "repo", cfg)
+	if !errors.Is(err, ErrDeliveryFailed) {
+		t.Fatalf("expected ErrDeliveryFailed, got %v", err)
+	}
+	if checkpoint.cursor != "e1" {
+		t.Fatalf("expected the checkpoint to stop before the failed event, got %q", checkpoint.cursor)
+	}
+
+	sender.failures = nil
+	sender.deliveries = nil
+	if _, err := dispatcher.Dispatch(context.Background(), "repo", cfg); err != nil {
+		t.Fatalf("retry returned error: %v", err)
+	}
+	if want := []string{"tasks/h3"}; !reflect.DeepEqual(deliveredIDs(sender.deliveries), want) {
+		t.Fatalf("expected the failed event to be redelivered, got %v", deliveredIDs(sender.deliveries))
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	cases := []Config{
+		{Hooks: []Hook{{URL: "http://localhost"}}},
+		{Hooks: []Hook{{Name: "a"}}},
+		{Hooks: []Hook{{Name: "a", URL: "http://localhost", Command: []string{"true"}}}},
+		{Hooks: []Hook{{Name: "a", URL: "ftp://localhost"}}},
+		{Hooks: []Hook{{Name: "a", URL: "http://localhost", Ops: []string{"upsert"}}}},
+		{Hooks: []Hook{{Name: "a", URL: "http://localhost"}, {Name: "a", URL: "http://localhost"}}},
+	}
+	for i, cfg := range cases {
+		if err := cfg.Validate(); !errors.Is(err, ErrInvalidHook) {
+			t.Fatalf("case %d: expected ErrInvalidHook, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package hooks
+
+import "errors"
+
+var ErrInvalidHook = errors.New("invalid hook")
+var ErrDeliveryFailed = errors.New("hook delivery failed")
@@ -0,0 +1,20 @@
+package hooks
+
+import (
+	"context"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+)
+
+type EventSource interface {
+	Stream(ctx context.Context, repoPath string, opts changesapp.StreamOptions, emit func(changesapp.Event) error) (string, error)
+}
+
+type Sender interface {
+	Send(ctx context.Context, delivery Delivery) error
+}
+
+type Checkpoint interface {
+	Load(ctx context.Context) (string, error)
+	Save(ctx context.Context, cursor string) error
+}
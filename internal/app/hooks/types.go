@@ -0,0 +1,64 @@
+package hooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = time.Second
+	MaxBackoff         = time.Minute
+)
+
+// Hook delivers matching transactions either as an HTTP POST to URL or on
+// the stdin of Command. Empty Collections or Ops match everything.
+type Hook struct {
+	Name        string
+	Collections []string
+	Ops         []string
+	URL         string
+	Secret      string
+	Command     []string
+}
+
+type Config struct {
+	Hooks       []Hook
+	MaxAttempts int
+	Backoff     time.Duration
+}
+
+// Payload is the JSON body delivered to every hook.
+type Payload struct {
+	Hook          string          `json:"hook"`
+	Cursor        string          `json:"cursor"`
+	Commit        string          `json:"commit"`
+	Collection    string          `json:"collection"`
+	DocID         string          `json:"doc_id"`
+	Op            string          `json:"op"`
+	TxID          string          `json:"tx_id"`
+	TxHash        string          `json:"tx_hash"`
+	ParentHash    string          `json:"parent_hash,omitempty"`
+	Timestamp     int64           `json:"timestamp"`
+	SchemaVersion string          `json:"schema_version,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Patch         json.RawMessage `json:"patch,omitempty"`
+}
+
+// Delivery is one attempt to hand a payload to a hook. Timestamp is the
+// Unix time of the attempt and Signature the hex HMAC-SHA256 of
+// "<Timestamp>.<Body>" keyed by the hook secret, empty without a secret.
+type Delivery struct {
+	Hook      Hook
+	ID        string
+	Body      []byte
+	Timestamp int64
+	Signature string
+	Attempt   int
+}
+
+type DispatchResult struct {
+	Events    int
+	Delivered int
+	Cursor    string
+}
@@ -3,7 +3,6 @@ package index
 import "errors"
 
 var ErrCommitNotFound = errors.New("last indexed commit not found in repo")
-var ErrMergeCommitUnsupported = errors.New("merge commits are not supported")
 var ErrFetchUnavailable = errors.New("fetch is not configured")
 var ErrMissingDocument = errors.New("document missing for patch")
 var ErrPatchUnsupported = errors.New("patch operations not supported")
@@ -11,3 +10,11 @@ var ErrStateUnavailable = errors.New("state tree not available")
 var ErrInvalidInterval = errors.New("invalid sync interval")
 var ErrInvalidJitter = errors.New("invalid sync jitter")
 var ErrInvalidBatchCommits = errors.New("invalid commit batch size")
+var ErrCollectionRequired = errors.New("collection name is required")
+var ErrSearchQueryRequired = errors.New("search query is required")
+var ErrInvalidSearchLimit = errors.New("invalid search limit")
+var ErrFullTextNotEnabled = errors.New("collection has no full-text fields indexed")
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+var ErrSinkRequired = errors.New("index database or sink is required")
+var ErrSinkConflict = errors.New("use either a sqlite db path or a sink, not both")
+var ErrUnsupportedSink = errors.New("unsupported index sink")
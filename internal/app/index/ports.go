@@ -17,6 +17,15 @@ type CommitSource interface {
 	StateTxsSince(ctx context.Context, repoPath string, state State) (StateTxsResult, error)
 }
 
+type IndexDeclarations interface {
+	LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error)
+	LoadFullText(ctx context.Context, repoPath, collection string) ([]string, error)
+}
+
+type TrustStore interface {
+	LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error)
+}
+
 type Store interface {
 	GetState(ctx context.Context) (State, error)
 	Begin(ctx context.Context) (StoreTx, error)
@@ -27,11 +36,20 @@ type StoreTx interface {
 	EnsureCollection(ctx context.Context, collection string) (string, error)
 	GetDoc(ctx context.Context, collection, docID string) (DocRecord, bool, error)
 	UpsertDoc(ctx context.Context, collection string, record DocRecord) error
+	AppendHistory(ctx context.Context, collection string, record HistoryRecord) error
 	SetState(ctx context.Context, state State) error
+	Collections(ctx context.Context) ([]string, error)
+	EnsureIndexes(ctx context.Context, collection string, fields []string) (IndexChanges, error)
+	EnsureFullText(ctx context.Context, collection string, fields []string) (bool, error)
+	UpdateFullText(ctx context.Context, collection, docID string) error
 	Commit() error
 	Rollback() error
 }
 
+type Searcher interface {
+	Search(ctx context.Context, collection, query string, limit int) ([]SearchHit, error)
+}
+
 type Canonicalizer interface {
 	Canonicalize(ctx context.Context, input []byte) ([]byte, error)
 }
@@ -0,0 +1,37 @@
+package index
+
+import (
+	"context"
+	"strings"
+)
+
+const defaultSearchLimit = 20
+
+type SearchService struct {
+	searcher Searcher
+}
+
+func NewSearchService(searcher Searcher) *SearchService {
+	return &SearchService{searcher: searcher}
+}
+
+// Search runs an FTS5 match expression against the full-text fields of a
+// collection and returns the best matches first.
+func (s *SearchService) Search(ctx context.Context, collection, query string, opts SearchOptions) ([]SearchHit, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return nil, ErrCollectionRequired
+	}
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrSearchQueryRequired
+	}
+	if opts.Limit < 0 {
+		return nil, ErrInvalidSearchLimit
+	}
+	limit := opts.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	return s.searcher.Search(ctx, collection, query, limit)
+}
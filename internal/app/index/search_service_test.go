@@ -0,0 +1,48 @@
+package index
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type recordingSearcher struct {
+	collection string
+	query      string
+	limit      int
+}
+
+func (r *recordingSearcher) Search(ctx context.Context, collection, query string, limit int) ([]SearchHit, error) {
+	r.collection, r.query, r.limit = collection, query, limit
+	return []SearchHit{{DocID: "n1"}}, nil
+}
+
+func TestSearchServiceValidatesAndDefaultsLimit(t *testing.T) {
+	searcher := &recordingSearcher{}
+	service := NewSearchService(searcher)
+
+	hits, err := service.Search(context.Background(), " notes ", " disk ", SearchOptions{})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(hits) != 1 || searcher.collection != "notes" || searcher.query != "disk" || searcher.limit != defaultSearchLimit {
+		t.Fatalf("unexpected search call: %+v", searcher)
+	}
+
+	cases := []struct {
+		collection string
+		query      string
+		limit      int
+		err        error
+	}{
+		{"", "disk", 0, ErrCollectionRequired},
+		{"notes", " ", 0, ErrSearchQueryRequired},
+		{"notes", "disk", -1, ErrInvalidSearchLimit},
+	}
+	for _, tc := range cases {
+		_, err := service.Search(context.Background(), tc.collection, tc.query, SearchOptions{Limit: tc.limit})
+		if !errors.Is(err, tc.err) {
+			t.Fatalf("expected %v, got %v", tc.err, err)
+		}
+	}
+}
@@ -11,22 +11,26 @@ import (
 type SyncService struct {
 	fetcher       Fetcher
 	source        CommitSource
+	declarations  IndexDeclarations
 	store         Store
 	canonicalizer Canonicalizer
 	decoder       Decoder
 	patcher       Patcher
 	hasher        Hasher
+	trust         TrustStore
 }
 
-func NewSyncService(fetcher Fetcher, source CommitSource, store Store, canonicalizer Canonicalizer, decoder Decoder, patcher Patcher, hasher Hasher) *SyncService {
+func NewSyncService(fetcher Fetcher, source CommitSource, declarations IndexDeclarations, store Store, canonicalizer Canonicalizer, decoder Decoder, patcher Patcher, hasher Hasher, trust TrustStore) *SyncService {
 	return &SyncService{
 		fetcher:       fetcher,
 		source:        source,
+		declarations:  declarations,
 		store:         store,
 		canonicalizer: canonicalizer,
 		decoder:       decoder,
 		patcher:       patcher,
 		hasher:        hasher,
+		trust:         trust,
 	}
 }
 
@@ -44,18 +48,72 @@ func (s *SyncService) Sync(ctx context.Context, repoPath string, opts SyncOption
 		}
 	}
 
+	// State mode only sees the latest version of each document, so recording
+	// history always replays the commit log.
 	mode := NormalizeMode(opts.Mode)
-	if mode == ModeState {
+	if mode == ModeState && !opts.History {
 		result, err := s.syncState(ctx, repoPath, opts)
 		if err == nil {
-			return result, nil
+			return result, s.syncIndexes(ctx, repoPath, &result)
 		}
 		if !errors.Is(err, ErrStateUnavailable) {
 			return result, err
 		}
 	}
 
-	return s.syncHistory(ctx, repoPath, opts)
+	result, err := s.syncHistory(ctx, repoPath, opts)
+	if err != nil {
+		return result, err
+	}
+	return result, s.syncIndexes(ctx, repoPath, &result)
+}
+
+// syncIndexes reconciles every indexed collection with its declared index
+// and full-text fields, so adding or removing a declaration takes effect on
+// the next sync even when no documents changed.
+func (s *SyncService) syncIndexes(ctx context.Context, repoPath string, result *SyncResult) error {
+	if s.declarations == nil {
+		return nil
+	}
+
+	storeTx, err := s.store.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	collections, err := storeTx.Collections(ctx)
+	if err != nil {
+		_ = storeTx.Rollback()
+		return err
+	}
+	for _, collection := range collections {
+		fields, err := s.declarations.LoadIndexes(ctx, repoPath, collection)
+		if err != nil {
+			_ = storeTx.Rollback()
+			return err
+		}
+		changes, err := storeTx.EnsureIndexes(ctx, collection, fields)
+		if err != nil {
+			_ = storeTx.Rollback()
+			return err
+		}
+		result.IndexesCreated += len(changes.Created)
+		result.IndexesDropped += len(changes.Dropped)
+
+		fullText, err := s.declarations.LoadFullText(ctx, repoPath, collection)
+		if err != nil {
+			_ = storeTx.Rollback()
+			return err
+		}
+		rebuilt, err := storeTx.EnsureFullText(ctx, collection, fullText)
+		if err != nil {
+			_ = storeTx.Rollback()
+			return err
+		}
+		if rebuilt {
+			result.FullTextRebuilt++
+		}
+	}
+	return storeTx.Commit()
 }
 
 func (s *SyncService) syncHistory(ctx context.Context, repoPath string, opts SyncOptions) (SyncResult, error) {
@@ -92,6 +150,7 @@ func (s *SyncService) syncHistory(ctx context.Context, repoPath string, opts Syn
 	}
 
 	collections := make(map[string]struct{})
+	trusted := make(map[string][]string)
 	for start := 0; start < len(commitHashes); start += batchSize {
 		end := start + batchSize
 		if end > len(commitHashes) {
@@ -115,13 +174,17 @@ func (s *SyncService) syncHistory(ctx context.Context, repoPath string, opts Syn
 				return result, err
 			}
 
-			decoded, err := s.decodeTxs(txBlobs)
+			decoded, err := s.decodeTxs(ctx, repoPath, txBlobs, trusted)
 			if err != nil {
 				_ = storeTx.Rollback()
 				return result, err
 			}
 
-			if err := s.applyTxs(ctx, storeTx, decoded, collections, &result); err != nil {
+			historyCommit := ""
+			if opts.History {
+				historyCommit = commitHash
+			}
+			if err := s.applyTxs(ctx, storeTx, decoded, collections, &result, historyCommit); err != nil {
 				_ = storeTx.Rollback()
 				return result, err
 			}
@@ -188,13 +251,13 @@ func (s *SyncService) syncState(ctx context.Context, repoPath string, opts SyncO
 
 	collections := make(map[string]struct{})
 	if len(stateResult.Txs) > 0 {
-		decoded, err := s.decodeTxs(stateResult.Txs)
+		decoded, err := s.decodeTxs(ctx, repoPath, stateResult.Txs, make(map[string][]string))
 		if err != nil {
 			_ = storeTx.Rollback()
 			return result, err
 		}
 
-		if err := s.applyTxs(ctx, storeTx, decoded, collections, &result); err != nil {
+		if err := s.applyTxs(ctx, storeTx, decoded, collections, &result, ""); err != nil {
 			_ = storeTx.Rollback()
 			return result, err
 		}
@@ -223,7 +286,9 @@ type decodedTx struct {
 	Bytes []byte
 }
 
-func (s *SyncService) decodeTxs(blobs []CommitTx) ([]decodedTx, error) {
+// decodeTxs decodes and validates blobs, applying each collection's trusted
+// keys. trusted caches the keys per collection across calls.
+func (s *SyncService) decodeTxs(ctx context.Context, repoPath string, blobs []CommitTx, trusted map[string][]string) ([]decodedTx, error) {
 	decoded := make([]decodedTx, 0, len(blobs))
 	for _, blob := range blobs {
 		tx, err := s.decoder.Decode(blob.Bytes)
@@ -233,6 +298,9 @@ func (s *SyncService) decodeTxs(blobs []CommitTx) ([]decodedTx, error) {
 		if err := tx.Validate(); err != nil {
 			return nil, err
 		}
+		if err := s.checkSigner(ctx, repoPath, tx, trusted); err != nil {
+			return nil, err
+		}
 		decoded = append(decoded, decodedTx{Tx: tx, Bytes: blob.Bytes})
 	}
 
@@ -246,7 +314,9 @@ func (s *SyncService) decodeTxs(blobs []CommitTx) ([]decodedTx, error) {
 	return decoded, nil
 }
 
-func (s *SyncService) applyTxs(ctx context.Context, storeTx StoreTx, txs []decodedTx, collections map[string]struct{}, result *SyncResult) error {
+// applyTxs projects txs onto the collection tables. A non-empty
+// historyCommit also appends each tx to the collection history table.
+func (s *SyncService) applyTxs(ctx context.Context, storeTx StoreTx, txs []decodedTx, collections map[string]struct{}, result *SyncResult, historyCommit string) error {
 	for _, item := range txs {
 		tx := item.Tx
 		if _, err := storeTx.EnsureCollection(ctx, tx.Collection); err != nil {
@@ -254,46 +324,52 @@ func (s *SyncService) applyTxs(ctx context.Context, storeTx StoreTx, txs []decod
 		}
 		collections[tx.Collection] = struct{}{}
 
+		var payload []byte
+		var err error
 		switch tx.Op {
 		case domain.TxOpPut:
-			payload, err := s.canonicalizer.Canonicalize(ctx, tx.Snapshot)
-			if err != nil {
-				return err
-			}
-			if err := storeTx.UpsertDoc(ctx, tx.Collection, s.newRecord(tx, item.Bytes, payload, false)); err != nil {
-				return err
-			}
-			result.TxsApplied++
-			result.DocsUpserted++
+			payload, err = s.canonicalizer.Canonicalize(ctx, tx.Snapshot)
 		case domain.TxOpPatch:
-			payload, err := s.applyPatch(ctx, storeTx, tx)
-			if err != nil {
-				return err
-			}
-			if err := storeTx.UpsertDoc(ctx, tx.Collection, s.newRecord(tx, item.Bytes, payload, false)); err != nil {
-				return err
-			}
-			result.TxsApplied++
-			result.DocsUpserted++
+			payload, err = s.applyPatch(ctx, storeTx, tx)
 		case domain.TxOpMerge:
-			payload, err := s.applyMerge(ctx, storeTx, tx)
-			if err != nil {
-				return err
-			}
-			if err := storeTx.UpsertDoc(ctx, tx.Collection, s.newRecord(tx, item.Bytes, payload, false)); err != nil {
-				return err
-			}
-			result.TxsApplied++
-			result.DocsUpserted++
+			payload, err = s.applyMerge(ctx, storeTx, tx)
 		case domain.TxOpDelete:
-			if err := storeTx.UpsertDoc(ctx, tx.Collection, s.newRecord(tx, item.Bytes, nil, true)); err != nil {
-				return err
-			}
-			result.TxsApplied++
-			result.DocsDeleted++
 		default:
 			return domain.ErrInvalidOp
 		}
+		if err != nil {
+			return err
+		}
+
+		deleted := tx.Op == domain.TxOpDelete
+		record := s.newRecord(tx, item.Bytes, payload, deleted)
+		if err := storeTx.UpsertDoc(ctx, tx.Collection, record); err != nil {
+			return err
+		}
+		result.TxsApplied++
+		if deleted {
+			result.DocsDeleted++
+		} else {
+			result.DocsUpserted++
+		}
+		if err := storeTx.UpdateFullText(ctx, tx.Collection, tx.DocID); err != nil {
+			return err
+		}
+		if historyCommit != "" {
+			if err := storeTx.AppendHistory(ctx, tx.Collection, HistoryRecord{
+				TxHash:     record.TxHash,
+				TxID:       tx.TxID,
+				DocID:      tx.DocID,
+				ParentHash: tx.ParentHash,
+				Op:         record.Op,
+				Timestamp:  tx.Timestamp,
+				CommitHash: historyCommit,
+				Patch:      tx.Patch,
+				Payload:    payload,
+			}); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -337,6 +413,22 @@ func (s *SyncService) applyMerge(ctx context.Context, storeTx StoreTx, tx domain
 	return s.canonicalizer.Canonicalize(ctx, updated)
 }
 
+func (s *SyncService) checkSigner(ctx context.Context, repoPath string, tx domain.Transaction, trusted map[string][]string) error {
+	if s.trust == nil {
+		return nil
+	}
+	keys, ok := trusted[tx.Collection]
+	if !ok {
+		var err error
+		keys, err = s.trust.LoadTrustedKeys(ctx, repoPath, tx.Collection)
+		if err != nil {
+			return err
+		}
+		trusted[tx.Collection] = keys
+	}
+	return domain.CheckSigner(keys, tx)
+}
+
 func (s *SyncService) newRecord(tx domain.Transaction, txBytes []byte, payload []byte, deleted bool) DocRecord {
 	return DocRecord{
 		DocID:         tx.DocID,
@@ -3,6 +3,9 @@ package index
 import (
 	"context"
 	"errors"
+	"reflect"
+	"slices"
+	"sort"
 	"testing"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
@@ -44,6 +47,10 @@ func (f fakeSource) StateTxsSince(ctx context.Context, repoPath string, state St
 type memStore struct {
 	state       State
 	collections map[string]map[string]DocRecord
+	indexes     map[string][]string
+	fullText    map[string][]string
+	textUpdates []string
+	history     map[string][]HistoryRecord
 	resetCalled bool
 	beginCount  int
 }
@@ -98,11 +105,66 @@ func (m *memStoreTx) UpsertDoc(ctx context.Context, collection string, record Do
 	return nil
 }
 
+func (m *memStoreTx) AppendHistory(ctx context.Context, collection string, record HistoryRecord) error {
+	if m.store.history == nil {
+		m.store.history = make(map[string][]HistoryRecord)
+	}
+	m.store.history[collection] = append(m.store.history[collection], record)
+	return nil
+}
+
 func (m *memStoreTx) SetState(ctx context.Context, state State) error {
 	m.store.state = state
 	return nil
 }
 
+func (m *memStoreTx) Collections(ctx context.Context) ([]string, error) {
+	var collections []string
+	for collection := range m.store.collections {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+func (m *memStoreTx) EnsureIndexes(ctx context.Context, collection string, fields []string) (IndexChanges, error) {
+	if m.store.indexes == nil {
+		m.store.indexes = make(map[string][]string)
+	}
+	var changes IndexChanges
+	current := make(map[string]bool)
+	for _, field := range m.store.indexes[collection] {
+		current[field] = true
+	}
+	for _, field := range fields {
+		if !current[field] {
+			changes.Created = append(changes.Created, field)
+		}
+		delete(current, field)
+	}
+	for field := range current {
+		changes.Dropped = append(changes.Dropped, field)
+	}
+	m.store.indexes[collection] = fields
+	return changes, nil
+}
+
+func (m *memStoreTx) EnsureFullText(ctx context.Context, collection string, fields []string) (bool, error) {
+	if m.store.fullText == nil {
+		m.store.fullText = make(map[string][]string)
+	}
+	if slices.Equal(m.store.fullText[collection], fields) {
+		return false, nil
+	}
+	m.store.fullText[collection] = fields
+	return true, nil
+}
+
+func (m *memStoreTx) UpdateFullText(ctx context.Context, collection, docID string) error {
+	m.store.textUpdates = append(m.store.textUpdates, collection+"/"+docID)
+	return nil
+}
+
 func (m *memStoreTx) Commit() error {
 	return nil
 }
@@ -187,11 +249,13 @@ func TestSyncServiceAppliesTxs(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{out: []byte(`{"a":2}`)},
 		testHasher{},
+		nil,
 	)
 
 	result, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false})
@@ -242,11 +306,13 @@ func TestSyncServiceStateModeAppliesTxs(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{},
 		testHasher{},
+		nil,
 	)
 
 	result, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false, Mode: ModeState})
@@ -287,11 +353,13 @@ func TestSyncServiceMissingDoc(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{out: []byte(`{"a":2}`)},
 		testHasher{},
+		nil,
 	)
 
 	if _, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false}); err == nil || !errors.Is(err, ErrMissingDocument) {
@@ -326,11 +394,13 @@ func TestSyncServiceStateModeFallsBackToHistory(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{},
 		testHasher{},
+		nil,
 	)
 
 	result, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false, Mode: ModeState})
@@ -398,11 +468,13 @@ func TestSyncServiceResetsOnMissingCommit(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{},
 		testHasher{},
+		nil,
 	)
 
 	result, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false, AllowReset: true})
@@ -462,11 +534,13 @@ func TestSyncServiceBatchesCommits(t *testing.T) {
 	service := NewSyncService(
 		nil,
 		source,
+		nil,
 		store,
 		passCanonicalizer{},
 		decoder,
 		fakePatcher{},
 		testHasher{},
+		nil,
 	)
 
 	result, err := service.Sync(context.Background(), "repo", SyncOptions{Fetch: false, BatchCommits: 2})
@@ -483,3 +557,149 @@ func TestSyncServiceBatchesCommits(t *testing.T) {
 		t.Fatalf("expected last commit to be c3, got %s", store.state.LastCommit)
 	}
 }
+
+type mapDeclarations map[string][]string
+
+func (d mapDeclarations) LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return d[collection], nil
+}
+
+func (d mapDeclarations) LoadFullText(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return nil, nil
+}
+
+type fullTextDeclarations map[string][]string
+
+func (d fullTextDeclarations) LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return nil, nil
+}
+
+func (d fullTextDeclarations) LoadFullText(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return d[collection], nil
+}
+
+func TestSyncServiceMaintainsFullText(t *testing.T) {
+	store := newMemStore()
+	source := fakeSource{
+		commits: []string{"c1"},
+		txs:     map[string][]CommitTx{"c1": {{Bytes: []byte("tx1")}, {Bytes: []byte("tx2")}}},
+	}
+	decoder := mapDecoder{
+		txs: map[string]domain.Transaction{
+			"tx1": {TxID: "tx1", Timestamp: 1, Collection: "notes", DocID: "n1", Op: domain.TxOpPut, Snapshot: []byte(`{"body":"hello"}`)},
+			"tx2": {TxID: "tx2", Timestamp: 2, Collection: "notes", DocID: "n1", Op: domain.TxOpDelete},
+		},
+	}
+	declarations := fullTextDeclarations{"notes": {"body"}}
+	service := NewSyncService(nil, source, declarations, store, passCanonicalizer{}, decoder, nil, testHasher{}, nil)
+
+	result, err := service.Sync(context.Background(), "repo", SyncOptions{})
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+	if result.FullTextRebuilt != 1 {
+		t.Fatalf("expected the full-text table to be built, got %+v", result)
+	}
+	if !reflect.DeepEqual(store.textUpdates, []string{"notes/n1", "notes/n1"}) {
+		t.Fatalf("expected every applied tx to refresh full text, got %v", store.textUpdates)
+	}
+
+	service = NewSyncService(nil, fakeSource{}, declarations, store, passCanonicalizer{}, decoder, nil, testHasher{}, nil)
+	result, err = service.Sync(context.Background(), "repo", SyncOptions{})
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+	if result.FullTextRebuilt != 0 {
+		t.Fatalf("expected unchanged declarations to keep the table, got %+v", result)
+	}
+}
+
+func TestSyncServiceReconcilesDeclaredIndexes(t *testing.T) {
+	store := newMemStore()
+	source := fakeSource{
+		commits: []string{"c1"},
+		txs:     map[string][]CommitTx{"c1": {{Bytes: []byte("tx1")}}},
+	}
+	decoder := mapDecoder{
+		txs: map[string]domain.Transaction{
+			"tx1": {TxID: "tx1", Timestamp: 1, Collection: "users", DocID: "u1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)},
+		},
+	}
+	declarations := mapDeclarations{"users": {"assignee", "status"}}
+	service := NewSyncService(nil, source, declarations, store, passCanonicalizer{}, decoder, nil, testHasher{}, nil)
+
+	result, err := service.Sync(context.Background(), "repo", SyncOptions{})
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+	if result.IndexesCreated != 2 || result.IndexesDropped != 0 {
+		t.Fatalf("unexpected index changes: %+v", result)
+	}
+
+	declarations["users"] = []string{"status"}
+	service = NewSyncService(nil, fakeSource{}, declarations, store, passCanonicalizer{}, decoder, nil, testHasher{}, nil)
+	result, err = service.Sync(context.Background(), "repo", SyncOptions{})
+	if err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+	if result.IndexesCreated != 0 || result.IndexesDropped != 1 {
+		t.Fatalf("expected the removed declaration to be dropped without new commits: %+v", result)
+	}
+}
+
+func TestSyncServiceRecordsHistory(t *testing.T) {
+	store := newMemStore()
+	source := fakeSource{
+		commits: []string{"c1", "c2"},
+		txs: map[string][]CommitTx{
+			"c1": {{Bytes: []byte("tx1")}},
+			"c2": {{Bytes: []byte("tx2")}, {Bytes: []byte("tx3")}},
+		},
+		stateErr: errors.New("state mode must not be used"),
+	}
+	decoder := mapDecoder{
+		txs: map[string]domain.Transaction{
+			"tx1": {TxID: "tx1", Timestamp: 1, Collection: "tasks", DocID: "t1", Op: domain.TxOpPut, Snapshot: []byte(`{"status":"open"}`)},
+			"tx2": {TxID: "tx2", Timestamp: 2, Collection: "tasks", DocID: "t1", Op: domain.TxOpPatch, Patch: []byte(`[{"op":"replace","path":"/status","value":"done"}]`), ParentHash: "hash:tx1"},
+			"tx3": {TxID: "tx3", Timestamp: 3, Collection: "tasks", DocID: "t1", Op: domain.TxOpDelete, ParentHash: "hash:tx2"},
+		},
+	}
+	service := NewSyncService(nil, source, nil, store, passCanonicalizer{}, decoder, fakePatcher{out: []byte(`{"status":"done"}`)}, testHasher{}, nil)
+
+	if _, err := service.Sync(context.Background(), "repo", SyncOptions{Mode: ModeState, History: true}); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+
+	want := []HistoryRecord{
+		{TxHash: "hash:tx1", TxID: "tx1", DocID: "t1", Op: "put", Timestamp: 1, CommitHash: "c1", Payload: []byte(`{"status":"open"}`)},
+		{TxHash: "hash:tx2", TxID: "tx2", DocID: "t1", ParentHash: "hash:tx1", Op: "patch", Timestamp: 2, CommitHash: "c2", Patch: []byte(`[{"op":"replace","path":"/status","value":"done"}]`), Payload: []byte(`{"status":"done"}`)},
+		{TxHash: "hash:tx3", TxID: "tx3", DocID: "t1", ParentHash: "hash:tx2", Op: "delete", Timestamp: 3, CommitHash: "c2"},
+	}
+	if !reflect.DeepEqual(store.history["tasks"], want) {
+		t.Fatalf("unexpected history:\n got %+v\nwant %+v", store.history["tasks"], want)
+	}
+	if !store.collections["tasks"]["t1"].Deleted {
+		t.Fatalf("expected latest state to still be projected")
+	}
+}
+
+func TestSyncServiceSkipsHistoryByDefault(t *testing.T) {
+	store := newMemStore()
+	source := fakeSource{
+		commits: []string{"c1"},
+		txs:     map[string][]CommitTx{"c1": {{Bytes: []byte("tx1")}}},
+	}
+	decoder := mapDecoder{
+		txs: map[string]domain.Transaction{
+			"tx1": {TxID: "tx1", Timestamp: 1, Collection: "tasks", DocID: "t1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)},
+		},
+	}
+	service := NewSyncService(nil, source, nil, store, passCanonicalizer{}, decoder, nil, testHasher{}, nil)
+
+	if _, err := service.Sync(context.Background(), "repo", SyncOptions{Mode: ModeHistory}); err != nil {
+		t.Fatalf("expected sync to succeed: %v", err)
+	}
+	if len(store.history) != 0 {
+		t.Fatalf("expected no history rows, got %+v", store.history)
+	}
+}
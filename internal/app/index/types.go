@@ -61,20 +61,53 @@ type DocRecord struct {
 	Deleted       bool
 }
 
+// HistoryRecord is one applied transaction together with the document it
+// produced. Payload is nil for deletes.
+type HistoryRecord struct {
+	TxHash     string
+	TxID       string
+	DocID      string
+	ParentHash string
+	Op         string
+	Timestamp  int64
+	CommitHash string
+	Patch      []byte
+	Payload    []byte
+}
+
+type IndexChanges struct {
+	Created []string
+	Dropped []string
+}
+
 type SyncOptions struct {
 	Fetch        bool
 	AllowReset   bool
 	BatchCommits int
 	Mode         Mode
+	History      bool
 }
 
 type SyncResult struct {
-	Reset        bool
-	Fetched      bool
-	Commits      int
-	TxsApplied   int
-	DocsUpserted int
-	DocsDeleted  int
-	Collections  int
-	LastCommit   string
+	Reset           bool
+	Fetched         bool
+	Commits         int
+	TxsApplied      int
+	DocsUpserted    int
+	DocsDeleted     int
+	Collections     int
+	LastCommit      string
+	IndexesCreated  int
+	IndexesDropped  int
+	FullTextRebuilt int
+}
+
+type SearchOptions struct {
+	Limit int
+}
+
+type SearchHit struct {
+	DocID   string
+	Score   float64
+	Snippet string
 }
@@ -0,0 +1,98 @@
+package integrity
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+)
+
+type AnchorService struct {
+	store    AnchorStore
+	backends []AnchorBackend
+}
+
+func NewAnchorService(store AnchorStore, backends []AnchorBackend) *AnchorService {
+	return &AnchorService{
+		store:    store,
+		backends: backends,
+	}
+}
+
+// Anchor records main's head with every backend whose latest anchor holds
+// another commit, saving each receipt beside the ledger. The anchor time is
+// the one the backend reports, never the ledger's own clock.
+func (s *AnchorService) Anchor(ctx context.Context, repoPath string) ([]AnchorResult, error) {
+	if len(s.backends) == 0 {
+		return nil, ErrAnchorBackendRequired
+	}
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	head, err := s.store.LoadMainHead(ctx, absRepoPath)
+	if err != nil {
+		return nil, err
+	}
+	if head == "" {
+		return nil, fmt.Errorf("%w: main", docapp.ErrRefNotFound)
+	}
+	anchors, err := s.store.ListAnchors(ctx, absRepoPath)
+	if err != nil {
+		return nil, err
+	}
+	latest := make(map[string]Anchor, len(s.backends))
+	for _, anchor := range anchors {
+		latest[anchor.Backend] = anchor
+	}
+
+	results := make([]AnchorResult, 0, len(s.backends))
+	for _, backend := range s.backends {
+		if last, ok := latest[backend.Name()]; ok && last.Commit == head {
+			results = append(results, AnchorResult{Anchor: last, Skipped: true})
+			continue
+		}
+		anchor, err := backend.Anchor(ctx, absRepoPath, head)
+		if err != nil {
+			return nil, fmt.Errorf("anchor with %s: %w", backend.Name(), err)
+		}
+		anchor.Backend = backend.Name()
+		anchor.Commit = head
+		if err := s.store.SaveAnchor(ctx, absRepoPath, anchor); err != nil {
+			return nil, err
+		}
+		results = append(results, AnchorResult{Anchor: anchor})
+	}
+	return results, nil
+}
+
+// Watch anchors main's head and then again every interval until ctx is
+// cancelled.
+func (s *AnchorService) Watch(ctx context.Context, repoPath string, interval time.Duration, emit func([]AnchorResult) error) error {
+	if interval <= 0 {
+		return ErrInvalidAnchorInterval
+	}
+	for {
+		results, err := s.Anchor(ctx, repoPath)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := emit(results); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+	}
+}
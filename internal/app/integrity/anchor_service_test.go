@@ -0,0 +1,153 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+)
+
+type memoryAnchors struct {
+	head      string
+	anchors   []Anchor
+	contained map[string]bool
+}
+
+func (m *memoryAnchors) LoadMainHead(ctx context.Context, repoPath string) (string, error) {
+	return m.head, nil
+}
+
+func (m *memoryAnchors) SaveAnchor(ctx context.Context, repoPath string, anchor Anchor) error {
+	m.anchors = append(m.anchors, anchor)
+	return nil
+}
+
+func (m *memoryAnchors) ListAnchors(ctx context.Context, repoPath string) ([]Anchor, error) {
+	return m.anchors, nil
+}
+
+func (m *memoryAnchors) IsMainAncestor(ctx context.Context, repoPath, commit string) (bool, error) {
+	return m.contained[commit], nil
+}
+
+// fakeBackend issues the commit as its receipt, stamped with its own time.
+type fakeBackend struct {
+	name string
+	at   time.Time
+}
+
+func (f fakeBackend) Name() string {
+	return f.name
+}
+
+func (f fakeBackend) Anchor(ctx context.Context, repoPath, commit string) (Anchor, error) {
+	return Anchor{Time: f.at, Receipt: []byte(commit)}, nil
+}
+
+type fakeRecord struct {
+	name    string
+	anchors []Anchor
+	err     error
+}
+
+func (f fakeRecord) Name() string {
+	return f.name
+}
+
+func (f fakeRecord) ReadAnchors(ctx context.Context, repoPath string) ([]Anchor, error) {
+	return f.anchors, f.err
+}
+
+func TestAnchorSkipsUnchangedHead(t *testing.T) {
+	store := &memoryAnchors{head: "c1"}
+	at := time.Unix(100, 0)
+	service := NewAnchorService(store, []AnchorBackend{fakeBackend{name: "file", at: at.Add(time.Second)}, fakeBackend{name: "tsa", at: at}})
+
+	results, err := service.Anchor(context.Background(), "repo")
+	if err != nil {
+		t.Fatalf("Anchor returned error: %v", err)
+	}
+	if len(results) != 2 || results[0].Skipped || len(store.anchors) != 2 {
+		t.Fatalf("expected two new anchors, got %+v", results)
+	}
+	if results[1].Anchor.Backend != "tsa" || results[1].Anchor.Commit != "c1" || !results[1].Anchor.Time.Equal(at) || !results[0].Anchor.Time.Equal(at.Add(time.Second)) {
+		t.Fatalf("unexpected anchor %+v", results[1].Anchor)
+	}
+
+	results, err = service.Anchor(context.Background(), "repo")
+	if err != nil {
+		t.Fatalf("Anchor returned error: %v", err)
+	}
+	if !results[0].Skipped || !results[1].Skipped || len(store.anchors) != 2 {
+		t.Fatalf("expected both backends to skip an unchanged head, got %+v", results)
+	}
+
+	store.head = "c2"
+	results, err = service.Anchor(context.Background(), "repo")
+	if err != nil {
+		t.Fatalf("Anchor returned error: %v", err)
+	}
+	if results[0].Skipped || len(store.anchors) != 4 {
+		t.Fatalf("expected a new head to be anchored, got %+v", results)
+	}
+}
+
+func TestAnchorRequiresBackendAndHead(t *testing.T) {
+	service := NewAnchorService(&memoryAnchors{head: "c1"}, nil)
+	if _, err := service.Anchor(context.Background(), "repo"); !errors.Is(err, ErrAnchorBackendRequired) {
+		t.Fatalf("expected ErrAnchorBackendRequired, got %v", err)
+	}
+	service = NewAnchorService(&memoryAnchors{}, []AnchorBackend{fakeBackend{name: "file"}})
+	if _, err := service.Anchor(context.Background(), "repo"); !errors.Is(err, doc.ErrRefNotFound) {
+		t.Fatalf("expected ErrRefNotFound, got %v", err)
+	}
+	if err := service.Watch(context.Background(), "repo", 0, nil); !errors.Is(err, ErrInvalidAnchorInterval) {
+		t.Fatalf("expected ErrInvalidAnchorInterval, got %v", err)
+	}
+}
+
+func TestVerifyAnchorsReadsExternalRecords(t *testing.T) {
+	// The in-repo receipts are only a cache: emptying them must not hide a
+	// rewrite the external records still show.
+	anchors := &memoryAnchors{contained: map[string]bool{"c1": true, "c3": true}}
+	service := NewVerifyService(fakeLister{}, fakeStore{}, mapDecoder{}, mapHasher{}, nil, nil, nil, nil, nil, anchors)
+
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{
+		AnchorRecords: []AnchorRecord{
+			fakeRecord{name: "file", anchors: []Anchor{{Commit: "c1"}, {Commit: "c2"}}},
+			fakeRecord{name: "tsa", anchors: []Anchor{{Commit: "c3"}}, err: ErrAnchorMismatch},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Anchors != 3 {
+		t.Fatalf("expected 3 anchors checked, got %d", result.Anchors)
+	}
+	want := []struct{ commit, code string }{
+		{"c2", IssueAnchorRewritten},
+		{"", IssueAnchorInvalid},
+	}
+	if len(result.Issues) != len(want) {
+		t.Fatalf("expected %d issues, got %+v", len(want), result.Issues)
+	}
+	for i, issue := range result.Issues {
+		if issue.CommitHash != want[i].commit || issue.Code != want[i].code {
+			t.Fatalf("issue %d: expected %s %s, got %+v", i, want[i].commit, want[i].code, issue)
+		}
+	}
+
+	_, err = service.Verify(context.Background(), "repo", VerifyOptions{
+		AnchorRecords: []AnchorRecord{fakeRecord{name: "file", anchors: []Anchor{{Commit: "c1"}}}, fakeRecord{name: "git"}},
+	})
+	if !errors.Is(err, ErrAnchorsNotFound) {
+		t.Fatalf("expected ErrAnchorsNotFound for an empty record, got %v", err)
+	}
+
+	service = NewVerifyService(fakeLister{}, fakeStore{}, mapDecoder{}, mapHasher{}, nil, nil, nil, nil, nil, nil)
+	if _, err := service.Verify(context.Background(), "repo", VerifyOptions{AnchorRecords: []AnchorRecord{fakeRecord{name: "file"}}}); !errors.Is(err, ErrAnchorsUnavailable) {
+		t.Fatalf("expected ErrAnchorsUnavailable, got %v", err)
+	}
+}
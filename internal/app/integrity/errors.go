@@ -0,0 +1,16 @@
+package integrity
+
+import "errors"
+
+var ErrCommitUnsigned = errors.New("commit is not signed")
+var ErrBadSignature = errors.New("commit signature is not valid")
+var ErrKeyringNotFound = errors.New("keyring not found")
+var ErrSignaturesUnavailable = errors.New("commit signature verification is not available")
+var ErrInvalidWorkers = errors.New("invalid worker count")
+var ErrInvalidProof = errors.New("invalid proof")
+var ErrAnchorBackendRequired = errors.New("anchor backend is required")
+var ErrAnchorMismatch = errors.New("anchor receipt does not match")
+var ErrAnchorsUnavailable = errors.New("anchor verification is not available")
+var ErrAnchorsNotFound = errors.New("no anchors found")
+var ErrInvalidAnchorInterval = errors.New("invalid anchor interval")
+var ErrTrustWeakened = errors.New("trusted keys weakened")
@@ -9,13 +9,82 @@ import (
 
 type StreamLister interface {
 	ListDocStreams(ctx context.Context, repoPath string) ([]string, error)
+	ListStateStreams(ctx context.Context, repoPath string) ([]string, error)
 }
 
 type ReadStore interface {
 	LoadStreamHead(ctx context.Context, repoPath, streamPath string) (string, error)
+	LoadHeadTx(ctx context.Context, repoPath, streamPath string) (doc.TxBlob, error)
 	LoadStreamTxs(ctx context.Context, repoPath, streamPath string) ([]doc.TxBlob, error)
 }
 
+// Snapshot reads streams from one pinned commit of main, ignoring the repo
+// path its methods take. It is not safe for concurrent use.
+type Snapshot interface {
+	StreamLister
+	ReadStore
+	Commit() string
+}
+
+type SnapshotOpener interface {
+	OpenSnapshot(ctx context.Context, repoPath, commit string) (Snapshot, error)
+}
+
+type Checkpoint interface {
+	Load(ctx context.Context) (Progress, bool, error)
+	Save(ctx context.Context, progress Progress) error
+	Clear(ctx context.Context) error
+}
+
+type InclusionStore interface {
+	// LoadInclusion reads the objects that link commit, or main when empty,
+	// to a stream's HEAD and tx files.
+	LoadInclusion(ctx context.Context, repoPath, commit, streamPath string) (Inclusion, error)
+	// VerifyInclusion checks that the objects hash up to the commit without
+	// a repository.
+	VerifyInclusion(ctx context.Context, inclusion Inclusion) error
+}
+
+// AnchorBackend records a commit hash with a party outside the repository.
+// The anchor it returns carries the time and receipt that party issued.
+type AnchorBackend interface {
+	Name() string
+	Anchor(ctx context.Context, repoPath, commit string) (Anchor, error)
+}
+
+// AnchorRecord reads every anchor back from where a backend keeps it, oldest
+// first. A record that is partly damaged returns the anchors it can still
+// confirm together with an error wrapping ErrAnchorMismatch.
+type AnchorRecord interface {
+	Name() string
+	ReadAnchors(ctx context.Context, repoPath string) ([]Anchor, error)
+}
+
+// AnchorStore keeps the receipts of past anchors in the repository. They
+// only spare unchanged heads from being anchored again; verification reads
+// the external records instead.
+type AnchorStore interface {
+	LoadMainHead(ctx context.Context, repoPath string) (string, error)
+	SaveAnchor(ctx context.Context, repoPath string, anchor Anchor) error
+	// ListAnchors returns the saved anchors, oldest first.
+	ListAnchors(ctx context.Context, repoPath string) ([]Anchor, error)
+	// IsMainAncestor reports whether commit is main's head or one of its
+	// ancestors.
+	IsMainAncestor(ctx context.Context, repoPath, commit string) (bool, error)
+}
+
+type TrustStore interface {
+	LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error)
+	// ListTrustChanges returns every commit reachable from ref that changed
+	// a collection's trusted keys; an empty ref means main.
+	ListTrustChanges(ctx context.Context, repoPath, ref string) ([]TrustChange, error)
+}
+
+type CommitVerifier interface {
+	ListMainCommits(ctx context.Context, repoPath string) ([]string, error)
+	VerifyCommitSignature(ctx context.Context, repoPath, commitHash, keyring string) error
+}
+
 type Decoder interface {
 	Decode(data []byte) (domain.Transaction, error)
 }
@@ -28,18 +97,60 @@ type Patcher interface {
 	Apply(ctx context.Context, doc, patch []byte) ([]byte, error)
 }
 
+type Canonicalizer interface {
+	Canonicalize(ctx context.Context, input []byte) ([]byte, error)
+}
+
 type VerifyOptions struct {
 	Deep bool
+	// State rehydrates every stream and compares it with its state/ entry,
+	// which reads serve first.
+	State bool
+	// Signatures checks the signature of every commit on main against
+	// Keyring: a GnuPG home directory or an SSH allowed_signers file. An
+	// empty Keyring uses the git configuration of the host.
+	Signatures bool
+	Keyring    string
+	// Workers verifies that many streams at once; below one means one.
+	Workers int
+	// Checkpoint records progress so an interrupted run resumes after the
+	// last verified stream. It is cleared once a run completes.
+	Checkpoint Checkpoint
+	// AnchorRecords checks that main still contains every commit each
+	// record holds. A record with no anchors fails the run.
+	AnchorRecords []AnchorRecord
+	// Progress is called after each verified stream with the streams done so
+	// far, including resumed ones, and the total.
+	Progress func(done, total int)
 }
 
 type VerifyResult struct {
-	Streams int
-	Valid   int
-	Issues  []Issue
+	Snapshot string
+	Streams  int
+	Valid    int
+	Resumed  int
+	Commits  int
+	Anchors  int
+	Issues   []Issue
+}
+
+// Progress is a checkpointed run: every stream of Snapshot up to and
+// including LastStream has been verified with the Deep and State options.
+type Progress struct {
+	Snapshot   string
+	Deep       bool
+	State      bool
+	LastStream string
+	Streams    int
+	Valid      int
+	Issues     []Issue
 }
 
 type Issue struct {
 	StreamPath string
+	CommitHash string
+	Collection string
+	DocID      string
 	Code       string
 	Message    string
 }
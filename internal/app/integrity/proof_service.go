@@ -0,0 +1,181 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type ProofService struct {
+	inclusions InclusionStore
+	decoder    Decoder
+	hasher     Hasher
+	layout     domain.StreamLayout
+}
+
+func NewProofService(inclusions InclusionStore, decoder Decoder, hasher Hasher, layout domain.StreamLayout) *ProofService {
+	return &ProofService{
+		inclusions: inclusions,
+		decoder:    decoder,
+		hasher:     hasher,
+		layout:     layout,
+	}
+}
+
+// Prove collects the objects an auditor needs to check, without the
+// repository, that a document's current transaction chain is in commit, or
+// in main when commit is empty.
+func (s *ProofService) Prove(ctx context.Context, repoPath, collection, docID, commit string) (Proof, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return Proof{}, docapp.ErrCollectionRequired
+	}
+	docID = strings.TrimSpace(docID)
+	if docID == "" {
+		return Proof{}, docapp.ErrDocIDRequired
+	}
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	streamPath := filepath.ToSlash(domain.StreamPath(s.layout, collection, docID))
+	inclusion, err := s.inclusions.LoadInclusion(ctx, absRepoPath, strings.TrimSpace(commit), streamPath)
+	if err != nil {
+		return Proof{}, err
+	}
+
+	headName, err := headTxName(inclusion.Head)
+	if err != nil {
+		return Proof{}, err
+	}
+	byName := make(map[string]ProofTx, len(inclusion.Txs))
+	byHash := make(map[string]ProofTx, len(inclusion.Txs))
+	for _, tx := range inclusion.Txs {
+		byName[tx.Name] = tx
+		byHash[s.hasher.SumHex(tx.Blob)] = tx
+	}
+
+	current, ok := byName[headName]
+	if !ok {
+		return Proof{}, fmt.Errorf("%w: head tx %s not found", docapp.ErrDocNotFound, headName)
+	}
+	var chain []ProofTx
+	for {
+		chain = append(chain, current)
+		if len(chain) > len(inclusion.Txs) {
+			return Proof{}, fmt.Errorf("cycle detected in %s", streamPath)
+		}
+		tx, err := s.decoder.Decode(current.Blob)
+		if err != nil {
+			return Proof{}, fmt.Errorf("decode tx %s: %w", current.Name, err)
+		}
+		if tx.ParentHash == "" {
+			break
+		}
+		parent, ok := byHash[tx.ParentHash]
+		if !ok {
+			return Proof{}, fmt.Errorf("missing tx %s in %s", tx.ParentHash, streamPath)
+		}
+		current = parent
+	}
+	inclusion.Txs = chain
+
+	return Proof{Collection: collection, DocID: docID, Inclusion: inclusion}, nil
+}
+
+// Check validates a proof offline: the objects must hash up to the commit,
+// HEAD must name the first tx, and every tx must decode, belong to the
+// document and hash to its successor's parent, ending at a genesis tx.
+func (s *ProofService) Check(ctx context.Context, proof Proof) (ProofResult, error) {
+	if proof.Collection == "" || proof.DocID == "" {
+		return ProofResult{}, fmt.Errorf("%w: collection and doc id are required", ErrInvalidProof)
+	}
+	inclusion := proof.Inclusion
+	if !isStreamPathOf(inclusion.StreamPath, proof.Collection, proof.DocID) {
+		return ProofResult{}, fmt.Errorf("%w: stream path %s is not %s/%s", ErrInvalidProof, inclusion.StreamPath, proof.Collection, proof.DocID)
+	}
+	if len(inclusion.Txs) == 0 {
+		return ProofResult{}, fmt.Errorf("%w: no transactions", ErrInvalidProof)
+	}
+	if err := s.inclusions.VerifyInclusion(ctx, inclusion); err != nil {
+		return ProofResult{}, err
+	}
+
+	headName, err := headTxName(inclusion.Head)
+	if err != nil {
+		return ProofResult{}, fmt.Errorf("%w: %v", ErrInvalidProof, err)
+	}
+	if headName != inclusion.Txs[0].Name {
+		return ProofResult{}, fmt.Errorf("%w: HEAD names %s, proof starts at %s", ErrInvalidProof, headName, inclusion.Txs[0].Name)
+	}
+
+	var head domain.Transaction
+	var parentHash string
+	for i, entry := range inclusion.Txs {
+		if err := ctx.Err(); err != nil {
+			return ProofResult{}, err
+		}
+		tx, err := s.decoder.Decode(entry.Blob)
+		if err != nil {
+			return ProofResult{}, fmt.Errorf("%w: decode tx %s: %v", ErrInvalidProof, entry.Name, err)
+		}
+		if err := tx.Validate(); err != nil {
+			return ProofResult{}, fmt.Errorf("%w: tx %s: %v", ErrInvalidProof, entry.Name, err)
+		}
+		if tx.Collection != proof.Collection || tx.DocID != proof.DocID {
+			return ProofResult{}, fmt.Errorf("%w: tx %s belongs to %s/%s", ErrInvalidProof, entry.Name, tx.Collection, tx.DocID)
+		}
+		if i > 0 && s.hasher.SumHex(entry.Blob) != parentHash {
+			return ProofResult{}, fmt.Errorf("%w: tx %s is not the parent of the tx before it", ErrInvalidProof, entry.Name)
+		}
+		if i == 0 {
+			head = tx
+		}
+		parentHash = tx.ParentHash
+	}
+	if parentHash != "" {
+		return ProofResult{}, fmt.Errorf("%w: chain stops before its genesis tx", ErrInvalidProof)
+	}
+
+	return ProofResult{
+		Collection: proof.Collection,
+		DocID:      proof.DocID,
+		Commit:     inclusion.Commit,
+		StreamPath: inclusion.StreamPath,
+		TxHash:     s.hasher.SumHex(inclusion.Txs[0].Blob),
+		TxID:       head.TxID,
+		Op:         head.Op,
+		Timestamp:  head.Timestamp,
+		Signer:     head.Signer,
+		Chain:      len(inclusion.Txs),
+	}, nil
+}
+
+// headTxName returns the tx file name a stream's HEAD file points at.
+func headTxName(head []byte) (string, error) {
+	relPath := strings.TrimSpace(string(head))
+	dir, name := path.Split(relPath)
+	if name == "" || path.Clean(dir) != domain.TxDirName {
+		return "", errors.New("HEAD does not name a tx file")
+	}
+	return name, nil
+}
+
+// isStreamPathOf accepts the stream path of either layout, since a proof does
+// not carry the repository's manifest.
+func isStreamPathOf(streamPath, collection, docID string) bool {
+	for _, layout := range []domain.StreamLayout{domain.StreamLayoutSharded, domain.StreamLayoutFlat} {
+		if streamPath == filepath.ToSlash(domain.StreamPath(layout, collection, docID)) {
+			return true
+		}
+	}
+	return false
+}
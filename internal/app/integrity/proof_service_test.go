@@ -0,0 +1,100 @@
+package integrity
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type fakeInclusionStore struct {
+	inclusion Inclusion
+	verifyErr error
+}
+
+func (f fakeInclusionStore) LoadInclusion(ctx context.Context, repoPath, commit, streamPath string) (Inclusion, error) {
+	inclusion := f.inclusion
+	inclusion.StreamPath = streamPath
+	return inclusion, nil
+}
+
+func (f fakeInclusionStore) VerifyInclusion(ctx context.Context, inclusion Inclusion) error {
+	return f.verifyErr
+}
+
+func newProofFixture() (fakeInclusionStore, mapDecoder, mapHasher) {
+	put := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	patch := domain.Transaction{TxID: "t2", Timestamp: 2, Collection: "users", DocID: "doc", Op: domain.TxOpPatch, Patch: []byte(`[]`), ParentHash: "h1"}
+	other := domain.Transaction{TxID: "t3", Timestamp: 3, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{}`)}
+
+	store := fakeInclusionStore{inclusion: Inclusion{
+		Commit: "c1",
+		Head:   []byte("tx/2_patch.txpb"),
+		Txs: []ProofTx{
+			{Name: "1_put.txpb", Blob: []byte("tx1")},
+			{Name: "2_patch.txpb", Blob: []byte("tx2")},
+			{Name: "3_put.txpb", Blob: []byte("tx3")},
+		},
+	}}
+	decoder := mapDecoder{txs: map[string]domain.Transaction{"tx1": put, "tx2": patch, "tx3": other}}
+	hasher := mapHasher{hashes: map[string]string{"tx1": "h1", "tx2": "h2", "tx3": "h3"}}
+	return store, decoder, hasher
+}
+
+func TestProveAndCheckHeadChain(t *testing.T) {
+	store, decoder, hasher := newProofFixture()
+	service := NewProofService(store, decoder, hasher, domain.StreamLayoutSharded)
+
+	proof, err := service.Prove(context.Background(), "repo", "users", "doc", "")
+	if err != nil {
+		t.Fatalf("Prove returned error: %v", err)
+	}
+	if want := filepath.ToSlash(domain.StreamPath(domain.StreamLayoutSharded, "users", "doc")); proof.Inclusion.StreamPath != want {
+		t.Fatalf("expected stream path %s, got %s", want, proof.Inclusion.StreamPath)
+	}
+	if len(proof.Inclusion.Txs) != 2 || proof.Inclusion.Txs[0].Name != "2_patch.txpb" || proof.Inclusion.Txs[1].Name != "1_put.txpb" {
+		t.Fatalf("expected the head chain only, got %+v", proof.Inclusion.Txs)
+	}
+
+	result, err := service.Check(context.Background(), proof)
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if result.TxHash != "h2" || result.TxID != "t2" || result.Op != domain.TxOpPatch || result.Chain != 2 || result.Commit != "c1" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+}
+
+func TestCheckRejectsTamperedProofs(t *testing.T) {
+	store, decoder, hasher := newProofFixture()
+	service := NewProofService(store, decoder, hasher, domain.StreamLayoutFlat)
+	proof, err := service.Prove(context.Background(), "repo", "users", "doc", "")
+	if err != nil {
+		t.Fatalf("Prove returned error: %v", err)
+	}
+
+	cases := map[string]func(p *Proof){
+		"other document": func(p *Proof) { p.DocID = "other" },
+		"head mismatch":  func(p *Proof) { p.Inclusion.Head = []byte("tx/1_put.txpb") },
+		"truncated":      func(p *Proof) { p.Inclusion.Txs = p.Inclusion.Txs[:1] },
+		"broken parent": func(p *Proof) {
+			p.Inclusion.Txs = []ProofTx{p.Inclusion.Txs[0], {Name: "3_put.txpb", Blob: []byte("tx3")}}
+		},
+	}
+	for name, tamper := range cases {
+		tampered := proof
+		tampered.Inclusion.Txs = append([]ProofTx(nil), proof.Inclusion.Txs...)
+		tamper(&tampered)
+		if _, err := service.Check(context.Background(), tampered); !errors.Is(err, ErrInvalidProof) {
+			t.Fatalf("%s: expected ErrInvalidProof, got %v", name, err)
+		}
+	}
+
+	objectErr := errors.New("tree hash mismatch")
+	service = NewProofService(fakeInclusionStore{verifyErr: objectErr}, decoder, hasher, domain.StreamLayoutFlat)
+	if _, err := service.Check(context.Background(), proof); !errors.Is(err, objectErr) {
+		t.Fatalf("expected the inclusion error, got %v", err)
+	}
+}
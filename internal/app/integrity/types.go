@@ -0,0 +1,77 @@
+package integrity
+
+import (
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// Inclusion is the git side of a proof: the raw commit object, the raw tree
+// objects from the commit's root down to the stream's tx directory, and the
+// stream files those trees name.
+type Inclusion struct {
+	Commit       string
+	CommitObject []byte
+	Trees        []ProofTree
+	StreamPath   string
+	Head         []byte
+	Txs          []ProofTx
+}
+
+// ProofTree is a raw tree object and its path from the root; the root tree
+// has an empty path.
+type ProofTree struct {
+	Path   string
+	Object []byte
+}
+
+// ProofTx is a tx file by its name in the stream's tx directory.
+type ProofTx struct {
+	Name string
+	Blob []byte
+}
+
+// Proof shows that a document's transaction chain, head first, is part of a
+// commit.
+type Proof struct {
+	Collection string
+	DocID      string
+	Inclusion  Inclusion
+}
+
+type ProofResult struct {
+	Collection string
+	DocID      string
+	Commit     string
+	StreamPath string
+	TxHash     string
+	TxID       string
+	Op         domain.TxOp
+	Timestamp  int64
+	Signer     string
+	Chain      int
+}
+
+// TrustChange is a commit that changed the trusted keys of Collection from
+// Before to After; an empty list means no policy.
+type TrustChange struct {
+	Commit     string
+	Collection string
+	Before     []string
+	After      []string
+}
+
+// Anchor is a backend's receipt stating that main pointed at Commit at Time.
+// Receipt is opaque to everything but the backend that issued it.
+type Anchor struct {
+	Backend string
+	Commit  string
+	Time    time.Time
+	Receipt []byte
+}
+
+type AnchorResult struct {
+	Anchor Anchor
+	// Skipped is set when the backend's latest anchor already holds the head.
+	Skipped bool
+}
@@ -1,47 +1,81 @@
 package integrity
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
 	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 )
 
 const (
-	IssueHeadRead    = "head_read"
-	IssueHeadMissing = "head_missing"
-	IssueTxRead      = "tx_read"
-	IssueTxMissing   = "tx_missing"
-	IssueTxDecode    = "tx_decode"
-	IssueTxInvalid   = "tx_invalid"
-	IssueChain       = "chain_invalid"
-	IssueOrphanTx    = "orphan_tx"
-	IssueRehydrate   = "rehydrate_failed"
+	IssueHeadRead        = "head_read"
+	IssueHeadMissing     = "head_missing"
+	IssueTxRead          = "tx_read"
+	IssueTxMissing       = "tx_missing"
+	IssueTxDecode        = "tx_decode"
+	IssueTxSignature     = "tx_signature"
+	IssueTxInvalid       = "tx_invalid"
+	IssueUntrusted       = "untrusted_signer"
+	IssueTrustWeakened   = "trust_weakened"
+	IssueChain           = "chain_invalid"
+	IssueOrphanTx        = "orphan_tx"
+	IssueRehydrate       = "rehydrate_failed"
+	IssueStateDrift      = "state_drift"
+	IssueStateMissing    = "state_missing"
+	IssueStateOrphan     = "state_orphan"
+	IssueStateRead       = "state_read"
+	IssueUnsigned        = "commit_unsigned"
+	IssueBadSignature    = "bad_signature"
+	IssueAnchorRewritten = "anchor_rewritten"
+	IssueAnchorInvalid   = "anchor_invalid"
 )
 
+// checkpointInterval is how many verified streams pass between checkpoint
+// saves.
+const checkpointInterval = 1000
+
 var (
-	errPatchWithoutBase = errors.New("patch without base document")
-	errMergeWithoutBase = errors.New("merge patch without base document")
-	errPatchUnsupported = errors.New("patcher not configured")
+	errCanonicalizerMissing = errors.New("canonicalizer not configured")
+	errPatchWithoutBase     = errors.New("patch without base document")
+	errMergeWithoutBase     = errors.New("merge patch without base document")
+	errPatchUnsupported     = errors.New("patcher not configured")
 )
 
 type VerifyService struct {
-	lister  StreamLister
-	store   ReadStore
-	decoder Decoder
-	hasher  Hasher
-	patcher Patcher
+	lister        StreamLister
+	store         ReadStore
+	decoder       Decoder
+	hasher        Hasher
+	patcher       Patcher
+	canonicalizer Canonicalizer
+	trust         TrustStore
+	commits       CommitVerifier
+	snapshots     SnapshotOpener
+	anchors       AnchorStore
 }
 
-func NewVerifyService(lister StreamLister, store ReadStore, decoder Decoder, hasher Hasher, patcher Patcher) *VerifyService {
+func NewVerifyService(lister StreamLister, store ReadStore, decoder Decoder, hasher Hasher, patcher Patcher, canonicalizer Canonicalizer, trust TrustStore, commits CommitVerifier, snapshots SnapshotOpener, anchors AnchorStore) *VerifyService {
 	return &VerifyService{
-		lister:  lister,
-		store:   store,
-		decoder: decoder,
-		hasher:  hasher,
-		patcher: patcher,
+		lister:        lister,
+		store:         store,
+		decoder:       decoder,
+		hasher:        hasher,
+		patcher:       patcher,
+		canonicalizer: canonicalizer,
+		trust:         trust,
+		commits:       commits,
+		snapshots:     snapshots,
+		anchors:       anchors,
 	}
 }
 
@@ -51,30 +85,321 @@ func (s *VerifyService) Verify(ctx context.Context, repoPath string, opts Verify
 		return VerifyResult{}, err
 	}
 
-	streams, err := s.lister.ListDocStreams(ctx, absRepoPath)
+	progress := Progress{Deep: opts.Deep, State: opts.State}
+	resumed := false
+	if opts.Checkpoint != nil {
+		saved, ok, err := opts.Checkpoint.Load(ctx)
+		if err != nil {
+			return VerifyResult{}, err
+		}
+		if ok && saved.Deep == opts.Deep && saved.State == opts.State {
+			progress = saved
+			resumed = true
+		}
+	}
+
+	root, err := s.openReader(ctx, absRepoPath, progress.Snapshot)
 	if err != nil {
 		return VerifyResult{}, err
 	}
+	progress.Snapshot = root.commit
 
-	result := VerifyResult{Streams: len(streams)}
-	for _, streamPath := range streams {
-		if err := ctx.Err(); err != nil {
+	streams, err := root.ListDocStreams(ctx, absRepoPath)
+	if err != nil {
+		return VerifyResult{}, err
+	}
+
+	start := 0
+	if resumed {
+		start = sort.Search(len(streams), func(i int) bool {
+			return streams[i] > progress.LastStream
+		})
+	}
+	if err := s.verifyStreams(ctx, absRepoPath, root, streams, start, opts, &progress); err != nil {
+		return VerifyResult{}, err
+	}
+
+	result := VerifyResult{
+		Snapshot: progress.Snapshot,
+		Streams:  len(streams),
+		Valid:    progress.Valid,
+		Issues:   progress.Issues,
+	}
+	if resumed {
+		result.Resumed = start
+	}
+
+	if opts.State {
+		issues, err := s.orphanStates(ctx, absRepoPath, root, streams)
+		if err != nil {
 			return VerifyResult{}, err
 		}
+		result.Issues = append(result.Issues, issues...)
+	}
 
-		issues := s.verifyStream(ctx, absRepoPath, streamPath, opts)
-		if len(issues) == 0 {
-			result.Valid++
-			continue
+	if s.trust != nil {
+		if err := s.verifyTrustChanges(ctx, absRepoPath, root.commit, &result); err != nil {
+			return VerifyResult{}, err
+		}
+	}
+
+	if opts.Signatures {
+		if err := s.verifyCommits(ctx, absRepoPath, opts.Keyring, &result); err != nil {
+			return VerifyResult{}, err
 		}
-		result.Issues = append(result.Issues, issues...)
 	}
 
+	if len(opts.AnchorRecords) > 0 {
+		if err := s.verifyAnchors(ctx, absRepoPath, opts.AnchorRecords, &result); err != nil {
+			return VerifyResult{}, err
+		}
+	}
+
+	if opts.Checkpoint != nil {
+		if err := opts.Checkpoint.Clear(ctx); err != nil {
+			return VerifyResult{}, err
+		}
+	}
 	return result, nil
 }
 
-func (s *VerifyService) verifyStream(ctx context.Context, repoPath, streamPath string, opts VerifyOptions) []Issue {
-	headHash, err := s.store.LoadStreamHead(ctx, repoPath, streamPath)
+type reader struct {
+	StreamLister
+	ReadStore
+	commit string
+}
+
+// openReader pins commit through the snapshot opener when there is one;
+// otherwise every read goes to the current main.
+func (s *VerifyService) openReader(ctx context.Context, repoPath, commit string) (reader, error) {
+	if s.snapshots == nil {
+		return reader{StreamLister: s.lister, ReadStore: s.store}, nil
+	}
+	snapshot, err := s.snapshots.OpenSnapshot(ctx, repoPath, commit)
+	if err != nil {
+		return reader{}, err
+	}
+	return reader{StreamLister: snapshot, ReadStore: snapshot, commit: snapshot.Commit()}, nil
+}
+
+type streamJob struct {
+	index      int
+	streamPath string
+}
+
+type streamResult struct {
+	index  int
+	issues []Issue
+}
+
+// verifyStreams verifies streams[start:] on a pool of workers, each with its
+// own reader of the same commit. Results are folded into progress in stream
+// order, so the report and the checkpoint do not depend on scheduling.
+func (s *VerifyService) verifyStreams(ctx context.Context, repoPath string, root reader, streams []string, start int, opts VerifyOptions, progress *Progress) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(streams)-start {
+		workers = len(streams) - start
+	}
+
+	stores := make([]ReadStore, workers)
+	for i := range stores {
+		if i == 0 {
+			stores[i] = root.ReadStore
+			continue
+		}
+		worker, err := s.openReader(ctx, repoPath, root.commit)
+		if err != nil {
+			return err
+		}
+		stores[i] = worker.ReadStore
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	trusted := &trustCache{keys: make(map[string][]string)}
+	jobs := make(chan streamJob)
+	results := make(chan streamResult)
+
+	var wg sync.WaitGroup
+	for _, store := range stores {
+		wg.Add(1)
+		go func(store ReadStore) {
+			defer wg.Done()
+			for job := range jobs {
+				issues := s.verifyStream(runCtx, store, repoPath, job.streamPath, opts, trusted)
+				if runCtx.Err() != nil {
+					return
+				}
+				select {
+				case results <- streamResult{index: job.index, issues: issues}:
+				case <-runCtx.Done():
+					return
+				}
+			}
+		}(store)
+	}
+	go func() {
+		defer close(jobs)
+		for i := start; i < len(streams); i++ {
+			select {
+			case jobs <- streamJob{index: i, streamPath: streams[i]}:
+			case <-runCtx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	pending := make(map[int][]Issue)
+	next := start
+	var saveErr error
+	for result := range results {
+		if runCtx.Err() != nil {
+			continue
+		}
+		pending[result.index] = result.issues
+		for {
+			issues, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			progress.LastStream = streams[next]
+			progress.Streams = next + 1
+			if len(issues) == 0 {
+				progress.Valid++
+			} else {
+				progress.Issues = append(progress.Issues, issues...)
+			}
+			next++
+			if opts.Progress != nil {
+				opts.Progress(next, len(streams))
+			}
+			if opts.Checkpoint != nil && (next-start)%checkpointInterval == 0 {
+				if err := opts.Checkpoint.Save(ctx, *progress); err != nil {
+					saveErr = err
+					cancel()
+					break
+				}
+			}
+		}
+	}
+
+	if saveErr != nil {
+		return saveErr
+	}
+	if err := ctx.Err(); err != nil {
+		if opts.Checkpoint != nil && next > start {
+			_ = opts.Checkpoint.Save(context.WithoutCancel(ctx), *progress)
+		}
+		return err
+	}
+	if opts.Checkpoint != nil && next > start {
+		return opts.Checkpoint.Save(ctx, *progress)
+	}
+	return nil
+}
+
+func (s *VerifyService) verifyCommits(ctx context.Context, repoPath, keyring string, result *VerifyResult) error {
+	if s.commits == nil {
+		return ErrSignaturesUnavailable
+	}
+	commits, err := s.commits.ListMainCommits(ctx, repoPath)
+	if err != nil {
+		return err
+	}
+	result.Commits = len(commits)
+	for _, commitHash := range commits {
+		err := s.commits.VerifyCommitSignature(ctx, repoPath, commitHash, keyring)
+		switch {
+		case err == nil:
+		case errors.Is(err, ErrCommitUnsigned):
+			result.Issues = append(result.Issues, newCommitIssue(commitHash, IssueUnsigned, err))
+		case errors.Is(err, ErrBadSignature):
+			result.Issues = append(result.Issues, newCommitIssue(commitHash, IssueBadSignature, err))
+		default:
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyTrustChanges reports every commit that cleared a collection's
+// trusted keys or added one to them. Heads are only checked against the
+// current keys, so a writer admitted for a while and then removed again
+// would otherwise leave no trace.
+func (s *VerifyService) verifyTrustChanges(ctx context.Context, repoPath, ref string, result *VerifyResult) error {
+	changes, err := s.trust.ListTrustChanges(ctx, repoPath, ref)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if len(change.Before) == 0 {
+			continue
+		}
+		var err error
+		if len(change.After) == 0 {
+			err = fmt.Errorf("%w: %s keys cleared", ErrTrustWeakened, change.Collection)
+		} else {
+			var added []string
+			for _, key := range change.After {
+				if !slices.Contains(change.Before, key) {
+					added = append(added, key)
+				}
+			}
+			if len(added) == 0 {
+				continue
+			}
+			err = fmt.Errorf("%w: %s keys added %s", ErrTrustWeakened, change.Collection, strings.Join(added, ", "))
+		}
+		issue := newCommitIssue(change.Commit, IssueTrustWeakened, err)
+		issue.Collection = change.Collection
+		result.Issues = append(result.Issues, issue)
+	}
+	return nil
+}
+
+// verifyAnchors reads every anchor from each external record and reports
+// the commits main no longer contains, which means its history was rewritten
+// after the anchor. An empty record proves nothing, so it fails the run.
+func (s *VerifyService) verifyAnchors(ctx context.Context, repoPath string, records []AnchorRecord, result *VerifyResult) error {
+	if s.anchors == nil {
+		return ErrAnchorsUnavailable
+	}
+	for _, record := range records {
+		anchors, err := record.ReadAnchors(ctx, repoPath)
+		switch {
+		case errors.Is(err, ErrAnchorMismatch):
+			result.Issues = append(result.Issues, Issue{Code: IssueAnchorInvalid, Message: fmt.Sprintf("%s anchors: %v", record.Name(), err)})
+		case err != nil:
+			return err
+		case len(anchors) == 0:
+			return fmt.Errorf("%w: %s", ErrAnchorsNotFound, record.Name())
+		}
+
+		result.Anchors += len(anchors)
+		for _, anchor := range anchors {
+			contained, err := s.anchors.IsMainAncestor(ctx, repoPath, anchor.Commit)
+			if err != nil {
+				return err
+			}
+			if !contained {
+				err := fmt.Errorf("main no longer contains the commit anchored with %s at %s", record.Name(), anchor.Time.UTC().Format(time.RFC3339))
+				result.Issues = append(result.Issues, newCommitIssue(anchor.Commit, IssueAnchorRewritten, err))
+			}
+		}
+	}
+	return nil
+}
+
+func (s *VerifyService) verifyStream(ctx context.Context, store ReadStore, repoPath, streamPath string, opts VerifyOptions, trusted *trustCache) []Issue {
+	headHash, err := store.LoadStreamHead(ctx, repoPath, streamPath)
 	if err != nil {
 		return []Issue{newIssue(streamPath, IssueHeadRead, err)}
 	}
@@ -82,7 +407,7 @@ func (s *VerifyService) verifyStream(ctx context.Context, repoPath, streamPath s
 		return []Issue{newIssue(streamPath, IssueHeadMissing, errors.New("HEAD not found"))}
 	}
 
-	txBlobs, err := s.store.LoadStreamTxs(ctx, repoPath, streamPath)
+	txBlobs, err := store.LoadStreamTxs(ctx, repoPath, streamPath)
 	if err != nil {
 		return []Issue{newIssue(streamPath, IssueTxRead, err)}
 	}
@@ -93,6 +418,9 @@ func (s *VerifyService) verifyStream(ctx context.Context, repoPath, streamPath s
 	index := make(map[string]chainEntry, len(txBlobs))
 	for _, blob := range txBlobs {
 		tx, err := s.decoder.Decode(blob.Bytes)
+		if errors.Is(err, domain.ErrInvalidSignature) {
+			return []Issue{newIssue(streamPath, IssueTxSignature, err)}
+		}
 		if err != nil {
 			return []Issue{newIssue(streamPath, IssueTxDecode, err)}
 		}
@@ -111,20 +439,151 @@ func (s *VerifyService) verifyStream(ctx context.Context, repoPath, streamPath s
 		return []Issue{newIssue(streamPath, IssueChain, err)}
 	}
 
+	reachable, err := countReachable(headHash, index)
+	if err != nil {
+		return []Issue{newIssue(streamPath, IssueChain, err)}
+	}
+
 	var issues []Issue
-	if len(chain) != len(index) {
-		issues = append(issues, newIssue(streamPath, IssueOrphanTx, fmt.Errorf("%d orphan tx(s)", len(index)-len(chain))))
+	if reachable != len(index) {
+		issues = append(issues, newIssue(streamPath, IssueOrphanTx, fmt.Errorf("%d orphan tx(s)", len(index)-reachable)))
+	}
+	if err := s.checkSigner(ctx, repoPath, chain[0].Tx, trusted); err != nil {
+		issues = append(issues, newIssue(streamPath, IssueUntrusted, err))
 	}
 
-	if opts.Deep {
-		if err := verifyRehydrate(ctx, chain, s.patcher); err != nil {
+	if opts.Deep || opts.State {
+		doc, err := rehydrate(ctx, chain, s.patcher)
+		if err != nil {
 			issues = append(issues, newIssue(streamPath, IssueRehydrate, err))
+		} else if opts.State {
+			issues = append(issues, s.verifyState(ctx, store, repoPath, streamPath, chain[0].Tx, doc)...)
 		}
 	}
 
 	return issues
 }
 
+// verifyState compares a stream's rehydrated head with the state/ entry that
+// reads serve before falling back to the chain.
+func (s *VerifyService) verifyState(ctx context.Context, store ReadStore, repoPath, streamPath string, head domain.Transaction, doc []byte) []Issue {
+	stateIssue := func(code string, err error) []Issue {
+		issue := newIssue(streamPath, code, err)
+		issue.Collection = head.Collection
+		issue.DocID = head.DocID
+		return []Issue{issue}
+	}
+
+	blob, err := store.LoadHeadTx(ctx, repoPath, statePathOf(streamPath))
+	if errors.Is(err, docapp.ErrDocNotFound) {
+		return stateIssue(IssueStateMissing, errors.New("no state entry"))
+	}
+	if err != nil {
+		return stateIssue(IssueStateRead, err)
+	}
+	stateTx, err := s.decoder.Decode(blob.Bytes)
+	if err != nil {
+		return stateIssue(IssueStateRead, err)
+	}
+
+	switch {
+	case stateTx.Collection != head.Collection || stateTx.DocID != head.DocID:
+		return stateIssue(IssueStateDrift, fmt.Errorf("state belongs to %s/%s", stateTx.Collection, stateTx.DocID))
+	case stateTx.TxID != head.TxID:
+		return stateIssue(IssueStateDrift, fmt.Errorf("state is at tx %s, head is tx %s", stateTx.TxID, head.TxID))
+	case (stateTx.Op == domain.TxOpDelete) != (head.Op == domain.TxOpDelete):
+		return stateIssue(IssueStateDrift, fmt.Errorf("state op %s, head op %s", stateTx.Op, head.Op))
+	case stateTx.Op == domain.TxOpDelete || len(stateTx.Snapshot) == 0:
+		return nil
+	}
+
+	if s.canonicalizer == nil {
+		return stateIssue(IssueStateRead, errCanonicalizerMissing)
+	}
+	want, err := s.canonicalizer.Canonicalize(ctx, doc)
+	if err != nil {
+		return stateIssue(IssueRehydrate, err)
+	}
+	got, err := s.canonicalizer.Canonicalize(ctx, stateTx.Snapshot)
+	if err != nil {
+		return stateIssue(IssueStateRead, err)
+	}
+	if !bytes.Equal(want, got) {
+		return stateIssue(IssueStateDrift, errors.New("state snapshot differs from the rehydrated document"))
+	}
+	return nil
+}
+
+// orphanStates reports state/ entries whose documents/ stream is missing.
+func (s *VerifyService) orphanStates(ctx context.Context, repoPath string, root reader, streams []string) ([]Issue, error) {
+	statePaths, err := root.ListStateStreams(ctx, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(streams))
+	for _, streamPath := range streams {
+		known[statePathOf(streamPath)] = struct{}{}
+	}
+
+	var issues []Issue
+	for _, statePath := range statePaths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if _, ok := known[statePath]; ok {
+			continue
+		}
+		issue := newIssue(statePath, IssueStateOrphan, errors.New("state entry without a document stream"))
+		if blob, err := root.LoadHeadTx(ctx, repoPath, statePath); err == nil {
+			if tx, err := s.decoder.Decode(blob.Bytes); err == nil {
+				issue.Collection = tx.Collection
+				issue.DocID = tx.DocID
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// statePathOf maps documents/<...>/DOC_<hash> to its state/ entry, which
+// mirrors the stream path under the state root.
+func statePathOf(streamPath string) string {
+	return path.Join(domain.StateRoot, strings.TrimPrefix(streamPath, domain.DocumentsRoot))
+}
+
+// checkSigner applies the collection's trusted keys to a stream head. The head
+// commits to its parent's hash, so a trusted head vouches for the whole chain.
+func (s *VerifyService) checkSigner(ctx context.Context, repoPath string, tx domain.Transaction, trusted *trustCache) error {
+	if s.trust == nil {
+		return nil
+	}
+	keys, err := trusted.load(ctx, s.trust, repoPath, tx.Collection)
+	if err != nil {
+		return err
+	}
+	return domain.CheckSigner(keys, tx)
+}
+
+// trustCache shares each collection's trusted keys between workers.
+type trustCache struct {
+	mu   sync.Mutex
+	keys map[string][]string
+}
+
+func (c *trustCache) load(ctx context.Context, trust TrustStore, repoPath, collection string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if keys, ok := c.keys[collection]; ok {
+		return keys, nil
+	}
+	keys, err := trust.LoadTrustedKeys(ctx, repoPath, collection)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[collection] = keys
+	return keys, nil
+}
+
 type chainEntry struct {
 	Hash string
 	Tx   domain.Transaction
@@ -150,11 +609,34 @@ func buildTxChain(headHash string, index map[string]chainEntry) ([]chainEntry, e
 	return chain, nil
 }
 
-func verifyRehydrate(ctx context.Context, chain []chainEntry, patcher Patcher) error {
+// countReachable walks every parent edge, including merge parents, so the
+// branch a merge tx pulled in is not reported as orphaned.
+func countReachable(headHash string, index map[string]chainEntry) (int, error) {
+	visited := make(map[string]struct{}, len(index))
+	stack := []string{headHash}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if _, ok := visited[current]; ok {
+			continue
+		}
+		entry, ok := index[current]
+		if !ok {
+			return 0, fmt.Errorf("missing tx %s", current)
+		}
+		visited[current] = struct{}{}
+		stack = append(stack, entry.Tx.Parents()...)
+	}
+	return len(visited), nil
+}
+
+// rehydrate replays chain, newest first, and returns the head document; a
+// deleted head yields nil.
+func rehydrate(ctx context.Context, chain []chainEntry, patcher Patcher) ([]byte, error) {
 	var doc []byte
 	for i := len(chain) - 1; i >= 0; i-- {
 		if err := ctx.Err(); err != nil {
-			return err
+			return nil, err
 		}
 
 		tx := chain[i].Tx
@@ -163,14 +645,14 @@ func verifyRehydrate(ctx context.Context, chain []chainEntry, patcher Patcher) e
 			doc = tx.Snapshot
 		case domain.TxOpPatch:
 			if patcher == nil {
-				return errPatchUnsupported
+				return nil, errPatchUnsupported
 			}
 			if doc == nil {
-				return errPatchWithoutBase
+				return nil, errPatchWithoutBase
 			}
 			updated, err := patcher.Apply(ctx, doc, tx.Patch)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			doc = updated
 		case domain.TxOpDelete:
@@ -181,24 +663,24 @@ func verifyRehydrate(ctx context.Context, chain []chainEntry, patcher Patcher) e
 				continue
 			}
 			if len(tx.Patch) == 0 {
-				return domain.ErrMissingPayload
+				return nil, domain.ErrMissingPayload
 			}
 			if patcher == nil {
-				return errPatchUnsupported
+				return nil, errPatchUnsupported
 			}
 			if doc == nil {
-				return errMergeWithoutBase
+				return nil, errMergeWithoutBase
 			}
 			updated, err := patcher.Apply(ctx, doc, tx.Patch)
 			if err != nil {
-				return err
+				return nil, err
 			}
 			doc = updated
 		default:
-			return domain.ErrInvalidOp
+			return nil, domain.ErrInvalidOp
 		}
 	}
-	return nil
+	return doc, nil
 }
 
 func newIssue(streamPath, code string, err error) Issue {
@@ -208,3 +690,11 @@ func newIssue(streamPath, code string, err error) Issue {
 		Message:    err.Error(),
 	}
 }
+
+func newCommitIssue(commitHash, code string, err error) Issue {
+	return Issue{
+		CommitHash: commitHash,
+		Code:       code,
+		Message:    err.Error(),
+	}
+}
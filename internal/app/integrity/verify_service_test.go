@@ -1,8 +1,12 @@
 package integrity
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
@@ -13,6 +17,7 @@ const testStreamPath = "documents/users/DOC_deadbeef"
 
 type fakeLister struct {
 	streams []string
+	states  []string
 	err     error
 }
 
@@ -23,11 +28,16 @@ func (f fakeLister) ListDocStreams(ctx context.Context, repoPath string) ([]stri
 	return f.streams, nil
 }
 
+func (f fakeLister) ListStateStreams(ctx context.Context, repoPath string) ([]string, error) {
+	return f.states, nil
+}
+
 type fakeStore struct {
 	head    string
 	headErr error
 	txs     []doc.TxBlob
 	txErr   error
+	state   map[string]doc.TxBlob
 }
 
 func (f fakeStore) LoadStreamHead(ctx context.Context, repoPath, streamPath string) (string, error) {
@@ -37,6 +47,14 @@ func (f fakeStore) LoadStreamHead(ctx context.Context, repoPath, streamPath stri
 	return f.head, nil
 }
 
+func (f fakeStore) LoadHeadTx(ctx context.Context, repoPath, streamPath string) (doc.TxBlob, error) {
+	blob, ok := f.state[streamPath]
+	if !ok {
+		return doc.TxBlob{}, doc.ErrDocNotFound
+	}
+	return blob, nil
+}
+
 func (f fakeStore) LoadStreamTxs(ctx context.Context, repoPath, streamPath string) ([]doc.TxBlob, error) {
 	if f.txErr != nil {
 		return nil, f.txErr
@@ -86,6 +104,11 @@ func TestVerifyReportsMissingHead(t *testing.T) {
 		mapDecoder{},
 		mapHasher{},
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
@@ -137,6 +160,11 @@ func TestVerifyAcceptsValidChain(t *testing.T) {
 			"tx2": "h2",
 		}},
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
@@ -196,6 +224,11 @@ func TestVerifyDetectsOrphanTxs(t *testing.T) {
 			"tx3": "h3",
 		}},
 		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
@@ -248,6 +281,11 @@ func TestVerifyDeepReportsPatchFailure(t *testing.T) {
 			"tx2": "h2",
 		}},
 		fakePatcher{err: patchErr},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
 	)
 
 	result, err := service.Verify(context.Background(), "repo", VerifyOptions{Deep: true})
@@ -261,3 +299,368 @@ func TestVerifyDeepReportsPatchFailure(t *testing.T) {
 		t.Fatalf("expected %s, got %s", IssueRehydrate, result.Issues[0].Code)
 	}
 }
+
+func TestVerifyAcceptsMergeParents(t *testing.T) {
+	base := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	ours := domain.Transaction{TxID: "t2", Timestamp: 2, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":2}`), ParentHash: "h1"}
+	theirs := domain.Transaction{TxID: "t3", Timestamp: 3, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":3}`), ParentHash: "h1"}
+	merge := domain.Transaction{TxID: "t4", Timestamp: 4, Collection: "users", DocID: "doc", Op: domain.TxOpMerge, Snapshot: []byte(`{"a":2}`), ParentHash: "h2", MergeParents: []string{"h3"}}
+
+	store := fakeStore{
+		head: "h4",
+		txs: []doc.TxBlob{
+			{Bytes: []byte("tx1")},
+			{Bytes: []byte("tx2")},
+			{Bytes: []byte("tx3")},
+			{Bytes: []byte("tx4")},
+		},
+	}
+	decoder := mapDecoder{txs: map[string]domain.Transaction{"tx1": base, "tx2": ours, "tx3": theirs, "tx4": merge}}
+	hasher := mapHasher{hashes: map[string]string{"tx1": "h1", "tx2": "h2", "tx3": "h3", "tx4": "h4"}}
+
+	service := NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, decoder, hasher, nil, nil, nil, nil, nil, nil)
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Valid != 1 || len(result.Issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", result)
+	}
+
+	store.txs = store.txs[:1:1]
+	store.txs = append(store.txs, doc.TxBlob{Bytes: []byte("tx2")}, doc.TxBlob{Bytes: []byte("tx4")})
+	service = NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, decoder, hasher, nil, nil, nil, nil, nil, nil)
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != IssueChain {
+		t.Fatalf("expected missing merge parent to be a chain issue, got %+v", result)
+	}
+}
+
+type compactCanonicalizer struct{}
+
+func (compactCanonicalizer) Canonicalize(ctx context.Context, input []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, input); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func TestVerifyStateReportsDriftMissingAndOrphans(t *testing.T) {
+	tx1 := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	tx2 := domain.Transaction{TxID: "t2", Timestamp: 2, Collection: "users", DocID: "doc", Op: domain.TxOpPatch, Patch: []byte(`[]`), ParentHash: "h1"}
+	orphan := domain.Transaction{TxID: "o1", Timestamp: 1, Collection: "users", DocID: "ghost", Op: domain.TxOpPut, Snapshot: []byte(`{}`)}
+	const statePath = "state/users/DOC_deadbeef"
+	const orphanPath = "state/users/DOC_cafe"
+
+	newService := func(state map[string]doc.TxBlob, states []string, decoded map[string]domain.Transaction) *VerifyService {
+		txs := map[string]domain.Transaction{"tx1": tx1, "tx2": tx2, "orphan": orphan}
+		for key, tx := range decoded {
+			txs[key] = tx
+		}
+		return NewVerifyService(
+			fakeLister{streams: []string{testStreamPath}, states: states},
+			fakeStore{head: "h2", txs: []doc.TxBlob{{Bytes: []byte("tx1")}, {Bytes: []byte("tx2")}}, state: state},
+			mapDecoder{txs: txs},
+			mapHasher{hashes: map[string]string{"tx1": "h1", "tx2": "h2"}},
+			fakePatcher{},
+			compactCanonicalizer{},
+			nil,
+			nil,
+			nil,
+			nil,
+		)
+	}
+
+	current := domain.Transaction{TxID: "t2", Collection: "users", DocID: "doc", Op: domain.TxOpMerge, Snapshot: []byte(`{ "ok": true }`)}
+	service := newService(map[string]doc.TxBlob{statePath: {Bytes: []byte("state")}}, []string{statePath}, map[string]domain.Transaction{"state": current})
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{State: true})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Valid != 1 || len(result.Issues) != 0 {
+		t.Fatalf("expected matching state to verify, got %+v", result)
+	}
+
+	drifted := current
+	drifted.Snapshot = []byte(`{"ok":false}`)
+	service = newService(
+		map[string]doc.TxBlob{statePath: {Bytes: []byte("state")}, orphanPath: {Bytes: []byte("orphan")}},
+		[]string{statePath, orphanPath},
+		map[string]domain.Transaction{"state": drifted},
+	)
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{State: true})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if len(result.Issues) != 2 {
+		t.Fatalf("expected drift and orphan issues, got %+v", result)
+	}
+	if issue := result.Issues[0]; issue.Code != IssueStateDrift || issue.Collection != "users" || issue.DocID != "doc" {
+		t.Fatalf("expected %s for users/doc, got %+v", IssueStateDrift, issue)
+	}
+	if issue := result.Issues[1]; issue.Code != IssueStateOrphan || issue.StreamPath != orphanPath || issue.DocID != "ghost" {
+		t.Fatalf("expected %s for users/ghost, got %+v", IssueStateOrphan, issue)
+	}
+
+	service = newService(nil, nil, nil)
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{State: true})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != IssueStateMissing || result.Issues[0].DocID != "doc" {
+		t.Fatalf("expected %s, got %+v", IssueStateMissing, result)
+	}
+}
+
+type fakeTrustStore map[string][]string
+
+func (f fakeTrustStore) LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return f[collection], nil
+}
+
+func (f fakeTrustStore) ListTrustChanges(ctx context.Context, repoPath, ref string) ([]TrustChange, error) {
+	return nil, nil
+}
+
+// trustHistory is a trust store whose keys changed over the given commits.
+type trustHistory struct {
+	fakeTrustStore
+	changes []TrustChange
+}
+
+func (f trustHistory) ListTrustChanges(ctx context.Context, repoPath, ref string) ([]TrustChange, error) {
+	return f.changes, nil
+}
+
+func TestVerifyReportsSignatureIssues(t *testing.T) {
+	tx1 := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	store := fakeStore{head: "h1", txs: []doc.TxBlob{{Bytes: []byte("tx1")}}}
+	hasher := mapHasher{hashes: map[string]string{"tx1": "h1"}}
+	trust := fakeTrustStore{"users": {"aa"}}
+
+	service := NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, mapDecoder{txs: map[string]domain.Transaction{"tx1": tx1}}, hasher, nil, nil, trust, nil, nil, nil)
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != IssueUntrusted {
+		t.Fatalf("expected an unsigned head to be untrusted, got %+v", result)
+	}
+
+	tx1.Signer = "aa"
+	service = NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, mapDecoder{txs: map[string]domain.Transaction{"tx1": tx1}}, hasher, nil, nil, trust, nil, nil, nil)
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil || result.Valid != 1 {
+		t.Fatalf("expected a trusted head to verify, got %+v (%v)", result, err)
+	}
+
+	service = NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, mapDecoder{err: domain.ErrInvalidSignature}, hasher, nil, nil, trust, nil, nil, nil)
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Code != IssueTxSignature {
+		t.Fatalf("expected %s, got %+v", IssueTxSignature, result)
+	}
+}
+
+func TestVerifyReportsWeakenedTrustPolicies(t *testing.T) {
+	tx1 := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`), Signer: "aa"}
+	store := fakeStore{head: "h1", txs: []doc.TxBlob{{Bytes: []byte("tx1")}}}
+	hasher := mapHasher{hashes: map[string]string{"tx1": "h1"}}
+	trust := trustHistory{
+		fakeTrustStore: fakeTrustStore{"users": {"aa"}},
+		changes: []TrustChange{
+			{Commit: "c5", Collection: "users", Before: []string{"aa", "bb"}, After: []string{"aa"}},
+			{Commit: "c4", Collection: "users", Before: []string{"aa"}, After: []string{"aa", "bb"}},
+			{Commit: "c3", Collection: "users", After: []string{"aa"}},
+			{Commit: "c2", Collection: "users", Before: []string{"aa"}},
+			{Commit: "c1", Collection: "users", After: []string{"aa"}},
+		},
+	}
+
+	service := NewVerifyService(fakeLister{streams: []string{testStreamPath}}, store, mapDecoder{txs: map[string]domain.Transaction{"tx1": tx1}}, hasher, nil, nil, trust, nil, nil, nil)
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Valid != 1 || len(result.Issues) != 2 {
+		t.Fatalf("expected the cleared and widened policies to be reported, got %+v", result)
+	}
+	for i, commit := range []string{"c4", "c2"} {
+		issue := result.Issues[i]
+		if issue.Code != IssueTrustWeakened || issue.CommitHash != commit || issue.Collection != "users" {
+			t.Fatalf("expected %s at %s, got %+v", IssueTrustWeakened, commit, issue)
+		}
+	}
+	if !strings.Contains(result.Issues[0].Message, "bb") {
+		t.Fatalf("expected the added key to be named, got %q", result.Issues[0].Message)
+	}
+}
+
+type fakeCommitVerifier map[string]error
+
+func (f fakeCommitVerifier) ListMainCommits(ctx context.Context, repoPath string) ([]string, error) {
+	return []string{"c3", "c2", "c1"}, nil
+}
+
+func (f fakeCommitVerifier) VerifyCommitSignature(ctx context.Context, repoPath, commitHash, keyring string) error {
+	if keyring != "keys" {
+		return errors.New("unexpected keyring " + keyring)
+	}
+	return f[commitHash]
+}
+
+func TestVerifyReportsCommitSignatures(t *testing.T) {
+	commits := fakeCommitVerifier{
+		"c2": fmt.Errorf("%w: no principal matched", ErrBadSignature),
+		"c3": ErrCommitUnsigned,
+	}
+	service := NewVerifyService(fakeLister{}, fakeStore{}, mapDecoder{}, mapHasher{}, nil, nil, nil, commits, nil, nil)
+
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{})
+	if err != nil || result.Commits != 0 || len(result.Issues) != 0 {
+		t.Fatalf("expected signatures to be skipped by default, got %+v (%v)", result, err)
+	}
+
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{Signatures: true, Keyring: "keys"})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Commits != 3 || len(result.Issues) != 2 {
+		t.Fatalf("expected 2 issues over 3 commits, got %+v", result)
+	}
+	if result.Issues[0].CommitHash != "c3" || result.Issues[0].Code != IssueUnsigned {
+		t.Fatalf("unexpected first issue %+v", result.Issues[0])
+	}
+	if result.Issues[1].CommitHash != "c2" || result.Issues[1].Code != IssueBadSignature {
+		t.Fatalf("unexpected second issue %+v", result.Issues[1])
+	}
+
+	service = NewVerifyService(fakeLister{}, fakeStore{}, mapDecoder{}, mapHasher{}, nil, nil, nil, nil, nil, nil)
+	if _, err := service.Verify(context.Background(), "repo", VerifyOptions{Signatures: true}); !errors.Is(err, ErrSignaturesUnavailable) {
+		t.Fatalf("expected ErrSignaturesUnavailable, got %v", err)
+	}
+}
+
+type streamStores map[string]fakeStore
+
+func (s streamStores) LoadStreamHead(ctx context.Context, repoPath, streamPath string) (string, error) {
+	return s[streamPath].LoadStreamHead(ctx, repoPath, streamPath)
+}
+
+func (s streamStores) LoadHeadTx(ctx context.Context, repoPath, streamPath string) (doc.TxBlob, error) {
+	return s[streamPath].LoadHeadTx(ctx, repoPath, streamPath)
+}
+
+func (s streamStores) LoadStreamTxs(ctx context.Context, repoPath, streamPath string) ([]doc.TxBlob, error) {
+	return s[streamPath].LoadStreamTxs(ctx, repoPath, streamPath)
+}
+
+type memoryCheckpoint struct {
+	progress *Progress
+	saves    int
+}
+
+func (c *memoryCheckpoint) Load(ctx context.Context) (Progress, bool, error) {
+	if c.progress == nil {
+		return Progress{}, false, nil
+	}
+	return *c.progress, true, nil
+}
+
+func (c *memoryCheckpoint) Save(ctx context.Context, progress Progress) error {
+	c.progress = &progress
+	c.saves++
+	return nil
+}
+
+func (c *memoryCheckpoint) Clear(ctx context.Context) error {
+	c.progress = nil
+	return nil
+}
+
+func TestVerifyWorkersAndCheckpoint(t *testing.T) {
+	tx1 := domain.Transaction{TxID: "t1", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{}`)}
+	valid := fakeStore{head: "h1", txs: []doc.TxBlob{{Bytes: []byte("tx1")}}}
+	streams := []string{"documents/users/DOC_0", "documents/users/DOC_1", "documents/users/DOC_2", "documents/users/DOC_3", "documents/users/DOC_4"}
+	stores := streamStores{}
+	for _, streamPath := range streams {
+		stores[streamPath] = valid
+	}
+	stores[streams[1]] = fakeStore{}
+	stores[streams[3]] = fakeStore{}
+
+	service := NewVerifyService(
+		fakeLister{streams: streams},
+		stores,
+		mapDecoder{txs: map[string]domain.Transaction{"tx1": tx1}},
+		mapHasher{hashes: map[string]string{"tx1": "h1"}},
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+
+	var done, total int
+	result, err := service.Verify(context.Background(), "repo", VerifyOptions{
+		Workers: 4,
+		Progress: func(verified, streams int) {
+			done, total = verified, streams
+		},
+	})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Valid != 3 || len(result.Issues) != 2 || done != 5 || total != 5 {
+		t.Fatalf("expected 3 valid streams and 2 issues, got %+v (progress %d/%d)", result, done, total)
+	}
+	if result.Issues[0].StreamPath != streams[1] || result.Issues[1].StreamPath != streams[3] {
+		t.Fatalf("expected issues in stream order, got %+v", result.Issues)
+	}
+
+	carried := Issue{StreamPath: streams[1], Code: IssueHeadMissing, Message: "carried"}
+	checkpoint := &memoryCheckpoint{progress: &Progress{LastStream: streams[2], Streams: 3, Valid: 2, Issues: []Issue{carried}}}
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{Workers: 2, Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Resumed != 3 || result.Valid != 3 || len(result.Issues) != 2 || result.Issues[0] != carried {
+		t.Fatalf("expected the run to resume after %s, got %+v", streams[2], result)
+	}
+	if checkpoint.progress != nil || checkpoint.saves == 0 {
+		t.Fatalf("expected the checkpoint to be saved and then cleared, got %+v after %d save(s)", checkpoint.progress, checkpoint.saves)
+	}
+
+	checkpoint = &memoryCheckpoint{progress: &Progress{Deep: true, LastStream: streams[4], Streams: 5, Valid: 5}}
+	result, err = service.Verify(context.Background(), "repo", VerifyOptions{Checkpoint: checkpoint})
+	if err != nil {
+		t.Fatalf("Verify returned error: %v", err)
+	}
+	if result.Resumed != 0 || result.Valid != 3 {
+		t.Fatalf("expected a checkpoint with other options to be ignored, got %+v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checkpoint = &memoryCheckpoint{}
+	_, err = service.Verify(ctx, "repo", VerifyOptions{
+		Checkpoint: checkpoint,
+		Progress: func(verified, streams int) {
+			if verified == 2 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if checkpoint.progress == nil || checkpoint.progress.LastStream != streams[1] {
+		t.Fatalf("expected an interrupted run to checkpoint %s, got %+v", streams[1], checkpoint.progress)
+	}
+}
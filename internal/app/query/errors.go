@@ -0,0 +1,8 @@
+package query
+
+import "errors"
+
+var ErrCollectionRequired = errors.New("collection name is required")
+var ErrInvalidCollectionName = errors.New("invalid collection name")
+var ErrInvalidWhere = errors.New("where must be field=value")
+var ErrFieldNotIndexed = errors.New("field is not indexed")
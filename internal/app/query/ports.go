@@ -0,0 +1,11 @@
+package query
+
+import "context"
+
+type IndexReader interface {
+	LookupIndex(ctx context.Context, repoPath, collection, field string, value []byte) ([]string, error)
+}
+
+type IndexDeclarations interface {
+	LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error)
+}
@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+type Service struct {
+	reader       IndexReader
+	declarations IndexDeclarations
+}
+
+func NewService(reader IndexReader, declarations IndexDeclarations) *Service {
+	return &Service{
+		reader:       reader,
+		declarations: declarations,
+	}
+}
+
+// Where answers an equality lookup from the git-native index of a declared
+// field. The value is matched by its JSON encoding, so `n=1` finds numbers
+// and `n="1"` finds strings.
+func (s *Service) Where(ctx context.Context, repoPath, collection, where string) (Result, error) {
+	collection = strings.TrimSpace(collection)
+	if collection == "" {
+		return Result{}, ErrCollectionRequired
+	}
+	if !domain.IsValidCollectionName(collection) {
+		return Result{}, ErrInvalidCollectionName
+	}
+
+	field, value, ok := strings.Cut(where, "=")
+	field = strings.TrimSpace(field)
+	if !ok || field == "" {
+		return Result{}, ErrInvalidWhere
+	}
+
+	absRepoPath, err := paths.NormalizeRepoPath(repoPath)
+	if err != nil {
+		return Result{}, err
+	}
+
+	fields, err := s.declarations.LoadIndexes(ctx, absRepoPath, collection)
+	if err != nil {
+		return Result{}, err
+	}
+	if !slices.Contains(fields, field) {
+		return Result{}, ErrFieldNotIndexed
+	}
+
+	docIDs, err := s.reader.LookupIndex(ctx, absRepoPath, collection, field, domain.ParseIndexValue(value))
+	if err != nil {
+		return Result{}, err
+	}
+	return Result{
+		Collection: collection,
+		Field:      field,
+		Value:      value,
+		DocIDs:     docIDs,
+	}, nil
+}
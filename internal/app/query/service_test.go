@@ -0,0 +1,64 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeIndex struct {
+	fields  []string
+	entries map[string][]string
+	lookups []string
+}
+
+func (f *fakeIndex) LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error) {
+	return f.fields, nil
+}
+
+func (f *fakeIndex) LookupIndex(ctx context.Context, repoPath, collection, field string, value []byte) ([]string, error) {
+	key := collection + "/" + field + "=" + string(value)
+	f.lookups = append(f.lookups, key)
+	return f.entries[key], nil
+}
+
+func TestWhereLooksUpEncodedValue(t *testing.T) {
+	index := &fakeIndex{
+		fields:  []string{"n", "status"},
+		entries: map[string][]string{`tasks/status="open"`: {"a", "b"}},
+	}
+	service := NewService(index, index)
+
+	result, err := service.Where(context.Background(), "repo", "tasks", "status=open")
+	if err != nil {
+		t.Fatalf("Where returned error: %v", err)
+	}
+	if result.Field != "status" || result.Value != "open" || !reflect.DeepEqual(result.DocIDs, []string{"a", "b"}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err := service.Where(context.Background(), "repo", "tasks", `n="1"`); err != nil {
+		t.Fatalf("Where returned error: %v", err)
+	}
+	if index.lookups[1] != `tasks/n="1"` {
+		t.Fatalf("expected quoted value to stay a string, got %s", index.lookups[1])
+	}
+}
+
+func TestWhereValidatesInput(t *testing.T) {
+	service := NewService(&fakeIndex{fields: []string{"status"}}, &fakeIndex{fields: []string{"status"}})
+	cases := map[string]error{
+		"status":  ErrInvalidWhere,
+		"=open":   ErrInvalidWhere,
+		"owner=x": ErrFieldNotIndexed,
+	}
+	for where, expected := range cases {
+		if _, err := service.Where(context.Background(), "repo", "tasks", where); !errors.Is(err, expected) {
+			t.Fatalf("where %q: expected %v, got %v", where, expected, err)
+		}
+	}
+	if _, err := service.Where(context.Background(), "repo", "a/b", "status=x"); !errors.Is(err, ErrInvalidCollectionName) {
+		t.Fatalf("expected ErrInvalidCollectionName, got %v", err)
+	}
+}
@@ -0,0 +1,8 @@
+package query
+
+type Result struct {
+	Collection string
+	Field      string
+	Value      string
+	DocIDs     []string
+}
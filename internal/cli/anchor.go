@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/anchor"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txkey"
+	"github.com/spf13/cobra"
+)
+
+func newIntegrityAnchorCmd(opts *RootOptions) *cobra.Command {
+	var logPath string
+	var tsaKeyPath string
+	var tsaReceipts string
+	var remoteURL string
+	var interval time.Duration
+	cmd := &cobra.Command{
+		Use:   "anchor",
+		Short: "Record main's head with external anchor backends",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if interval < 0 {
+				return integrityapp.ErrInvalidAnchorInterval
+			}
+			var backends []integrityapp.AnchorBackend
+			if path := strings.TrimSpace(logPath); path != "" {
+				backends = append(backends, anchor.NewFileLog(path))
+			}
+			if path := strings.TrimSpace(tsaKeyPath); path != "" {
+				receipts := strings.TrimSpace(tsaReceipts)
+				if receipts == "" {
+					return fmt.Errorf("%w: --tsa-key needs --tsa-receipts", integrityapp.ErrAnchorBackendRequired)
+				}
+				key, err := txkey.Load(path)
+				if err != nil {
+					return err
+				}
+				backends = append(backends, anchor.NewAuthority(key, receipts))
+			}
+			if url := strings.TrimSpace(remoteURL); url != "" {
+				backends = append(backends, gitrepo.NewRemoteAnchor(url))
+			}
+			service := integrityapp.NewAnchorService(newGitStore(opts), backends)
+
+			if interval == 0 {
+				results, err := service.Anchor(cmd.Context(), opts.RepoPath)
+				if err != nil {
+					return err
+				}
+				return writeAnchorResults(cmd, results, opts.JSONOutput)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return service.Watch(ctx, opts.RepoPath, interval, func(results []integrityapp.AnchorResult) error {
+				return writeAnchorResults(cmd, results, opts.JSONOutput)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&logPath, "file", "", "Append anchors to this hash-chained log file")
+	cmd.Flags().StringVar(&tsaKeyPath, "tsa-key", "", "Sign anchors with this Ed25519 timestamp authority key (PKCS#8 PEM)")
+	cmd.Flags().StringVar(&tsaReceipts, "tsa-receipts", "", "Append the timestamp authority's receipts to this hash-chained log file")
+	cmd.Flags().StringVar(&remoteURL, "remote", "", "Push anchored commits to this Git remote URL or path")
+	cmd.Flags().DurationVar(&interval, "interval", 0, "Anchor again at this interval until interrupted (0 anchors once)")
+	return cmd
+}
+
+// newAnchorRecords returns a record for every anchor location given to
+// verify. Locations and keys come only from the command line, never from the
+// receipts saved in the repository.
+func newAnchorRecords(logPath, remoteURL, tsaKey, tsaReceipts string) ([]integrityapp.AnchorRecord, error) {
+	var records []integrityapp.AnchorRecord
+	if path := strings.TrimSpace(logPath); path != "" {
+		records = append(records, anchor.NewFileLog(path))
+	}
+	if url := strings.TrimSpace(remoteURL); url != "" {
+		records = append(records, gitrepo.NewRemoteAnchor(url))
+	}
+	key := strings.TrimSpace(tsaKey)
+	receipts := strings.TrimSpace(tsaReceipts)
+	if (key == "") != (receipts == "") {
+		return nil, fmt.Errorf("%w: --anchor-key and --tsa-receipts go together", integrityapp.ErrAnchorBackendRequired)
+	}
+	if key != "" {
+		authority, err := anchor.NewAuthorityChecker(key, receipts)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, authority)
+	}
+	return records, nil
+}
+
+type anchorOutput struct {
+	Anchors []anchorResultOutput `json:"anchors"`
+}
+
+type anchorResultOutput struct {
+	Backend string `json:"backend"`
+	Commit  string `json:"commit"`
+	Time    string `json:"time"`
+	Skipped bool   `json:"skipped,omitempty"`
+}
+
+func writeAnchorResults(cmd *cobra.Command, results []integrityapp.AnchorResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := anchorOutput{Anchors: make([]anchorResultOutput, 0, len(results))}
+		for _, result := range results {
+			payload.Anchors = append(payload.Anchors, anchorResultOutput{
+				Backend: result.Anchor.Backend,
+				Commit:  result.Anchor.Commit,
+				Time:    result.Anchor.Time.UTC().Format(time.RFC3339Nano),
+				Skipped: result.Skipped,
+			})
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	for _, result := range results {
+		anchored := result.Anchor
+		when := anchored.Time.UTC().Format(time.RFC3339)
+		var err error
+		if result.Skipped {
+			_, err = fmt.Fprintf(out, "%s %s already anchors %s (%s)\n", ui.dim("Unchanged"), anchored.Backend, anchored.Commit, when)
+		} else {
+			_, err = fmt.Fprintf(out, "%s %s with %s at %s\n", ui.ok("Anchored"), anchored.Commit, anchored.Backend, when)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
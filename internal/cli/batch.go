@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+var errInvalidBatch = errors.New("invalid batch file")
+
+type batchLine struct {
+	Op         string          `json:"op"`
+	Collection string          `json:"collection"`
+	ID         string          `json:"id"`
+	Payload    json.RawMessage `json:"payload"`
+	Patch      json.RawMessage `json:"patch"`
+}
+
+func parseBatch(r io.Reader) ([]docapp.BatchOp, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+
+	var ops []docapp.BatchOp
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		var line batchLine
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&line); err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", errInvalidBatch, lineNo, err)
+		}
+
+		op := docapp.BatchOp{
+			Collection: line.Collection,
+			DocID:      line.ID,
+		}
+		switch strings.ToLower(strings.TrimSpace(line.Op)) {
+		case "put":
+			op.Op = domain.TxOpPut
+			op.Payload = line.Payload
+		case "patch":
+			op.Op = domain.TxOpPatch
+			op.Payload = line.Patch
+		case "delete":
+			op.Op = domain.TxOpDelete
+		default:
+			return nil, fmt.Errorf("%w: line %d: unknown op %q (expected put, patch or delete)", errInvalidBatch, lineNo, line.Op)
+		}
+		ops = append(ops, op)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read batch: %w", err)
+	}
+	return ops, nil
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestParseBatch(t *testing.T) {
+	input := strings.Join([]string{
+		`{"op":"put","collection":"accounts","id":"a","payload":{"balance":10}}`,
+		``,
+		`{"op":"patch","collection":"accounts","id":"b","patch":[{"op":"replace","path":"/balance","value":5}]}`,
+		`{"op":"delete","collection":"accounts","id":"c"}`,
+	}, "\n")
+
+	ops, err := parseBatch(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseBatch returned error: %v", err)
+	}
+	if len(ops) != 3 {
+		t.Fatalf("expected 3 ops, got %d", len(ops))
+	}
+	if ops[0].Op != domain.TxOpPut || string(ops[0].Payload) != `{"balance":10}` {
+		t.Fatalf("unexpected put op: %+v", ops[0])
+	}
+	if ops[1].Op != domain.TxOpPatch || ops[1].DocID != "b" || len(ops[1].Payload) == 0 {
+		t.Fatalf("unexpected patch op: %+v", ops[1])
+	}
+	if ops[2].Op != domain.TxOpDelete || ops[2].Collection != "accounts" {
+		t.Fatalf("unexpected delete op: %+v", ops[2])
+	}
+}
+
+func TestParseBatchRejectsUnknownOp(t *testing.T) {
+	_, err := parseBatch(strings.NewReader(`{"op":"upsert","collection":"accounts","id":"a"}`))
+	if !errors.Is(err, errInvalidBatch) {
+		t.Fatalf("expected errInvalidBatch, got %v", err)
+	}
+	if NormalizeError(err).Kind != KindValidation {
+		t.Fatalf("expected validation error kind")
+	}
+}
@@ -2,19 +2,28 @@ package cli
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/rand"
 	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
 	collectionapp "github.com/osvaldoandrade/ledgerdb/internal/app/collection"
 	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
 	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
 	inspectapp "github.com/osvaldoandrade/ledgerdb/internal/app/inspect"
 	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
 	maintenanceapp "github.com/osvaldoandrade/ledgerdb/internal/app/maintenance"
+	queryapp "github.com/osvaldoandrade/ledgerdb/internal/app/query"
 	repoapp "github.com/osvaldoandrade/ledgerdb/internal/app/repo"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
@@ -22,10 +31,14 @@ import (
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/indexsink"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonmerge"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/sqliteindex"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txkey"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/webhook"
 	"github.com/osvaldoandrade/ledgerdb/internal/platform"
 	"github.com/spf13/cobra"
 )
@@ -106,19 +119,63 @@ func newPushCmd(opts *RootOptions) *cobra.Command {
 	}
 }
 
+func newSyncCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "sync",
+		Short: "Fetch origin, merge divergent document streams, and push",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			store := newGitStore(opts)
+			merges := docapp.NewMergeService(
+				store,
+				store,
+				store,
+				canonicaljson.Canonicalizer{},
+				txEncoder(opts),
+				txv3.Decoder{},
+				jsonpatch.Patcher{},
+				jsonmerge.Merger{},
+				hash.SHA256{},
+				platform.RealClock{},
+				ident.NewULIDGenerator(),
+				store,
+				schema.JSONSchemaValidator{},
+				opts.StreamLayout,
+				opts.HistoryMode,
+			)
+			service := docapp.NewSyncService(store, store, txv3.Decoder{}, merges, opts.HistoryMode)
+
+			var result docapp.SyncResult
+			spin := spinnerEnabled(cmd.ErrOrStderr(), opts.JSONOutput)
+			label := newRenderer(cmd.ErrOrStderr(), opts.JSONOutput).dim("Syncing origin")
+			err := withSpinner(cmd.Context(), cmd.ErrOrStderr(), spin, label, func() error {
+				var err error
+				result, err = service.Sync(cmd.Context(), opts.RepoPath)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return writeSyncResult(cmd, result, opts.JSONOutput)
+		},
+	}
+}
+
 func newCollectionCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "collection",
 		Short: "Manage collections and schemas",
 		RunE:  runHelp,
 	}
-	cmd.AddCommand(newCollectionApplyCmd(opts))
+	cmd.AddCommand(newCollectionApplyCmd(opts), newCollectionLogCmd(opts))
 	return cmd
 }
 
 func newCollectionApplyCmd(opts *RootOptions) *cobra.Command {
 	var schemaPath string
 	var indexes string
+	var fullText string
+	var trustedKeys string
+	var clearTrustedKeys bool
 	cmd := &cobra.Command{
 		Use:   "apply <name>",
 		Short: "Create or update a collection schema",
@@ -127,19 +184,47 @@ func newCollectionApplyCmd(opts *RootOptions) *cobra.Command {
 			store := newGitStore(opts)
 			service := collectionapp.NewService(store, filesystem.SchemaSource{}, schema.JSONSchemaValidator{})
 			parsedIndexes := parseCommaList(indexes)
+			parsedFullText := parseCommaList(fullText)
+			// Without either flag the current trusted keys are kept.
+			parsedKeys := parseCommaList(trustedKeys)
+			if clearTrustedKeys {
+				if parsedKeys != nil {
+					return collectionapp.ErrTrustedKeysConflict
+				}
+				parsedKeys = []string{}
+			}
 			return runWithAutoSync(cmd, opts, store, func() error {
-				return service.Apply(cmd.Context(), opts.RepoPath, args[0], schemaPath, parsedIndexes)
+				return service.Apply(cmd.Context(), opts.RepoPath, args[0], schemaPath, parsedIndexes, parsedFullText, parsedKeys)
 			})
 		},
 	}
 	cmd.Flags().StringVar(&schemaPath, "schema", "", "Path to JSON schema")
 	cmd.Flags().StringVar(&indexes, "indexes", "", "Comma-separated index fields")
+	cmd.Flags().StringVar(&fullText, "fulltext", "", "Comma-separated full-text search fields")
+	cmd.Flags().StringVar(&trustedKeys, "trusted-keys", "", "Comma-separated hex Ed25519 public keys allowed to sign writes (replaces the current keys)")
+	cmd.Flags().BoolVar(&clearTrustedKeys, "clear-trusted-keys", false, "Remove the trusted keys so unsigned writes are accepted again")
 	if err := cmd.MarkFlagRequired("schema"); err != nil {
 		return cmd
 	}
 	return cmd
 }
 
+func newCollectionLogCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "log <name>",
+		Short: "Show schema and index history for a collection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := collectionapp.NewLogService(newGitStore(opts), hash.SHA256{})
+			entries, err := service.Log(cmd.Context(), opts.RepoPath, args[0])
+			if err != nil {
+				return err
+			}
+			return writeCollectionLogResult(cmd, entries, opts.JSONOutput)
+		},
+	}
+}
+
 func newDocCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "doc",
@@ -153,6 +238,10 @@ func newDocCmd(opts *RootOptions) *cobra.Command {
 		newDocDeleteCmd(opts),
 		newDocRevertCmd(opts),
 		newDocLogCmd(opts),
+		newDocApplyCmd(opts),
+		newDocMergeCmd(opts),
+		newDocDiffCmd(opts),
+		newDocListCmd(opts),
 	)
 	return cmd
 }
@@ -160,6 +249,7 @@ func newDocCmd(opts *RootOptions) *cobra.Command {
 func newDocPutCmd(opts *RootOptions) *cobra.Command {
 	var payload string
 	var payloadFile string
+	var writeOpts docapp.WriteOptions
 	cmd := &cobra.Command{
 		Use:   "put <collection> <doc_id>",
 		Short: "Write a document snapshot",
@@ -175,16 +265,18 @@ func newDocPutCmd(opts *RootOptions) *cobra.Command {
 			service := docapp.NewPutService(
 				store,
 				canonicaljson.Canonicalizer{},
-				txv3.Encoder{},
+				txEncoder(opts),
 				hash.SHA256{},
 				platform.RealClock{},
 				idGen,
+				store,
+				schema.JSONSchemaValidator{},
 				opts.StreamLayout,
 				opts.HistoryMode,
 			)
 
 			return runWithAutoSync(cmd, opts, store, func() error {
-				result, err := service.Put(cmd.Context(), opts.RepoPath, args[0], args[1], data)
+				result, err := service.Put(cmd.Context(), opts.RepoPath, args[0], args[1], data, writeOpts)
 				if err != nil {
 					return err
 				}
@@ -195,28 +287,47 @@ func newDocPutCmd(opts *RootOptions) *cobra.Command {
 
 	cmd.Flags().StringVar(&payload, "payload", "", "Inline JSON document payload")
 	cmd.Flags().StringVar(&payloadFile, "file", "", "Path to JSON document payload")
+	addWritePreconditionFlags(cmd, &writeOpts)
 	return cmd
 }
 
+var errInvalidAsOf = errors.New("as-of must be an RFC3339 timestamp")
+
 func newDocGetCmd(opts *RootOptions) *cobra.Command {
-	return &cobra.Command{
+	var readOpts docapp.ReadOptions
+	var asOf string
+	cmd := &cobra.Command{
 		Use:   "get <collection> <doc_id>",
 		Short: "Read a document state",
 		Args:  cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			service := docapp.NewGetService(newGitStore(opts), txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, opts.StreamLayout)
-			result, err := service.Get(cmd.Context(), opts.RepoPath, args[0], args[1])
+			if asOf != "" {
+				parsed, err := time.Parse(time.RFC3339, asOf)
+				if err != nil {
+					return fmt.Errorf("%w: %v", errInvalidAsOf, err)
+				}
+				readOpts.AsOf = parsed
+			}
+			store := newGitStore(opts)
+			service := docapp.NewGetService(store, store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, store, opts.StreamLayout)
+			result, err := service.GetAt(cmd.Context(), opts.RepoPath, args[0], args[1], readOpts)
 			if err != nil {
 				return err
 			}
 			return writeGetResult(cmd, result, opts.JSONOutput)
 		},
 	}
+	cmd.Flags().StringVar(&readOpts.AtTx, "at-tx", "", "Read the document as of a transaction (tx hash or tx id)")
+	cmd.Flags().StringVar(&readOpts.AtCommit, "at-commit", "", "Read the document as of a repository commit")
+	cmd.Flags().StringVar(&asOf, "as-of", "", "Read the document as of a point in time (RFC3339)")
+	return cmd
 }
 
 func newDocPatchCmd(opts *RootOptions) *cobra.Command {
 	var ops string
 	var opsFile string
+	var writeOpts docapp.WriteOptions
+	retry := docapp.DefaultRetryPolicy()
 	cmd := &cobra.Command{
 		Use:   "patch <collection> <doc_id>",
 		Short: "Apply a JSON Patch delta",
@@ -233,17 +344,20 @@ func newDocPatchCmd(opts *RootOptions) *cobra.Command {
 				store,
 				store,
 				canonicaljson.Canonicalizer{},
-				txv3.Encoder{},
+				txEncoder(opts),
 				txv3.Decoder{},
 				jsonpatch.Patcher{},
 				hash.SHA256{},
 				platform.RealClock{},
 				idGen,
+				store,
+				schema.JSONSchemaValidator{},
+				retry,
 				opts.StreamLayout,
 				opts.HistoryMode,
 			)
 			return runWithAutoSync(cmd, opts, store, func() error {
-				result, err := service.Patch(cmd.Context(), opts.RepoPath, args[0], args[1], data)
+				result, err := service.Patch(cmd.Context(), opts.RepoPath, args[0], args[1], data, writeOpts)
 				if err != nil {
 					return err
 				}
@@ -253,11 +367,14 @@ func newDocPatchCmd(opts *RootOptions) *cobra.Command {
 	}
 	cmd.Flags().StringVar(&ops, "ops", "", "Inline JSON Patch operations")
 	cmd.Flags().StringVar(&opsFile, "file", "", "Path to JSON Patch operations")
+	cmd.Flags().IntVar(&retry.MaxRetries, "retries", retry.MaxRetries, "Rebase and retry the patch this many times when another writer moves the stream head")
+	addWritePreconditionFlags(cmd, &writeOpts)
 	return cmd
 }
 
 func newDocDeleteCmd(opts *RootOptions) *cobra.Command {
-	return &cobra.Command{
+	var writeOpts docapp.WriteOptions
+	cmd := &cobra.Command{
 		Use:   "delete <collection> <doc_id>",
 		Short: "Delete a document (tombstone)",
 		Args:  cobra.ExactArgs(2),
@@ -267,7 +384,7 @@ func newDocDeleteCmd(opts *RootOptions) *cobra.Command {
 			service := docapp.NewDeleteService(
 				store,
 				store,
-				txv3.Encoder{},
+				txEncoder(opts),
 				txv3.Decoder{},
 				hash.SHA256{},
 				platform.RealClock{},
@@ -276,7 +393,7 @@ func newDocDeleteCmd(opts *RootOptions) *cobra.Command {
 				opts.HistoryMode,
 			)
 			return runWithAutoSync(cmd, opts, store, func() error {
-				result, err := service.Delete(cmd.Context(), opts.RepoPath, args[0], args[1])
+				result, err := service.Delete(cmd.Context(), opts.RepoPath, args[0], args[1], writeOpts)
 				if err != nil {
 					return err
 				}
@@ -284,6 +401,145 @@ func newDocDeleteCmd(opts *RootOptions) *cobra.Command {
 			})
 		},
 	}
+	addWritePreconditionFlags(cmd, &writeOpts)
+	return cmd
+}
+
+func newDocApplyCmd(opts *RootOptions) *cobra.Command {
+	var batchFile string
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply a batch of writes atomically in one commit",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			batchFile = strings.TrimSpace(batchFile)
+			if batchFile == "" {
+				return fmt.Errorf("batch is required (use --batch)")
+			}
+
+			var input io.Reader = cmd.InOrStdin()
+			if batchFile != "-" {
+				file, err := os.Open(batchFile)
+				if err != nil {
+					return fmt.Errorf("read batch file: %w", err)
+				}
+				defer file.Close()
+				input = file
+			}
+			ops, err := parseBatch(input)
+			if err != nil {
+				return err
+			}
+
+			store := newGitStore(opts)
+			service := docapp.NewBatchService(
+				store,
+				store,
+				canonicaljson.Canonicalizer{},
+				txEncoder(opts),
+				txv3.Decoder{},
+				jsonpatch.Patcher{},
+				hash.SHA256{},
+				platform.RealClock{},
+				ident.NewULIDGenerator(),
+				store,
+				schema.JSONSchemaValidator{},
+				opts.StreamLayout,
+				opts.HistoryMode,
+			)
+			return runWithAutoSync(cmd, opts, store, func() error {
+				result, err := service.Apply(cmd.Context(), opts.RepoPath, ops)
+				if err != nil {
+					return err
+				}
+				return writeBatchResult(cmd, ops, result, opts.JSONOutput)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&batchFile, "batch", "", "Path to NDJSON batch file (- for stdin)")
+	return cmd
+}
+
+func newDocMergeCmd(opts *RootOptions) *cobra.Command {
+	var theirs string
+	cmd := &cobra.Command{
+		Use:   "merge <collection> <doc_id>",
+		Short: "Three-way merge a divergent document head from another ref",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := newGitStore(opts)
+			service := docapp.NewMergeService(
+				store,
+				store,
+				store,
+				canonicaljson.Canonicalizer{},
+				txEncoder(opts),
+				txv3.Decoder{},
+				jsonpatch.Patcher{},
+				jsonmerge.Merger{},
+				hash.SHA256{},
+				platform.RealClock{},
+				ident.NewULIDGenerator(),
+				store,
+				schema.JSONSchemaValidator{},
+				opts.StreamLayout,
+				opts.HistoryMode,
+			)
+			return runWithAutoSync(cmd, opts, store, func() error {
+				result, err := service.Merge(cmd.Context(), opts.RepoPath, args[0], args[1], docapp.MergeOptions{Theirs: theirs})
+				if err != nil {
+					return err
+				}
+				return writeMergeResult(cmd, result, opts.JSONOutput)
+			})
+		},
+	}
+	cmd.Flags().StringVar(&theirs, "theirs", "", "Ref or commit holding the other head (e.g. refs/remotes/origin/main)")
+	return cmd
+}
+
+func newDocDiffCmd(opts *RootOptions) *cobra.Command {
+	var diffOpts docapp.DiffOptions
+	cmd := &cobra.Command{
+		Use:   "diff <collection> <doc_id>",
+		Short: "Show what changed between two document versions",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := newGitStore(opts)
+			service := docapp.NewDiffService(store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, jsonpatch.Differ{}, opts.StreamLayout)
+			result, err := service.Diff(cmd.Context(), opts.RepoPath, args[0], args[1], diffOpts)
+			if err != nil {
+				return err
+			}
+			return writeDiffResult(cmd, result, opts.JSONOutput)
+		},
+	}
+	cmd.Flags().StringVar(&diffOpts.From, "from", "", "Base transaction (tx hash or tx id); defaults to the parent of --to")
+	cmd.Flags().StringVar(&diffOpts.To, "to", "", "Target transaction (tx hash or tx id); defaults to the stream head")
+	return cmd
+}
+
+func newDocListCmd(opts *RootOptions) *cobra.Command {
+	listOpts := docapp.ListOptions{Limit: 100}
+	cmd := &cobra.Command{
+		Use:   "list <collection>",
+		Short: "List documents in a collection directly from the ledger",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := newGitStore(opts)
+			service := docapp.NewListService(store, txv3.Decoder{})
+			result, err := service.List(cmd.Context(), opts.RepoPath, args[0], listOpts)
+			if err != nil {
+				return err
+			}
+			return writeListResult(cmd, result, opts.JSONOutput)
+		},
+	}
+	cmd.Flags().StringVar(&listOpts.Prefix, "prefix", "", "Only list document ids starting with this prefix")
+	cmd.Flags().StringVar(&listOpts.After, "cursor", "", "Resume after the cursor returned by a previous page")
+	cmd.Flags().IntVar(&listOpts.Limit, "limit", listOpts.Limit, "Maximum documents per page (0 for all)")
+	cmd.Flags().BoolVar(&listOpts.IncludeDeleted, "include-deleted", false, "Include deleted documents")
+	return cmd
 }
 
 func newDocRevertCmd(opts *RootOptions) *cobra.Command {
@@ -300,12 +556,14 @@ func newDocRevertCmd(opts *RootOptions) *cobra.Command {
 				store,
 				store,
 				canonicaljson.Canonicalizer{},
-				txv3.Encoder{},
+				txEncoder(opts),
 				txv3.Decoder{},
 				jsonpatch.Patcher{},
 				hash.SHA256{},
 				platform.RealClock{},
 				idGen,
+				store,
+				schema.JSONSchemaValidator{},
 				opts.StreamLayout,
 				opts.HistoryMode,
 			)
@@ -348,7 +606,7 @@ func newIndexCmd(opts *RootOptions) *cobra.Command {
 		Short: "External index operations",
 		RunE:  runHelp,
 	}
-	cmd.AddCommand(newIndexSyncCmd(opts), newIndexWatchCmd(opts))
+	cmd.AddCommand(newIndexSyncCmd(opts), newIndexWatchCmd(opts), newIndexSearchCmd(opts))
 	return cmd
 }
 
@@ -358,6 +616,8 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 	var batchCommits int
 	var fast bool
 	var mode string
+	var history bool
+	var sink string
 	cmd := &cobra.Command{
 		Use:   "sync",
 		Short: "Sync the SQLite index from the ledger",
@@ -369,7 +629,7 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 			if err != nil {
 				return err
 			}
-			store, err := sqliteindex.OpenWithOptions(dbPath, sqliteindex.OpenOptions{Fast: fast})
+			store, err := openIndexSink(dbPath, sink, fast)
 			if err != nil {
 				return err
 			}
@@ -379,6 +639,7 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 
 			gitStore := newGitStore(opts)
 			service := indexapp.NewSyncService(
+				gitStore,
 				gitStore,
 				gitStore,
 				store,
@@ -386,6 +647,7 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 				txv3.Decoder{},
 				jsonpatch.Patcher{},
 				hash.SHA256{},
+				gitStore,
 			)
 
 			var result indexapp.SyncResult
@@ -398,6 +660,7 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 					AllowReset:   opts.HistoryMode == domain.HistoryModeAmend,
 					BatchCommits: batchCommits,
 					Mode:         parsedMode,
+					History:      history,
 				})
 				return err
 			})
@@ -412,9 +675,8 @@ func newIndexSyncCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().IntVar(&batchCommits, "batch-commits", 1, "Commits per SQLite transaction (>=1)")
 	cmd.Flags().BoolVar(&fast, "fast", false, "Relax SQLite durability for faster indexing")
 	cmd.Flags().StringVar(&mode, "mode", string(indexapp.ModeState), "Index source (history, state)")
-	if err := cmd.MarkFlagRequired("db"); err != nil {
-		return cmd
-	}
+	cmd.Flags().BoolVar(&history, "history", false, "Record every applied tx in collection_<name>_history (replays commits)")
+	cmd.Flags().StringVar(&sink, "sink", "", "Index sink URI instead of --db (sqlite://, postgres://, file://<out.ndjson>, parquet://<dir>)")
 	return cmd
 }
 
@@ -429,6 +691,9 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 	var batchCommits int
 	var fast bool
 	var mode string
+	var history bool
+	var sink string
+	var hooksPath string
 	cmd := &cobra.Command{
 		Use:   "watch",
 		Short: "Continuously sync the SQLite index",
@@ -447,7 +712,7 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 				return err
 			}
 
-			store, err := sqliteindex.OpenWithOptions(dbPath, sqliteindex.OpenOptions{Fast: fast})
+			store, err := openIndexSink(dbPath, sink, fast)
 			if err != nil {
 				return err
 			}
@@ -457,6 +722,7 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 
 			gitStore := newGitStore(opts)
 			service := indexapp.NewSyncService(
+				gitStore,
 				gitStore,
 				gitStore,
 				store,
@@ -464,8 +730,23 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 				txv3.Decoder{},
 				jsonpatch.Patcher{},
 				hash.SHA256{},
+				gitStore,
 			)
 
+			var dispatcher *hooksapp.Dispatcher
+			var hookCfg webhook.Config
+			if hooksPath != "" {
+				hookCfg, err = webhook.LoadConfig(hooksPath)
+				if err != nil {
+					return err
+				}
+				dispatcher = hooksapp.NewDispatcher(
+					changesapp.NewService(gitStore, gitStore, txv3.Decoder{}, hash.SHA256{}),
+					webhook.NewSender(hookCfg.Timeout),
+					webhook.NewFileCheckpoint(hookCfg.Checkpoint),
+				)
+			}
+
 			rng := rand.New(rand.NewSource(time.Now().UnixNano()))
 			spin := spinnerEnabled(cmd.ErrOrStderr(), opts.JSONOutput) && !quiet
 			label := newRenderer(cmd.ErrOrStderr(), opts.JSONOutput).accent("Syncing index")
@@ -479,6 +760,7 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 						AllowReset:   opts.HistoryMode == domain.HistoryModeAmend,
 						BatchCommits: batchCommits,
 						Mode:         parsedMode,
+						History:      history,
 					})
 					return err
 				})
@@ -490,6 +772,23 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 						return err
 					}
 				}
+				if dispatcher != nil {
+					hookResult, err := dispatcher.Dispatch(cmd.Context(), opts.RepoPath, hookCfg.Hooks)
+					if err != nil {
+						if once || !errors.Is(err, hooksapp.ErrDeliveryFailed) {
+							return err
+						}
+						ui := newRenderer(cmd.ErrOrStderr(), opts.JSONOutput)
+						if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "%s %v\n", ui.warn("Hooks:"), err); err != nil {
+							return err
+						}
+					}
+					if !quiet && hookResult.Delivered > 0 {
+						if err := writeHookDispatchResult(cmd, hookResult, opts.JSONOutput); err != nil {
+							return err
+						}
+					}
+				}
 				if once {
 					return nil
 				}
@@ -518,12 +817,99 @@ func newIndexWatchCmd(opts *RootOptions) *cobra.Command {
 	cmd.Flags().IntVar(&batchCommits, "batch-commits", 1, "Commits per SQLite transaction (>=1)")
 	cmd.Flags().BoolVar(&fast, "fast", false, "Relax SQLite durability for faster indexing")
 	cmd.Flags().StringVar(&mode, "mode", string(indexapp.ModeState), "Index source (history, state)")
+	cmd.Flags().BoolVar(&history, "history", false, "Record every applied tx in collection_<name>_history (replays commits)")
+	cmd.Flags().StringVar(&sink, "sink", "", "Index sink URI instead of --db (sqlite://, postgres://, file://<out.ndjson>, parquet://<dir>)")
+	cmd.Flags().StringVar(&hooksPath, "hooks", "", "JSON hooks file; deliver committed txs to webhooks or commands after each sync")
+	return cmd
+}
+
+func newIndexSearchCmd(opts *RootOptions) *cobra.Command {
+	var dbPath string
+	var searchOpts indexapp.SearchOptions
+	cmd := &cobra.Command{
+		Use:   "search <collection> <query>",
+		Short: "Full-text search a collection in the SQLite index",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := sqliteindex.Open(dbPath)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				_ = store.Close()
+			}()
+
+			service := indexapp.NewSearchService(store)
+			hits, err := service.Search(cmd.Context(), args[0], args[1], searchOpts)
+			if err != nil {
+				return err
+			}
+			return writeSearchResult(cmd, hits, opts.JSONOutput)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "Path to SQLite index database")
+	cmd.Flags().IntVar(&searchOpts.Limit, "limit", 20, "Maximum hits to return")
 	if err := cmd.MarkFlagRequired("db"); err != nil {
 		return cmd
 	}
 	return cmd
 }
 
+func newQueryCmd(opts *RootOptions) *cobra.Command {
+	var where string
+	cmd := &cobra.Command{
+		Use:   "query <collection>",
+		Short: "Find documents by a declared index field",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store := newGitStore(opts)
+			service := queryapp.NewService(store, store)
+			result, err := service.Where(cmd.Context(), opts.RepoPath, args[0], where)
+			if err != nil {
+				return err
+			}
+			return writeQueryResult(cmd, result, opts.JSONOutput)
+		},
+	}
+	cmd.Flags().StringVar(&where, "where", "", "Equality filter as field=value")
+	_ = cmd.MarkFlagRequired("where")
+	return cmd
+}
+
+func newChangesCmd(opts *RootOptions) *cobra.Command {
+	var since string
+	var follow bool
+	var interval time.Duration
+	var fetch bool
+	cmd := &cobra.Command{
+		Use:   "changes",
+		Short: "Stream one event per transaction on main, in commit order",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			gitStore := newGitStore(opts)
+			service := changesapp.NewService(gitStore, gitStore, txv3.Decoder{}, hash.SHA256{})
+			out := cmd.OutOrStdout()
+			ui := newRenderer(out, opts.JSONOutput)
+			emit := func(event changesapp.Event) error {
+				return writeChangeEvent(out, ui, event, opts.JSONOutput)
+			}
+			if follow {
+				return service.Follow(cmd.Context(), opts.RepoPath, changesapp.FollowOptions{
+					Since:    since,
+					Fetch:    fetch,
+					Interval: interval,
+				}, emit)
+			}
+			_, err := service.Stream(cmd.Context(), opts.RepoPath, changesapp.StreamOptions{Since: since, Fetch: fetch}, emit)
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&since, "since", "", "Resume after a cursor or commit hash (default: start of history)")
+	cmd.Flags().BoolVar(&follow, "follow", false, "Keep polling for new commits")
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Polling interval with --follow")
+	cmd.Flags().BoolVar(&fetch, "fetch", false, "Fetch remote updates before each poll")
+	return cmd
+}
+
 func newMaintenanceCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "maintenance",
@@ -571,7 +957,7 @@ func newMaintenanceSnapshotCmd(opts *RootOptions) *cobra.Command {
 				store,
 				store,
 				canonicaljson.Canonicalizer{},
-				txv3.Encoder{},
+				txEncoder(opts),
 				txv3.Decoder{},
 				jsonpatch.Patcher{},
 				hash.SHA256{},
@@ -612,9 +998,37 @@ func newIntegrityCmd(opts *RootOptions) *cobra.Command {
 		RunE:  runHelp,
 	}
 	cmd.AddCommand(newIntegrityVerifyCmd(opts))
+	cmd.AddCommand(newIntegrityProveCmd(opts))
+	cmd.AddCommand(newIntegrityCheckProofCmd(opts))
+	cmd.AddCommand(newIntegrityAnchorCmd(opts))
+	return cmd
+}
+
+func newKeyCmd(opts *RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "key",
+		Short: "Manage transaction signing keys",
+		RunE:  runHelp,
+	}
+	cmd.AddCommand(newKeyGenerateCmd(opts))
 	return cmd
 }
 
+func newKeyGenerateCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "generate <file>",
+		Short: "Write a new Ed25519 signing key and print its public key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := txkey.Generate(args[0])
+			if err != nil {
+				return err
+			}
+			return writeKeyResult(cmd, args[0], txkey.PublicKeyHex(key), opts.JSONOutput)
+		},
+	}
+}
+
 func newInspectCmd(opts *RootOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "inspect",
@@ -643,10 +1057,25 @@ func newInspectBlobCmd(opts *RootOptions) *cobra.Command {
 
 func newIntegrityVerifyCmd(opts *RootOptions) *cobra.Command {
 	var deep bool
+	var state bool
+	var signatures bool
+	var keyring string
+	var workers int
+	var checkpoint string
+	var anchorLog string
+	var anchorRemote string
+	var anchorKey string
+	var tsaReceipts string
 	cmd := &cobra.Command{
 		Use:   "verify",
 		Short: "Verify hash chains and report corruption",
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			if workers <= 0 {
+				return integrityapp.ErrInvalidWorkers
+			}
+			if strings.TrimSpace(keyring) != "" {
+				signatures = true
+			}
 			store := newGitStore(opts)
 			service := integrityapp.NewVerifyService(
 				store,
@@ -654,13 +1083,49 @@ func newIntegrityVerifyCmd(opts *RootOptions) *cobra.Command {
 				txv3.Decoder{},
 				hash.SHA256{},
 				jsonpatch.Patcher{},
+				canonicaljson.Canonicalizer{},
+				store,
+				store,
+				store,
+				store,
 			)
+			verifyOpts := integrityapp.VerifyOptions{
+				Deep:       deep,
+				State:      state,
+				Signatures: signatures,
+				Keyring:    strings.TrimSpace(keyring),
+				Workers:    workers,
+			}
+			records, err := newAnchorRecords(anchorLog, anchorRemote, anchorKey, tsaReceipts)
+			if err != nil {
+				return err
+			}
+			verifyOpts.AnchorRecords = records
+			if path := strings.TrimSpace(checkpoint); path != "" {
+				verifyOpts.Checkpoint = filesystem.NewVerifyCheckpoint(path)
+			}
+			var done, total atomic.Int64
+			verifyOpts.Progress = func(verified, streams int) {
+				done.Store(int64(verified))
+				total.Store(int64(streams))
+			}
+
+			// An interrupt cancels the run so the checkpoint records the last
+			// verified stream before exiting.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
 			var result integrityapp.VerifyResult
 			spin := spinnerEnabled(cmd.ErrOrStderr(), opts.JSONOutput)
 			label := newRenderer(cmd.ErrOrStderr(), opts.JSONOutput).accent("Verifying integrity")
-			err := withSpinner(cmd.Context(), cmd.ErrOrStderr(), spin, label, func() error {
+			err = withSpinnerLabel(ctx, cmd.ErrOrStderr(), spin, func() string {
+				if total.Load() == 0 {
+					return label
+				}
+				return fmt.Sprintf("%s %d/%d", label, done.Load(), total.Load())
+			}, func() error {
 				var err error
-				result, err = service.Verify(cmd.Context(), opts.RepoPath, integrityapp.VerifyOptions{Deep: deep})
+				result, err = service.Verify(ctx, opts.RepoPath, verifyOpts)
 				return err
 			})
 			if err != nil {
@@ -670,6 +1135,15 @@ func newIntegrityVerifyCmd(opts *RootOptions) *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolVar(&deep, "deep", false, "Rebuild documents by applying patches")
+	cmd.Flags().BoolVar(&state, "state", false, "Rehydrate every stream and compare it with its state/ snapshot")
+	cmd.Flags().BoolVar(&signatures, "signatures", false, "Verify the GPG/SSH signature of every commit on main")
+	cmd.Flags().StringVar(&keyring, "keyring", "", "Trusted keys: a GnuPG home directory or an SSH allowed_signers file (implies --signatures)")
+	cmd.Flags().IntVar(&workers, "workers", runtime.NumCPU(), "Number of streams to verify concurrently")
+	cmd.Flags().StringVar(&checkpoint, "checkpoint", "", "Progress file; an interrupted run resumes after the last verified stream")
+	cmd.Flags().StringVar(&anchorLog, "file", "", "Check that main contains every commit in this anchor log file")
+	cmd.Flags().StringVar(&anchorRemote, "remote", "", "Check that main contains every commit anchored on this Git remote")
+	cmd.Flags().StringVar(&anchorKey, "anchor-key", "", "Check the tsa receipts against this timestamp authority public key (hex)")
+	cmd.Flags().StringVar(&tsaReceipts, "tsa-receipts", "", "Timestamp authority receipt log to check with --anchor-key")
 	return cmd
 }
 
@@ -679,34 +1153,145 @@ type putOutput struct {
 	TxID   string `json:"tx_id"`
 }
 
+type batchOutput struct {
+	Commit string          `json:"commit"`
+	Txs    []batchTxOutput `json:"txs"`
+}
+
+type batchTxOutput struct {
+	Op         string `json:"op"`
+	Collection string `json:"collection"`
+	DocID      string `json:"doc_id"`
+	TxHash     string `json:"tx_hash"`
+	TxID       string `json:"tx_id"`
+}
+
+type mergeOutput struct {
+	Commit    string   `json:"commit,omitempty"`
+	TxHash    string   `json:"tx_hash,omitempty"`
+	TxID      string   `json:"tx_id,omitempty"`
+	Base      string   `json:"base,omitempty"`
+	Ours      string   `json:"ours,omitempty"`
+	Theirs    string   `json:"theirs,omitempty"`
+	Conflicts []string `json:"conflicts,omitempty"`
+	UpToDate  bool     `json:"up_to_date"`
+}
+
+type diffOutput struct {
+	FromTxHash string           `json:"from_tx_hash,omitempty"`
+	FromTxID   string           `json:"from_tx_id,omitempty"`
+	ToTxHash   string           `json:"to_tx_hash"`
+	ToTxID     string           `json:"to_tx_id,omitempty"`
+	Patch      []docapp.PatchOp `json:"patch"`
+}
+
+type syncOutput struct {
+	Action     string             `json:"action"`
+	Commit     string             `json:"commit,omitempty"`
+	LocalHead  string             `json:"local_head,omitempty"`
+	RemoteHead string             `json:"remote_head,omitempty"`
+	Merged     []syncStreamOutput `json:"merged,omitempty"`
+}
+
+type syncStreamOutput struct {
+	Collection string   `json:"collection"`
+	DocID      string   `json:"doc_id"`
+	TxHash     string   `json:"tx_hash"`
+	Conflicts  []string `json:"conflicts,omitempty"`
+}
+
 type getOutput struct {
 	Doc    json.RawMessage `json:"doc"`
 	TxHash string          `json:"tx_hash,omitempty"`
 	TxID   string          `json:"tx_id,omitempty"`
 	Op     string          `json:"op,omitempty"`
+	Commit string          `json:"commit,omitempty"`
 }
 
-type logOutput struct {
-	Entries []logEntryOutput `json:"entries"`
+type listOutput struct {
+	Entries    []listEntryOutput `json:"entries"`
+	NextCursor string            `json:"next_cursor,omitempty"`
 }
 
-type logEntryOutput struct {
-	TxHash     string `json:"tx_hash"`
-	TxID       string `json:"tx_id"`
-	ParentHash string `json:"parent_hash,omitempty"`
-	Timestamp  int64  `json:"timestamp"`
-	Op         string `json:"op"`
+type queryOutput struct {
+	Collection string   `json:"collection"`
+	Field      string   `json:"field"`
+	Value      string   `json:"value"`
+	DocIDs     []string `json:"doc_ids"`
 }
 
-type integrityOutput struct {
-	Streams int                    `json:"streams"`
-	Valid   int                    `json:"valid"`
-	Issues  []integrityIssueOutput `json:"issues,omitempty"`
+type hookDispatchOutput struct {
+	Events    int    `json:"hook_events"`
+	Delivered int    `json:"hooks_delivered"`
+	Cursor    string `json:"hook_cursor"`
 }
 
-type integrityIssueOutput struct {
-	StreamPath string `json:"stream_path"`
-	Code       string `json:"code"`
+type changeEventOutput struct {
+	Cursor        string          `json:"cursor"`
+	Commit        string          `json:"commit"`
+	Collection    string          `json:"collection"`
+	DocID         string          `json:"doc_id"`
+	Op            string          `json:"op"`
+	TxID          string          `json:"tx_id"`
+	TxHash        string          `json:"tx_hash"`
+	ParentHash    string          `json:"parent_hash,omitempty"`
+	Timestamp     int64           `json:"timestamp"`
+	SchemaVersion string          `json:"schema_version,omitempty"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Patch         json.RawMessage `json:"patch,omitempty"`
+}
+
+type listEntryOutput struct {
+	DocID   string          `json:"doc_id"`
+	TxID    string          `json:"tx_id"`
+	Op      string          `json:"op"`
+	Deleted bool            `json:"deleted,omitempty"`
+	Doc     json.RawMessage `json:"doc,omitempty"`
+}
+
+type logOutput struct {
+	Entries []logEntryOutput `json:"entries"`
+}
+
+type logEntryOutput struct {
+	TxHash       string   `json:"tx_hash"`
+	TxID         string   `json:"tx_id"`
+	ParentHash   string   `json:"parent_hash,omitempty"`
+	MergeParents []string `json:"merge_parents,omitempty"`
+	Timestamp    int64    `json:"timestamp"`
+	Op           string   `json:"op"`
+}
+
+type collectionLogOutput struct {
+	Entries []collectionLogEntryOutput `json:"entries"`
+}
+
+type collectionLogEntryOutput struct {
+	Commit        string   `json:"commit"`
+	Timestamp     string   `json:"timestamp"`
+	SchemaVersion string   `json:"schema_version,omitempty"`
+	Indexes       []string `json:"indexes,omitempty"`
+	FullText      []string `json:"fulltext,omitempty"`
+	TrustedKeys   []string `json:"trusted_keys,omitempty"`
+	Removed       bool     `json:"removed,omitempty"`
+}
+
+type integrityOutput struct {
+	Snapshot string                 `json:"snapshot,omitempty"`
+	Streams  int                    `json:"streams"`
+	Valid    int                    `json:"valid"`
+	Resumed  int                    `json:"resumed,omitempty"`
+	Commits  int                    `json:"commits,omitempty"`
+	Anchors  int                    `json:"anchors,omitempty"`
+	Issues   []integrityIssueOutput `json:"issues,omitempty"`
+}
+
+type integrityIssueOutput struct {
+	StreamPath string `json:"stream_path,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	DocID      string `json:"doc_id,omitempty"`
+	Code       string `json:"code"`
 	Message    string `json:"message"`
 }
 
@@ -732,6 +1317,11 @@ type gcOutput struct {
 	Prune  string `json:"prune,omitempty"`
 }
 
+type keyOutput struct {
+	Path      string `json:"path"`
+	PublicKey string `json:"public_key"`
+}
+
 type inspectOutput struct {
 	ObjectHash    string          `json:"object_hash"`
 	TxHash        string          `json:"tx_hash"`
@@ -747,14 +1337,27 @@ type inspectOutput struct {
 }
 
 type indexSyncOutput struct {
-	Reset        bool   `json:"reset"`
-	Fetched      bool   `json:"fetched"`
-	Commits      int    `json:"commits"`
-	TxsApplied   int    `json:"txs_applied"`
-	DocsUpserted int    `json:"docs_upserted"`
-	DocsDeleted  int    `json:"docs_deleted"`
-	Collections  int    `json:"collections"`
-	LastCommit   string `json:"last_commit,omitempty"`
+	Reset           bool   `json:"reset"`
+	Fetched         bool   `json:"fetched"`
+	Commits         int    `json:"commits"`
+	TxsApplied      int    `json:"txs_applied"`
+	DocsUpserted    int    `json:"docs_upserted"`
+	DocsDeleted     int    `json:"docs_deleted"`
+	Collections     int    `json:"collections"`
+	LastCommit      string `json:"last_commit,omitempty"`
+	IndexesCreated  int    `json:"indexes_created,omitempty"`
+	IndexesDropped  int    `json:"indexes_dropped,omitempty"`
+	FullTextRebuilt int    `json:"fulltext_rebuilt,omitempty"`
+}
+
+type searchOutput struct {
+	Hits []searchHitOutput `json:"hits"`
+}
+
+type searchHitOutput struct {
+	DocID   string  `json:"doc_id"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
 }
 
 type statusOutput struct {
@@ -844,14 +1447,18 @@ func writeStatus(cmd *cobra.Command, status domain.RepoStatus, asJSON bool) erro
 	return nil
 }
 
+func newPutOutput(result docapp.PutResult) putOutput {
+	return putOutput{
+		Commit: result.CommitHash,
+		TxHash: result.TxHash,
+		TxID:   result.TxID,
+	}
+}
+
 func writePutResult(cmd *cobra.Command, result docapp.PutResult, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
-		payload := putOutput{
-			Commit: result.CommitHash,
-			TxHash: result.TxHash,
-			TxID:   result.TxID,
-		}
+		payload := newPutOutput(result)
 		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(payload)
@@ -870,20 +1477,219 @@ func writePutResult(cmd *cobra.Command, result docapp.PutResult, asJSON bool) er
 	return nil
 }
 
-func writeGetResult(cmd *cobra.Command, result docapp.GetResult, asJSON bool) error {
+func writeBatchResult(cmd *cobra.Command, ops []docapp.BatchOp, result docapp.BatchResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := batchOutput{
+			Commit: result.CommitHash,
+			Txs:    make([]batchTxOutput, 0, len(result.Txs)),
+		}
+		for i, tx := range result.Txs {
+			payload.Txs = append(payload.Txs, batchTxOutput{
+				Op:         ops[i].Op.String(),
+				Collection: ops[i].Collection,
+				DocID:      ops[i].DocID,
+				TxHash:     tx.TxHash,
+				TxID:       tx.TxID,
+			})
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	if err := writeKV(out, ui, "Commit", result.CommitHash); err != nil {
+		return err
+	}
+	for i, tx := range result.Txs {
+		op := colorOp(ui, ops[i].Op.String())
+		if _, err := fmt.Fprintf(out, "%s %s %s/%s %s\n", tx.TxHash, tx.TxID, ops[i].Collection, ops[i].DocID, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMergeResult(cmd *cobra.Command, result docapp.MergeResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := mergeOutput{
+			Commit:    result.CommitHash,
+			TxHash:    result.TxHash,
+			TxID:      result.TxID,
+			Base:      result.BaseHash,
+			Ours:      result.OursHash,
+			Theirs:    result.TheirsHash,
+			Conflicts: result.Conflicts,
+			UpToDate:  result.UpToDate,
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	if result.UpToDate {
+		_, err := fmt.Fprintln(out, ui.ok("Already up to date"))
+		return err
+	}
+	rows := [][2]string{
+		{"Commit", result.CommitHash},
+		{"Tx Hash", result.TxHash},
+		{"Tx ID", result.TxID},
+		{"Base", result.BaseHash},
+		{"Ours", result.OursHash},
+		{"Theirs", result.TheirsHash},
+	}
+	for _, row := range rows {
+		if err := writeKV(out, ui, row[0], row[1]); err != nil {
+			return err
+		}
+	}
+	if len(result.Conflicts) == 0 {
+		return nil
+	}
+	if _, err := fmt.Fprintln(out, ui.warn(fmt.Sprintf("Conflicts (%d), recorded under _conflicts:", len(result.Conflicts)))); err != nil {
+		return err
+	}
+	for _, pointer := range result.Conflicts {
+		if _, err := fmt.Fprintf(out, "  %s\n", pointer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDiffResult(cmd *cobra.Command, result docapp.DiffResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := diffOutput{
+			FromTxHash: result.FromHash,
+			FromTxID:   result.FromTxID,
+			ToTxHash:   result.ToHash,
+			ToTxID:     result.ToTxID,
+			Patch:      result.Ops,
+		}
+		if payload.Patch == nil {
+			payload.Patch = []docapp.PatchOp{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	from := result.FromHash
+	if from == "" {
+		from = ui.dim("(none)")
+	}
+	if err := writeKV(out, ui, "From", from); err != nil {
+		return err
+	}
+	if err := writeKV(out, ui, "To", result.ToHash); err != nil {
+		return err
+	}
+	if len(result.Ops) == 0 {
+		_, err := fmt.Fprintln(out, ui.dim("No changes"))
+		return err
+	}
+	for _, op := range result.Ops {
+		path := op.Path
+		if path == "" {
+			path = "(document)"
+		}
+		var line string
+		switch op.Op {
+		case "add":
+			line = ui.ok("+ "+path) + " " + string(op.Value)
+		case "remove":
+			line = ui.err("- "+path) + " " + string(op.Old)
+		default:
+			line = ui.warn("~ "+path) + " " + string(op.Old) + " " + ui.dim("->") + " " + string(op.Value)
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSyncResult(cmd *cobra.Command, result docapp.SyncResult, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
-		payload := getOutput{
-			Doc:    json.RawMessage(result.Payload),
-			TxHash: result.TxHash,
-			TxID:   result.TxID,
-			Op:     result.Op.String(),
+		payload := syncOutput{
+			Action:     string(result.Action),
+			Commit:     result.CommitHash,
+			LocalHead:  result.LocalHead,
+			RemoteHead: result.RemoteHead,
+		}
+		for _, merged := range result.Merged {
+			payload.Merged = append(payload.Merged, syncStreamOutput{
+				Collection: merged.Collection,
+				DocID:      merged.DocID,
+				TxHash:     merged.TxHash,
+				Conflicts:  merged.Conflicts,
+			})
 		}
 		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(payload)
 	}
 
+	ui := newRenderer(out, asJSON)
+	var summary string
+	switch result.Action {
+	case docapp.SyncActionNoRemote:
+		summary = "No origin remote configured"
+	case docapp.SyncActionUpToDate:
+		summary = "Already up to date"
+	case docapp.SyncActionFastForward:
+		summary = "Fast-forwarded to origin/main"
+	case docapp.SyncActionPush:
+		summary = "Pushed local commits to origin"
+	case docapp.SyncActionMerge:
+		summary = fmt.Sprintf("Merged origin/main (%d document(s))", len(result.Merged))
+	}
+	if _, err := fmt.Fprintln(out, ui.ok(summary)); err != nil {
+		return err
+	}
+	if result.CommitHash != "" {
+		if err := writeKV(out, ui, "Commit", result.CommitHash); err != nil {
+			return err
+		}
+	}
+	for _, merged := range result.Merged {
+		line := fmt.Sprintf("  %s/%s %s", merged.Collection, merged.DocID, ui.dim(merged.TxHash))
+		if len(merged.Conflicts) > 0 {
+			line += " " + ui.warn(fmt.Sprintf("conflicts: %s", strings.Join(merged.Conflicts, ", ")))
+		}
+		if _, err := fmt.Fprintln(out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newGetOutput(result docapp.GetResult) getOutput {
+	return getOutput{
+		Doc:    json.RawMessage(result.Payload),
+		TxHash: result.TxHash,
+		TxID:   result.TxID,
+		Op:     result.Op.String(),
+		Commit: result.CommitHash,
+	}
+}
+
+func writeGetResult(cmd *cobra.Command, result docapp.GetResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := newGetOutput(result)
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
 	if _, err := out.Write(result.Payload); err != nil {
 		return err
 	}
@@ -895,19 +1701,25 @@ func writeGetResult(cmd *cobra.Command, result docapp.GetResult, asJSON bool) er
 	return nil
 }
 
+func newLogOutput(entries []docapp.LogEntry) logOutput {
+	payload := logOutput{Entries: make([]logEntryOutput, 0, len(entries))}
+	for _, entry := range entries {
+		payload.Entries = append(payload.Entries, logEntryOutput{
+			TxHash:       entry.TxHash,
+			TxID:         entry.TxID,
+			ParentHash:   entry.ParentHash,
+			MergeParents: entry.MergeParents,
+			Timestamp:    entry.Timestamp,
+			Op:           entry.Op.String(),
+		})
+	}
+	return payload
+}
+
 func writeLogResult(cmd *cobra.Command, entries []docapp.LogEntry, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
-		payload := logOutput{Entries: make([]logEntryOutput, 0, len(entries))}
-		for _, entry := range entries {
-			payload.Entries = append(payload.Entries, logEntryOutput{
-				TxHash:     entry.TxHash,
-				TxID:       entry.TxID,
-				ParentHash: entry.ParentHash,
-				Timestamp:  entry.Timestamp,
-				Op:         entry.Op.String(),
-			})
-		}
+		payload := newLogOutput(entries)
 		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(payload)
@@ -923,19 +1735,122 @@ func writeLogResult(cmd *cobra.Command, entries []docapp.LogEntry, asJSON bool)
 	return nil
 }
 
-func writeIntegrityResult(cmd *cobra.Command, result integrityapp.VerifyResult, asJSON bool) error {
+func writeListResult(cmd *cobra.Command, result docapp.ListResult, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
-		payload := integrityOutput{
-			Streams: result.Streams,
-			Valid:   result.Valid,
-			Issues:  make([]integrityIssueOutput, 0, len(result.Issues)),
+		payload := listOutput{Entries: make([]listEntryOutput, 0, len(result.Entries)), NextCursor: result.NextCursor}
+		for _, entry := range result.Entries {
+			payload.Entries = append(payload.Entries, listEntryOutput{
+				DocID:   entry.DocID,
+				TxID:    entry.TxID,
+				Op:      entry.Op.String(),
+				Deleted: entry.Deleted,
+				Doc:     entry.Payload,
+			})
 		}
-		for _, issue := range result.Issues {
-			payload.Issues = append(payload.Issues, integrityIssueOutput{
-				StreamPath: issue.StreamPath,
-				Code:       issue.Code,
-				Message:    issue.Message,
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	for _, entry := range result.Entries {
+		id := entry.DocID
+		if entry.Deleted {
+			id += " " + ui.err("(deleted)")
+		}
+		if _, err := fmt.Fprintf(out, "%s %s\n", id, ui.dim(entry.TxID)); err != nil {
+			return err
+		}
+	}
+	if result.NextCursor != "" {
+		return writeKV(out, ui, "Next cursor", result.NextCursor)
+	}
+	return nil
+}
+
+func writeSearchResult(cmd *cobra.Command, hits []indexapp.SearchHit, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := searchOutput{Hits: make([]searchHitOutput, 0, len(hits))}
+		for _, hit := range hits {
+			payload.Hits = append(payload.Hits, searchHitOutput{DocID: hit.DocID, Score: hit.Score, Snippet: hit.Snippet})
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	ui := newRenderer(out, asJSON)
+	for _, hit := range hits {
+		if _, err := fmt.Fprintf(out, "%s %s %s\n", hit.DocID, ui.dim(fmt.Sprintf("%.3g", hit.Score)), hit.Snippet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeQueryResult(cmd *cobra.Command, result queryapp.Result, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := queryOutput{
+			Collection: result.Collection,
+			Field:      result.Field,
+			Value:      result.Value,
+			DocIDs:     result.DocIDs,
+		}
+		if payload.DocIDs == nil {
+			payload.DocIDs = []string{}
+		}
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
+	for _, docID := range result.DocIDs {
+		if _, err := fmt.Fprintln(out, docID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeChangeEvent prints one event per line; JSON output is NDJSON so the
+// stream can be consumed while it is still running.
+func writeChangeEvent(out io.Writer, ui renderer, event changesapp.Event, asJSON bool) error {
+	if asJSON {
+		return json.NewEncoder(out).Encode(changeEventOutput{
+			Cursor:        event.Cursor,
+			Commit:        event.Commit,
+			Collection:    event.Collection,
+			DocID:         event.DocID,
+			Op:            event.Op,
+			TxID:          event.TxID,
+			TxHash:        event.TxHash,
+			ParentHash:    event.ParentHash,
+			Timestamp:     event.Timestamp,
+			SchemaVersion: event.SchemaVersion,
+			Payload:       event.Payload,
+			Patch:         event.Patch,
+		})
+	}
+	_, err := fmt.Fprintf(out, "%s %s %s/%s %s\n", event.Commit, colorOp(ui, event.Op), event.Collection, event.DocID, event.Cursor)
+	return err
+}
+
+func writeCollectionLogResult(cmd *cobra.Command, entries []collectionapp.LogEntry, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := collectionLogOutput{Entries: make([]collectionLogEntryOutput, 0, len(entries))}
+		for _, entry := range entries {
+			payload.Entries = append(payload.Entries, collectionLogEntryOutput{
+				Commit:        entry.CommitHash,
+				Timestamp:     entry.Timestamp.UTC().Format(time.RFC3339),
+				SchemaVersion: entry.SchemaVersion,
+				Indexes:       entry.Indexes,
+				FullText:      entry.FullText,
+				TrustedKeys:   entry.TrustedKeys,
+				Removed:       entry.Removed,
 			})
 		}
 		encoder := json.NewEncoder(out)
@@ -943,6 +1858,61 @@ func writeIntegrityResult(cmd *cobra.Command, result integrityapp.VerifyResult,
 		return encoder.Encode(payload)
 	}
 
+	ui := newRenderer(out, asJSON)
+	for _, entry := range entries {
+		version := entry.SchemaVersion
+		if entry.Removed {
+			version = ui.warn("removed")
+		}
+		indexes := ui.dim("-")
+		if len(entry.Indexes) > 0 {
+			indexes = strings.Join(entry.Indexes, ",")
+		}
+		if len(entry.FullText) > 0 {
+			indexes += " " + ui.key("fulltext:") + strings.Join(entry.FullText, ",")
+		}
+		if len(entry.TrustedKeys) > 0 {
+			indexes += " " + ui.key("keys:") + strconv.Itoa(len(entry.TrustedKeys))
+		}
+		if _, err := fmt.Fprintf(out, "%s %s %s %s\n", ui.accent(entry.CommitHash), entry.Timestamp.UTC().Format(time.RFC3339), version, indexes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newIntegrityOutput(result integrityapp.VerifyResult) integrityOutput {
+	payload := integrityOutput{
+		Snapshot: result.Snapshot,
+		Streams:  result.Streams,
+		Valid:    result.Valid,
+		Resumed:  result.Resumed,
+		Commits:  result.Commits,
+		Anchors:  result.Anchors,
+		Issues:   make([]integrityIssueOutput, 0, len(result.Issues)),
+	}
+	for _, issue := range result.Issues {
+		payload.Issues = append(payload.Issues, integrityIssueOutput{
+			StreamPath: issue.StreamPath,
+			Commit:     issue.CommitHash,
+			Collection: issue.Collection,
+			DocID:      issue.DocID,
+			Code:       issue.Code,
+			Message:    issue.Message,
+		})
+	}
+	return payload
+}
+
+func writeIntegrityResult(cmd *cobra.Command, result integrityapp.VerifyResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		payload := newIntegrityOutput(result)
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(payload)
+	}
+
 	ui := newRenderer(out, asJSON)
 	if result.Streams > 0 {
 		ratio := float64(result.Valid) / float64(result.Streams)
@@ -951,6 +1921,24 @@ func writeIntegrityResult(cmd *cobra.Command, result integrityapp.VerifyResult,
 		}
 	}
 
+	if result.Resumed > 0 {
+		if _, err := fmt.Fprintf(out, "%s %d stream(s) from checkpoint\n", ui.key("Resumed"), result.Resumed); err != nil {
+			return err
+		}
+	}
+
+	if result.Commits > 0 {
+		if _, err := fmt.Fprintf(out, "%s %d commit(s) checked\n", ui.key("Signatures"), result.Commits); err != nil {
+			return err
+		}
+	}
+
+	if result.Anchors > 0 {
+		if _, err := fmt.Fprintf(out, "%s %d anchor(s) checked\n", ui.key("Anchors"), result.Anchors); err != nil {
+			return err
+		}
+	}
+
 	if len(result.Issues) == 0 {
 		_, err := fmt.Fprintf(out, "%s: %d stream(s) verified\n", ui.ok("OK"), result.Streams)
 		return err
@@ -964,7 +1952,20 @@ func writeIntegrityResult(cmd *cobra.Command, result integrityapp.VerifyResult,
 		if ui.color {
 			code = ui.err(code)
 		}
-		if _, err := fmt.Fprintf(out, "- %s [%s] %s\n", issue.StreamPath, code, issue.Message); err != nil {
+		subject := issue.StreamPath
+		if issue.CommitHash != "" {
+			subject = "commit " + issue.CommitHash
+		}
+		switch {
+		case issue.DocID != "":
+			subject = fmt.Sprintf("%s (%s/%s)", subject, issue.Collection, issue.DocID)
+		case issue.Collection != "":
+			subject = fmt.Sprintf("%s (%s)", subject, issue.Collection)
+		}
+		if subject != "" {
+			subject += " "
+		}
+		if _, err := fmt.Fprintf(out, "- %s[%s] %s\n", subject, code, issue.Message); err != nil {
 			return err
 		}
 	}
@@ -1051,6 +2052,18 @@ func writeGCResult(cmd *cobra.Command, prune string, asJSON bool) error {
 	return err
 }
 
+func writeKeyResult(cmd *cobra.Command, path, publicKey string, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(keyOutput{Path: path, PublicKey: publicKey})
+	}
+	ui := newRenderer(out, asJSON)
+	_, err := fmt.Fprintf(out, "%s %s\n%s %s\n", ui.ok("Key written"), path, ui.key("public key:"), publicKey)
+	return err
+}
+
 func writeInspectResult(cmd *cobra.Command, result inspectapp.BlobResult, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
@@ -1117,19 +2130,26 @@ func writeInspectResult(cmd *cobra.Command, result inspectapp.BlobResult, asJSON
 	return nil
 }
 
+func newIndexSyncOutput(result indexapp.SyncResult) indexSyncOutput {
+	return indexSyncOutput{
+		Reset:           result.Reset,
+		Fetched:         result.Fetched,
+		Commits:         result.Commits,
+		TxsApplied:      result.TxsApplied,
+		DocsUpserted:    result.DocsUpserted,
+		DocsDeleted:     result.DocsDeleted,
+		Collections:     result.Collections,
+		LastCommit:      result.LastCommit,
+		IndexesCreated:  result.IndexesCreated,
+		IndexesDropped:  result.IndexesDropped,
+		FullTextRebuilt: result.FullTextRebuilt,
+	}
+}
+
 func writeIndexSyncResult(cmd *cobra.Command, result indexapp.SyncResult, asJSON bool) error {
 	out := cmd.OutOrStdout()
 	if asJSON {
-		payload := indexSyncOutput{
-			Reset:        result.Reset,
-			Fetched:      result.Fetched,
-			Commits:      result.Commits,
-			TxsApplied:   result.TxsApplied,
-			DocsUpserted: result.DocsUpserted,
-			DocsDeleted:  result.DocsDeleted,
-			Collections:  result.Collections,
-			LastCommit:   result.LastCommit,
-		}
+		payload := newIndexSyncOutput(result)
 		encoder := json.NewEncoder(out)
 		encoder.SetIndent("", "  ")
 		return encoder.Encode(payload)
@@ -1166,6 +2186,16 @@ func writeIndexSyncResult(cmd *cobra.Command, result indexapp.SyncResult, asJSON
 	if err := writeKV(out, ui, "Collections", fmt.Sprintf("%d", result.Collections)); err != nil {
 		return err
 	}
+	if result.IndexesCreated > 0 || result.IndexesDropped > 0 {
+		if err := writeKV(out, ui, "Indexes", fmt.Sprintf("+%d -%d", result.IndexesCreated, result.IndexesDropped)); err != nil {
+			return err
+		}
+	}
+	if result.FullTextRebuilt > 0 {
+		if err := writeKV(out, ui, "Full-Text Rebuilt", fmt.Sprintf("%d", result.FullTextRebuilt)); err != nil {
+			return err
+		}
+	}
 	if result.LastCommit == "" {
 		if err := writeKV(out, ui, "Last Commit", ui.dim("(none)")); err != nil {
 			return err
@@ -1175,8 +2205,51 @@ func writeIndexSyncResult(cmd *cobra.Command, result indexapp.SyncResult, asJSON
 	return writeKV(out, ui, "Last Commit", result.LastCommit)
 }
 
+func writeHookDispatchResult(cmd *cobra.Command, result hooksapp.DispatchResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(hookDispatchOutput{
+			Events:    result.Events,
+			Delivered: result.Delivered,
+			Cursor:    result.Cursor,
+		})
+	}
+
+	ui := newRenderer(out, asJSON)
+	if err := writeKV(out, ui, "Hooks Delivered", fmt.Sprintf("%d of %d event(s)", result.Delivered, result.Events)); err != nil {
+		return err
+	}
+	return writeKV(out, ui, "Hook Cursor", result.Cursor)
+}
+
 func hasIndexChanges(result indexapp.SyncResult) bool {
-	return result.Commits > 0 || result.TxsApplied > 0 || result.DocsUpserted > 0 || result.DocsDeleted > 0
+	return result.Commits > 0 || result.TxsApplied > 0 || result.DocsUpserted > 0 || result.DocsDeleted > 0 ||
+		result.IndexesCreated > 0 || result.IndexesDropped > 0 || result.FullTextRebuilt > 0
+}
+
+// openIndexSink keeps --db as shorthand for a SQLite sink and otherwise
+// resolves --sink through the sink registry.
+func openIndexSink(dbPath, sink string, fast bool) (indexsink.Sink, error) {
+	switch {
+	case dbPath != "" && sink != "":
+		return nil, indexapp.ErrSinkConflict
+	case sink != "":
+		return indexsink.Open(sink, indexsink.Options{Fast: fast})
+	case dbPath != "":
+		return indexsink.OpenSQLite(dbPath, indexsink.Options{Fast: fast})
+	default:
+		return nil, indexapp.ErrSinkRequired
+	}
+}
+
+// txEncoder signs transactions with the --tx-key key when one is set.
+func txEncoder(opts *RootOptions) docapp.Encoder {
+	if opts.txKey != nil {
+		return txv3.SigningEncoder{Key: opts.txKey}
+	}
+	return txv3.Encoder{}
 }
 
 func newGitStore(opts *RootOptions) *gitrepo.Store {
@@ -1184,6 +2257,7 @@ func newGitStore(opts *RootOptions) *gitrepo.Store {
 		SignCommits: opts.SignCommits,
 		SignKey:     opts.SignKey,
 		HistoryMode: opts.HistoryMode,
+		TxDecoder:   txv3.Decoder{},
 	})
 }
 
@@ -1254,6 +2328,11 @@ func parseCommaList(value string) []string {
 	return parts
 }
 
+func addWritePreconditionFlags(cmd *cobra.Command, writeOpts *docapp.WriteOptions) {
+	cmd.Flags().StringVar(&writeOpts.IfMatch, "if-match", "", "Only write if the stream head equals this tx hash")
+	cmd.Flags().BoolVar(&writeOpts.IfNoneMatch, "if-none-match", false, "Only write if the document stream does not exist yet")
+}
+
 func readJSONInput(label, inline, filePath string) ([]byte, error) {
 	inline = strings.TrimSpace(inline)
 	filePath = strings.TrimSpace(filePath)
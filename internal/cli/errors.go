@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"io"
 
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
 	collectionapp "github.com/osvaldoandrade/ledgerdb/internal/app/collection"
 	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
 	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
 	inspectapp "github.com/osvaldoandrade/ledgerdb/internal/app/inspect"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
 	maintenanceapp "github.com/osvaldoandrade/ledgerdb/internal/app/maintenance"
 	"github.com/osvaldoandrade/ledgerdb/internal/app/paths"
+	queryapp "github.com/osvaldoandrade/ledgerdb/internal/app/query"
 	repoapp "github.com/osvaldoandrade/ledgerdb/internal/app/repo"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txkey"
 )
 
 type ErrorKind string
@@ -59,11 +64,18 @@ func NormalizeError(err error) ExitError {
 	case errors.Is(err, docapp.ErrDocNotFound),
 		errors.Is(err, docapp.ErrDocDeleted),
 		errors.Is(err, docapp.ErrTxNotFound),
-		errors.Is(err, inspectapp.ErrBlobNotFound):
+		errors.Is(err, docapp.ErrRefNotFound),
+		errors.Is(err, collectionapp.ErrCollectionNotFound),
+		errors.Is(err, inspectapp.ErrBlobNotFound),
+		errors.Is(err, integrityapp.ErrAnchorsNotFound):
 		return ExitError{Code: ExitNotFound, Kind: KindNotFound, Err: err}
 	case errors.Is(err, domain.ErrHeadChanged),
+		errors.Is(err, docapp.ErrPatchConflict),
+		errors.Is(err, docapp.ErrMergeDeleted),
 		errors.Is(err, domain.ErrSyncConflict),
+		errors.Is(err, docapp.ErrSyncPathConflict),
 		errors.Is(err, indexapp.ErrCommitNotFound),
+		errors.Is(err, changesapp.ErrCursorNotFound),
 		errors.Is(err, indexapp.ErrMissingDocument):
 		return ExitError{Code: ExitConflict, Kind: KindConflict, Err: err}
 	case errors.Is(err, paths.ErrRepoPathRequired),
@@ -73,20 +85,44 @@ func NormalizeError(err error) ExitError {
 		errors.Is(err, collectionapp.ErrSchemaPathRequired),
 		errors.Is(err, collectionapp.ErrInvalidCollectionName),
 		errors.Is(err, collectionapp.ErrSchemaInvalidJSON),
+		errors.Is(err, collectionapp.ErrTrustedKeysConflict),
 		errors.Is(err, docapp.ErrCollectionRequired),
 		errors.Is(err, docapp.ErrInvalidCollection),
 		errors.Is(err, docapp.ErrDocIDRequired),
 		errors.Is(err, docapp.ErrPayloadRequired),
 		errors.Is(err, docapp.ErrTxReferenceRequired),
 		errors.Is(err, docapp.ErrTxReferenceAmbiguous),
+		errors.Is(err, docapp.ErrPreconditionAmbiguous),
+		errors.Is(err, docapp.ErrReadPointAmbiguous),
+		errors.Is(err, errInvalidAsOf),
+		errors.Is(err, errInvalidRequestBody),
+		errors.Is(err, docapp.ErrMergeRefRequired),
+		errors.Is(err, docapp.ErrMergeRequiresHistory),
+		errors.Is(err, docapp.ErrBatchEmpty),
+		errors.Is(err, docapp.ErrInvalidLimit),
+		errors.Is(err, queryapp.ErrCollectionRequired),
+		errors.Is(err, queryapp.ErrInvalidCollectionName),
+		errors.Is(err, queryapp.ErrInvalidWhere),
+		errors.Is(err, queryapp.ErrFieldNotIndexed),
+		errors.Is(err, errInvalidBatch),
 		errors.Is(err, inspectapp.ErrHashRequired),
 		errors.Is(err, inspectapp.ErrInvalidHash),
 		errors.Is(err, maintenanceapp.ErrInvalidThreshold),
 		errors.Is(err, maintenanceapp.ErrInvalidMax),
-		errors.Is(err, indexapp.ErrMergeCommitUnsupported),
+		errors.Is(err, changesapp.ErrInvalidCursor),
+		errors.Is(err, changesapp.ErrInvalidInterval),
+		errors.Is(err, hooksapp.ErrInvalidHook),
 		errors.Is(err, indexapp.ErrPatchUnsupported),
 		errors.Is(err, indexapp.ErrInvalidInterval),
 		errors.Is(err, indexapp.ErrInvalidJitter),
+		errors.Is(err, indexapp.ErrCollectionRequired),
+		errors.Is(err, indexapp.ErrSearchQueryRequired),
+		errors.Is(err, indexapp.ErrInvalidSearchLimit),
+		errors.Is(err, indexapp.ErrInvalidSearchQuery),
+		errors.Is(err, indexapp.ErrFullTextNotEnabled),
+		errors.Is(err, indexapp.ErrSinkRequired),
+		errors.Is(err, indexapp.ErrSinkConflict),
+		errors.Is(err, indexapp.ErrUnsupportedSink),
 		errors.Is(err, domain.ErrTxIDRequired),
 		errors.Is(err, domain.ErrTimestampRequired),
 		errors.Is(err, domain.ErrCollectionRequired),
@@ -94,7 +130,20 @@ func NormalizeError(err error) ExitError {
 		errors.Is(err, domain.ErrInvalidOp),
 		errors.Is(err, domain.ErrMissingPayload),
 		errors.Is(err, domain.ErrUnexpectedPayload),
-		errors.Is(err, domain.ErrMultiplePayloads):
+		errors.Is(err, domain.ErrMultiplePayloads),
+		errors.Is(err, domain.ErrUnexpectedParents),
+		errors.Is(err, domain.ErrSchemaViolation),
+		errors.Is(err, domain.ErrInvalidPublicKey),
+		errors.Is(err, domain.ErrUnsignedTx),
+		errors.Is(err, domain.ErrUntrustedSigner),
+		errors.Is(err, txkey.ErrInvalidKeyFile),
+		errors.Is(err, integrityapp.ErrKeyringNotFound),
+		errors.Is(err, integrityapp.ErrSignaturesUnavailable),
+		errors.Is(err, integrityapp.ErrInvalidWorkers),
+		errors.Is(err, integrityapp.ErrInvalidProof),
+		errors.Is(err, integrityapp.ErrAnchorBackendRequired),
+		errors.Is(err, integrityapp.ErrAnchorsUnavailable),
+		errors.Is(err, integrityapp.ErrInvalidAnchorInterval):
 		return ExitError{Code: ExitInvalid, Kind: KindValidation, Err: err}
 	default:
 		return ExitError{Code: ExitInternal, Kind: KindInternal, Err: err}
@@ -113,15 +162,18 @@ func writeCLIError(w io.Writer, exitErr ExitError, asJSON bool) error {
 		return nil
 	}
 	message := errorMessage(exitErr)
+	violations := schemaViolations(exitErr.Err)
 	if asJSON {
 		payload := struct {
-			Code    int    `json:"code"`
-			Kind    string `json:"kind"`
-			Message string `json:"message"`
+			Code       int                     `json:"code"`
+			Kind       string                  `json:"kind"`
+			Message    string                  `json:"message"`
+			Violations []schemaViolationOutput `json:"violations,omitempty"`
 		}{
-			Code:    exitErr.Code,
-			Kind:    string(exitErr.Kind),
-			Message: message,
+			Code:       exitErr.Code,
+			Kind:       string(exitErr.Kind),
+			Message:    message,
+			Violations: violations,
 		}
 		encoder := json.NewEncoder(w)
 		encoder.SetIndent("", "  ")
@@ -134,8 +186,36 @@ func writeCLIError(w io.Writer, exitErr ExitError, asJSON bool) error {
 		prefix = fmt.Sprintf("Error (%s)", exitErr.Kind)
 	}
 	prefix = ui.err(prefix)
-	_, err := fmt.Fprintf(w, "%s: %s\n", prefix, message)
-	return err
+	if _, err := fmt.Fprintf(w, "%s: %s\n", prefix, message); err != nil {
+		return err
+	}
+	for _, violation := range violations {
+		if _, err := fmt.Fprintf(w, "  %s %s\n", ui.key(violation.Pointer), violation.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type schemaViolationOutput struct {
+	Pointer string `json:"pointer"`
+	Reason  string `json:"reason"`
+}
+
+func schemaViolations(err error) []schemaViolationOutput {
+	var violationErr *domain.SchemaViolationError
+	if err == nil || !errors.As(err, &violationErr) {
+		return nil
+	}
+	out := make([]schemaViolationOutput, 0, len(violationErr.Violations))
+	for _, violation := range violationErr.Violations {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		out = append(out, schemaViolationOutput{Pointer: pointer, Reason: violation.Reason})
+	}
+	return out
 }
 
 func errorMessage(exitErr ExitError) string {
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -21,16 +23,17 @@ func TestNormalizeError(t *testing.T) {
 		{err: docapp.ErrTxNotFound, wantCode: ExitNotFound, wantKind: KindNotFound},
 		{err: domain.ErrHeadChanged, wantCode: ExitConflict, wantKind: KindConflict},
 		{err: domain.ErrSyncConflict, wantCode: ExitConflict, wantKind: KindConflict},
+		{err: docapp.ErrSyncPathConflict, wantCode: ExitConflict, wantKind: KindConflict},
 		{err: indexapp.ErrCommitNotFound, wantCode: ExitConflict, wantKind: KindConflict},
 		{err: indexapp.ErrMissingDocument, wantCode: ExitConflict, wantKind: KindConflict},
 		{err: paths.ErrRepoPathRequired, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: maintenanceapp.ErrInvalidThreshold, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: maintenanceapp.ErrInvalidMax, wantCode: ExitInvalid, wantKind: KindValidation},
-		{err: indexapp.ErrMergeCommitUnsupported, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: indexapp.ErrPatchUnsupported, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: indexapp.ErrInvalidInterval, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: indexapp.ErrInvalidJitter, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: docapp.ErrTxReferenceRequired, wantCode: ExitInvalid, wantKind: KindValidation},
+		{err: &domain.SchemaViolationError{Collection: "users"}, wantCode: ExitInvalid, wantKind: KindValidation},
 		{err: errors.New("boom"), wantCode: ExitInternal, wantKind: KindInternal},
 	}
 
@@ -55,3 +58,32 @@ func TestExitCode(t *testing.T) {
 		t.Fatalf("expected ExitCode(custom) == 9")
 	}
 }
+
+func TestWriteCLIErrorIncludesSchemaViolations(t *testing.T) {
+	err := &domain.SchemaViolationError{
+		Collection: "users",
+		Violations: []domain.SchemaViolation{{Pointer: "/email", Reason: "missing"}},
+	}
+
+	var buf bytes.Buffer
+	if writeErr := writeCLIError(&buf, NormalizeError(err), true); writeErr != nil {
+		t.Fatalf("writeCLIError returned error: %v", writeErr)
+	}
+
+	var payload struct {
+		Kind       string `json:"kind"`
+		Violations []struct {
+			Pointer string `json:"pointer"`
+			Reason  string `json:"reason"`
+		} `json:"violations"`
+	}
+	if decodeErr := json.Unmarshal(buf.Bytes(), &payload); decodeErr != nil {
+		t.Fatalf("decode error output: %v", decodeErr)
+	}
+	if payload.Kind != string(KindValidation) {
+		t.Fatalf("expected kind %q, got %q", KindValidation, payload.Kind)
+	}
+	if len(payload.Violations) != 1 || payload.Violations[0].Pointer != "/email" {
+		t.Fatalf("unexpected violations: %+v", payload.Violations)
+	}
+}
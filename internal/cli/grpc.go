@@ -0,0 +1,304 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+	collectionapp "github.com/osvaldoandrade/ledgerdb/internal/app/collection"
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
+	"github.com/osvaldoandrade/ledgerdb/pkg/ledgerdbpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcLedger serves ledgerdbpb.Ledger on top of the HTTP server's services,
+// so gRPC writes share the same single writer queue.
+type grpcLedger struct {
+	ledgerdbpb.UnimplementedLedgerServer
+	srv *server
+}
+
+func newGRPCServer(srv *server) *grpc.Server {
+	grpcServer := grpc.NewServer(grpc.MaxRecvMsgSize(maxRequestBody))
+	ledgerdbpb.RegisterLedgerServer(grpcServer, &grpcLedger{srv: srv})
+	return grpcServer
+}
+
+func (g *grpcLedger) GetDocument(ctx context.Context, req *ledgerdbpb.GetDocumentRequest) (*ledgerdbpb.Document, error) {
+	readOpts := docapp.ReadOptions{
+		AtTx:     req.GetAtTx(),
+		AtCommit: req.GetAtCommit(),
+	}
+	if asOf := req.GetAsOf(); asOf != "" {
+		parsed, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			return nil, grpcError(fmt.Errorf("%w: %v", errInvalidAsOf, err))
+		}
+		readOpts.AsOf = parsed
+	}
+	result, err := g.srv.get.GetAt(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId(), readOpts)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &ledgerdbpb.Document{
+		Payload: result.Payload,
+		TxHash:  result.TxHash,
+		TxId:    result.TxID,
+		Op:      result.Op.String(),
+		Commit:  result.CommitHash,
+	}, nil
+}
+
+func (g *grpcLedger) PutDocument(ctx context.Context, req *ledgerdbpb.PutDocumentRequest) (*ledgerdbpb.WriteResult, error) {
+	return g.writeDocument(ctx, func(ctx context.Context) (docapp.PutResult, error) {
+		return g.srv.put.Put(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId(), req.GetPayload(), grpcWriteOptions(req.GetPreconditions()))
+	})
+}
+
+func (g *grpcLedger) PatchDocument(ctx context.Context, req *ledgerdbpb.PatchDocumentRequest) (*ledgerdbpb.WriteResult, error) {
+	return g.writeDocument(ctx, func(ctx context.Context) (docapp.PutResult, error) {
+		return g.srv.patch.Patch(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId(), req.GetPatch(), grpcWriteOptions(req.GetPreconditions()))
+	})
+}
+
+func (g *grpcLedger) DeleteDocument(ctx context.Context, req *ledgerdbpb.DeleteDocumentRequest) (*ledgerdbpb.WriteResult, error) {
+	return g.writeDocument(ctx, func(ctx context.Context) (docapp.PutResult, error) {
+		return g.srv.del.Delete(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId(), grpcWriteOptions(req.GetPreconditions()))
+	})
+}
+
+func (g *grpcLedger) RevertDocument(ctx context.Context, req *ledgerdbpb.RevertDocumentRequest) (*ledgerdbpb.WriteResult, error) {
+	return g.writeDocument(ctx, func(ctx context.Context) (docapp.PutResult, error) {
+		return g.srv.revert.Revert(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId(), docapp.RevertOptions{
+			TxID:   req.GetTxId(),
+			TxHash: req.GetTxHash(),
+		})
+	})
+}
+
+func (g *grpcLedger) writeDocument(ctx context.Context, fn func(ctx context.Context) (docapp.PutResult, error)) (*ledgerdbpb.WriteResult, error) {
+	var result docapp.PutResult
+	err := g.srv.write(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &ledgerdbpb.WriteResult{
+		Commit: result.CommitHash,
+		TxHash: result.TxHash,
+		TxId:   result.TxID,
+	}, nil
+}
+
+func (g *grpcLedger) ListDocuments(ctx context.Context, req *ledgerdbpb.ListDocumentsRequest) (*ledgerdbpb.ListDocumentsResponse, error) {
+	result, err := g.srv.list.List(ctx, g.srv.opts.RepoPath, req.GetCollection(), docapp.ListOptions{
+		Prefix:         req.GetPrefix(),
+		After:          req.GetCursor(),
+		Limit:          int(req.GetLimit()),
+		IncludeDeleted: req.GetIncludeDeleted(),
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &ledgerdbpb.ListDocumentsResponse{
+		Entries:    make([]*ledgerdbpb.ListEntry, 0, len(result.Entries)),
+		NextCursor: result.NextCursor,
+	}
+	for _, entry := range result.Entries {
+		resp.Entries = append(resp.Entries, &ledgerdbpb.ListEntry{
+			DocId:   entry.DocID,
+			TxId:    entry.TxID,
+			Op:      entry.Op.String(),
+			Deleted: entry.Deleted,
+			Payload: entry.Payload,
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcLedger) DocumentLog(ctx context.Context, req *ledgerdbpb.DocumentLogRequest) (*ledgerdbpb.DocumentLogResponse, error) {
+	entries, err := g.srv.log.Log(ctx, g.srv.opts.RepoPath, req.GetCollection(), req.GetDocId())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &ledgerdbpb.DocumentLogResponse{Entries: make([]*ledgerdbpb.LogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &ledgerdbpb.LogEntry{
+			TxHash:       entry.TxHash,
+			TxId:         entry.TxID,
+			ParentHash:   entry.ParentHash,
+			MergeParents: entry.MergeParents,
+			Timestamp:    entry.Timestamp,
+			Op:           entry.Op.String(),
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcLedger) ApplyBatch(ctx context.Context, req *ledgerdbpb.ApplyBatchRequest) (*ledgerdbpb.ApplyBatchResponse, error) {
+	ops := make([]docapp.BatchOp, 0, len(req.GetOps()))
+	for i, in := range req.GetOps() {
+		op := docapp.BatchOp{
+			Collection: in.GetCollection(),
+			DocID:      in.GetDocId(),
+		}
+		switch strings.ToLower(strings.TrimSpace(in.GetOp())) {
+		case "put":
+			op.Op = domain.TxOpPut
+			op.Payload = in.GetPayload()
+		case "patch":
+			op.Op = domain.TxOpPatch
+			op.Payload = in.GetPayload()
+		case "delete":
+			op.Op = domain.TxOpDelete
+		default:
+			return nil, grpcError(fmt.Errorf("%w: op %d: unknown op %q (expected put, patch or delete)", errInvalidBatch, i+1, in.GetOp()))
+		}
+		ops = append(ops, op)
+	}
+
+	var result docapp.BatchResult
+	err := g.srv.write(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = g.srv.batch.Apply(ctx, g.srv.opts.RepoPath, ops)
+		return err
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &ledgerdbpb.ApplyBatchResponse{
+		Commit: result.CommitHash,
+		Txs:    make([]*ledgerdbpb.BatchTx, 0, len(result.Txs)),
+	}
+	for i, tx := range result.Txs {
+		resp.Txs = append(resp.Txs, &ledgerdbpb.BatchTx{
+			Op:         ops[i].Op.String(),
+			Collection: ops[i].Collection,
+			DocId:      ops[i].DocID,
+			TxHash:     tx.TxHash,
+			TxId:       tx.TxID,
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcLedger) ApplyCollection(ctx context.Context, req *ledgerdbpb.ApplyCollectionRequest) (*ledgerdbpb.ApplyCollectionResponse, error) {
+	trustedKeys := req.GetTrustedKeys()
+	if len(trustedKeys) == 0 {
+		trustedKeys = nil
+	}
+	if req.GetClearTrustedKeys() {
+		if trustedKeys != nil {
+			return nil, grpcError(collectionapp.ErrTrustedKeysConflict)
+		}
+		trustedKeys = []string{}
+	}
+	service := collectionapp.NewService(g.srv.store, requestSchemaSource(req.GetSchema()), schema.JSONSchemaValidator{})
+	err := g.srv.write(ctx, func(ctx context.Context) error {
+		return service.Apply(ctx, g.srv.opts.RepoPath, req.GetCollection(), requestSchemaPath, req.GetIndexes(), req.GetFulltext(), trustedKeys)
+	})
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return &ledgerdbpb.ApplyCollectionResponse{}, nil
+}
+
+func (g *grpcLedger) CollectionLog(ctx context.Context, req *ledgerdbpb.CollectionLogRequest) (*ledgerdbpb.CollectionLogResponse, error) {
+	entries, err := g.srv.colLog.Log(ctx, g.srv.opts.RepoPath, req.GetCollection())
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &ledgerdbpb.CollectionLogResponse{Entries: make([]*ledgerdbpb.CollectionLogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &ledgerdbpb.CollectionLogEntry{
+			Commit:        entry.CommitHash,
+			Timestamp:     entry.Timestamp.UTC().Format(time.RFC3339),
+			SchemaVersion: entry.SchemaVersion,
+			Indexes:       entry.Indexes,
+			Fulltext:      entry.FullText,
+			Removed:       entry.Removed,
+			TrustedKeys:   entry.TrustedKeys,
+		})
+	}
+	return resp, nil
+}
+
+func (g *grpcLedger) Watch(req *ledgerdbpb.WatchRequest, stream grpc.ServerStreamingServer[ledgerdbpb.ChangeEvent]) error {
+	collections := make(map[string]bool, len(req.GetCollections()))
+	for _, collection := range req.GetCollections() {
+		collections[collection] = true
+	}
+	opts := changesapp.FollowOptions{
+		Since:    req.GetCursor(),
+		Interval: g.srv.cfg.WatchInterval,
+	}
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	defer context.AfterFunc(g.srv.watching, cancel)()
+	err := g.srv.changes.Follow(ctx, g.srv.opts.RepoPath, opts, func(event changesapp.Event) error {
+		if len(collections) > 0 && !collections[event.Collection] {
+			return nil
+		}
+		return stream.Send(&ledgerdbpb.ChangeEvent{
+			Cursor:        event.Cursor,
+			Commit:        event.Commit,
+			Collection:    event.Collection,
+			DocId:         event.DocID,
+			Op:            event.Op,
+			TxId:          event.TxID,
+			TxHash:        event.TxHash,
+			ParentHash:    event.ParentHash,
+			Timestamp:     event.Timestamp,
+			SchemaVersion: event.SchemaVersion,
+			Payload:       event.Payload,
+			Patch:         event.Patch,
+		})
+	})
+	if err != nil {
+		return grpcError(err)
+	}
+	return nil
+}
+
+func grpcWriteOptions(pre *ledgerdbpb.Preconditions) docapp.WriteOptions {
+	return docapp.WriteOptions{
+		IfMatch:     strings.TrimSpace(pre.GetIfMatch()),
+		IfNoneMatch: pre.GetIfNoneMatch(),
+	}
+}
+
+// grpcError maps an error onto a gRPC status using the same kinds as the
+// CLI exit codes.
+func grpcError(err error) error {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	exitErr := NormalizeError(err)
+	return status.Error(grpcCode(exitErr.Kind), errorMessage(exitErr))
+}
+
+func grpcCode(kind ErrorKind) codes.Code {
+	switch kind {
+	case KindValidation:
+		return codes.InvalidArgument
+	case KindNotFound:
+		return codes.NotFound
+	case KindConflict:
+		return codes.Aborted
+	default:
+		return codes.Internal
+	}
+}
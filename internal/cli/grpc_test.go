@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/pkg/ledgerdbpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestGRPCClient(t *testing.T) ledgerdbpb.LedgerClient {
+	t.Helper()
+	_, srv := newTestBackend(t, serverConfig{WatchInterval: 10 * time.Millisecond})
+	client, _ := dialTestGRPC(t, srv)
+	return client
+}
+
+func dialTestGRPC(t *testing.T, srv *server) (ledgerdbpb.LedgerClient, *grpc.Server) {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := newGRPCServer(srv)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = conn.Close()
+		grpcServer.Stop()
+	})
+	return ledgerdbpb.NewLedgerClient(conn), grpcServer
+}
+
+func TestGRPCDocumentLifecycle(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx := context.Background()
+
+	put, err := client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{Collection: "tasks", DocId: "t1", Payload: []byte(`{"status":"open"}`)})
+	if err != nil || put.GetTxHash() == "" {
+		t.Fatalf("put: %+v, %v", put, err)
+	}
+	_, err = client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{
+		Collection:    "tasks",
+		DocId:         "t1",
+		Payload:       []byte(`{}`),
+		Preconditions: &ledgerdbpb.Preconditions{IfNoneMatch: true},
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted for a failed precondition, got %v", err)
+	}
+
+	_, err = client.PatchDocument(ctx, &ledgerdbpb.PatchDocumentRequest{
+		Collection:    "tasks",
+		DocId:         "t1",
+		Patch:         []byte(`[{"op":"replace","path":"/status","value":"done"}]`),
+		Preconditions: &ledgerdbpb.Preconditions{IfMatch: put.GetTxHash()},
+	})
+	if err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	doc, err := client.GetDocument(ctx, &ledgerdbpb.GetDocumentRequest{Collection: "tasks", DocId: "t1"})
+	if err != nil || string(doc.GetPayload()) != `{"status":"done"}` {
+		t.Fatalf("get: %+v, %v", doc, err)
+	}
+	old, err := client.GetDocument(ctx, &ledgerdbpb.GetDocumentRequest{Collection: "tasks", DocId: "t1", AtTx: put.GetTxId()})
+	if err != nil || string(old.GetPayload()) != `{"status":"open"}` {
+		t.Fatalf("get at tx: %+v, %v", old, err)
+	}
+
+	if _, err := client.RevertDocument(ctx, &ledgerdbpb.RevertDocumentRequest{Collection: "tasks", DocId: "t1", TxHash: put.GetTxHash()}); err != nil {
+		t.Fatalf("revert: %v", err)
+	}
+	log, err := client.DocumentLog(ctx, &ledgerdbpb.DocumentLogRequest{Collection: "tasks", DocId: "t1"})
+	if err != nil || len(log.GetEntries()) != 3 {
+		t.Fatalf("log: %+v, %v", log, err)
+	}
+
+	if _, err := client.DeleteDocument(ctx, &ledgerdbpb.DeleteDocumentRequest{Collection: "tasks", DocId: "t1"}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := client.GetDocument(ctx, &ledgerdbpb.GetDocumentRequest{Collection: "tasks", DocId: "t1"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound after delete, got %v", err)
+	}
+	if _, err := client.GetDocument(ctx, &ledgerdbpb.GetDocumentRequest{Collection: "tasks", DocId: "t1", AsOf: "yesterday"}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a bad as_of, got %v", err)
+	}
+}
+
+func TestGRPCBatchListAndCollections(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx := context.Background()
+
+	_, err := client.ApplyCollection(ctx, &ledgerdbpb.ApplyCollectionRequest{
+		Collection: "tasks",
+		Schema:     []byte(`{"type":"object","required":["status"]}`),
+		Indexes:    []string{"status"},
+	})
+	if err != nil {
+		t.Fatalf("apply collection: %v", err)
+	}
+	colLog, err := client.CollectionLog(ctx, &ledgerdbpb.CollectionLogRequest{Collection: "tasks"})
+	if err != nil || len(colLog.GetEntries()) != 1 || colLog.GetEntries()[0].GetIndexes()[0] != "status" {
+		t.Fatalf("collection log: %+v, %v", colLog, err)
+	}
+	if _, err := client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{Collection: "tasks", DocId: "bad", Payload: []byte(`{}`)}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a schema violation, got %v", err)
+	}
+	_, err = client.ApplyCollection(ctx, &ledgerdbpb.ApplyCollectionRequest{
+		Collection:       "tasks",
+		Schema:           []byte(`{}`),
+		TrustedKeys:      []string{strings.Repeat("ab", 32)},
+		ClearTrustedKeys: true,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for setting and clearing keys at once, got %v", err)
+	}
+
+	batch, err := client.ApplyBatch(ctx, &ledgerdbpb.ApplyBatchRequest{Ops: []*ledgerdbpb.BatchOp{
+		{Op: "put", Collection: "tasks", DocId: "a", Payload: []byte(`{"status":"open"}`)},
+		{Op: "put", Collection: "tasks", DocId: "b", Payload: []byte(`{"status":"open"}`)},
+		{Op: "patch", Collection: "tasks", DocId: "a", Payload: []byte(`[{"op":"replace","path":"/status","value":"done"}]`)},
+	}})
+	if err != nil || batch.GetCommit() == "" || len(batch.GetTxs()) != 3 || batch.GetTxs()[2].GetDocId() != "a" {
+		t.Fatalf("batch: %+v, %v", batch, err)
+	}
+	if _, err := client.ApplyBatch(ctx, &ledgerdbpb.ApplyBatchRequest{Ops: []*ledgerdbpb.BatchOp{{Op: "upsert", Collection: "tasks", DocId: "a"}}}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown op, got %v", err)
+	}
+
+	list, err := client.ListDocuments(ctx, &ledgerdbpb.ListDocumentsRequest{Collection: "tasks", Limit: 1})
+	if err != nil || len(list.GetEntries()) != 1 || list.GetNextCursor() == "" {
+		t.Fatalf("list: %+v, %v", list, err)
+	}
+	next, err := client.ListDocuments(ctx, &ledgerdbpb.ListDocumentsRequest{Collection: "tasks", Cursor: list.GetNextCursor()})
+	if err != nil || len(next.GetEntries()) != 1 || next.GetEntries()[0].GetDocId() == list.GetEntries()[0].GetDocId() {
+		t.Fatalf("list next page: %+v, %v", next, err)
+	}
+}
+
+func TestGRPCWatchPushesNewCommits(t *testing.T) {
+	client := newTestGRPCClient(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{Collection: "users", DocId: "u1", Payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	if _, err := client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{Collection: "tasks", DocId: "t1", Payload: []byte(`{"n":1}`)}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+
+	stream, err := client.Watch(ctx, &ledgerdbpb.WatchRequest{Collections: []string{"tasks"}})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	first, err := stream.Recv()
+	if err != nil || first.GetDocId() != "t1" || string(first.GetPayload()) != `{"n":1}` {
+		t.Fatalf("expected the existing tasks event, got %+v, %v", first, err)
+	}
+
+	if _, err := client.DeleteDocument(ctx, &ledgerdbpb.DeleteDocumentRequest{Collection: "tasks", DocId: "t1"}); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	second, err := stream.Recv()
+	if err != nil || second.GetOp() != "delete" || second.GetCursor() == first.GetCursor() {
+		t.Fatalf("expected the new delete to be pushed, got %+v, %v", second, err)
+	}
+
+	resumed, err := client.Watch(ctx, &ledgerdbpb.WatchRequest{Cursor: "nope"})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if _, err := resumed.Recv(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a malformed cursor, got %v", err)
+	}
+}
+
+func TestGRPCGracefulStopEndsWatchStreams(t *testing.T) {
+	_, srv := newTestBackend(t, serverConfig{WatchInterval: 10 * time.Millisecond})
+	client, grpcServer := dialTestGRPC(t, srv)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := client.PutDocument(ctx, &ledgerdbpb.PutDocumentRequest{Collection: "tasks", DocId: "t1", Payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("put: %v", err)
+	}
+	stream, err := client.Watch(ctx, &ledgerdbpb.WatchRequest{})
+	if err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("expected the existing event, got %v", err)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		srv.stopWatch()
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		t.Fatal("GracefulStop waited on an open Watch stream")
+	}
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Fatalf("expected the stream to end cleanly, got %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/spf13/cobra"
+)
+
+// proofVersion is the proof file format; check-proof rejects other versions.
+const proofVersion = 1
+
+func newIntegrityProveCmd(opts *RootOptions) *cobra.Command {
+	var commit string
+	var outPath string
+	cmd := &cobra.Command{
+		Use:   "prove <collection> <id>",
+		Short: "Write a self-contained inclusion proof for a document",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			service := integrityapp.NewProofService(newGitStore(opts), txv3.Decoder{}, hash.SHA256{}, opts.StreamLayout)
+			proof, err := service.Prove(cmd.Context(), opts.RepoPath, args[0], args[1], commit)
+			if err != nil {
+				return err
+			}
+			return writeProof(cmd, proof, outPath, opts.JSONOutput)
+		},
+	}
+	cmd.Flags().StringVar(&commit, "commit", "", "Commit to prove against (default: main)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write the proof to this file instead of stdout")
+	return cmd
+}
+
+func newIntegrityCheckProofCmd(opts *RootOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check-proof <file>",
+		Short: "Check an inclusion proof without the repository",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			proof, err := readProof(args[0])
+			if err != nil {
+				return err
+			}
+			service := integrityapp.NewProofService(gitrepo.NewStore(), txv3.Decoder{}, hash.SHA256{}, "")
+			result, err := service.Check(cmd.Context(), proof)
+			if err != nil {
+				return err
+			}
+			return writeProofResult(cmd, result, opts.JSONOutput)
+		},
+	}
+}
+
+type proofFile struct {
+	Version      int             `json:"version"`
+	Collection   string          `json:"collection"`
+	DocID        string          `json:"doc_id"`
+	Commit       string          `json:"commit"`
+	StreamPath   string          `json:"stream_path"`
+	CommitObject []byte          `json:"commit_object"`
+	Trees        []proofTreeFile `json:"trees"`
+	Head         []byte          `json:"head"`
+	Txs          []proofTxFile   `json:"txs"`
+}
+
+type proofTreeFile struct {
+	Path   string `json:"path"`
+	Object []byte `json:"object"`
+}
+
+type proofTxFile struct {
+	Name string `json:"name"`
+	Blob []byte `json:"blob"`
+}
+
+type proofWrittenOutput struct {
+	Path       string `json:"path"`
+	Collection string `json:"collection"`
+	DocID      string `json:"doc_id"`
+	Commit     string `json:"commit"`
+	Txs        int    `json:"txs"`
+}
+
+type proofResultOutput struct {
+	Valid      bool   `json:"valid"`
+	Collection string `json:"collection"`
+	DocID      string `json:"doc_id"`
+	Commit     string `json:"commit"`
+	StreamPath string `json:"stream_path"`
+	TxHash     string `json:"tx_hash"`
+	TxID       string `json:"tx_id"`
+	Op         string `json:"op"`
+	Timestamp  int64  `json:"timestamp"`
+	Signer     string `json:"signer,omitempty"`
+	Chain      int    `json:"chain"`
+}
+
+func newProofFile(proof integrityapp.Proof) proofFile {
+	inclusion := proof.Inclusion
+	file := proofFile{
+		Version:      proofVersion,
+		Collection:   proof.Collection,
+		DocID:        proof.DocID,
+		Commit:       inclusion.Commit,
+		StreamPath:   inclusion.StreamPath,
+		CommitObject: inclusion.CommitObject,
+		Trees:        make([]proofTreeFile, 0, len(inclusion.Trees)),
+		Head:         inclusion.Head,
+		Txs:          make([]proofTxFile, 0, len(inclusion.Txs)),
+	}
+	for _, tree := range inclusion.Trees {
+		file.Trees = append(file.Trees, proofTreeFile{Path: tree.Path, Object: tree.Object})
+	}
+	for _, tx := range inclusion.Txs {
+		file.Txs = append(file.Txs, proofTxFile{Name: tx.Name, Blob: tx.Blob})
+	}
+	return file
+}
+
+func readProof(path string) (integrityapp.Proof, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return integrityapp.Proof{}, fmt.Errorf("read proof: %w", err)
+	}
+	var file proofFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return integrityapp.Proof{}, fmt.Errorf("%w: %v", integrityapp.ErrInvalidProof, err)
+	}
+	if file.Version != proofVersion {
+		return integrityapp.Proof{}, fmt.Errorf("%w: unsupported version %d", integrityapp.ErrInvalidProof, file.Version)
+	}
+
+	proof := integrityapp.Proof{
+		Collection: file.Collection,
+		DocID:      file.DocID,
+		Inclusion: integrityapp.Inclusion{
+			Commit:       file.Commit,
+			CommitObject: file.CommitObject,
+			StreamPath:   file.StreamPath,
+			Head:         file.Head,
+		},
+	}
+	for _, tree := range file.Trees {
+		proof.Inclusion.Trees = append(proof.Inclusion.Trees, integrityapp.ProofTree{Path: tree.Path, Object: tree.Object})
+	}
+	for _, tx := range file.Txs {
+		proof.Inclusion.Txs = append(proof.Inclusion.Txs, integrityapp.ProofTx{Name: tx.Name, Blob: tx.Blob})
+	}
+	return proof, nil
+}
+
+func writeProof(cmd *cobra.Command, proof integrityapp.Proof, path string, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if path == "" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(newProofFile(proof))
+	}
+
+	data, err := json.MarshalIndent(newProofFile(proof), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write proof: %w", err)
+	}
+
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(proofWrittenOutput{
+			Path:       path,
+			Collection: proof.Collection,
+			DocID:      proof.DocID,
+			Commit:     proof.Inclusion.Commit,
+			Txs:        len(proof.Inclusion.Txs),
+		})
+	}
+	ui := newRenderer(out, asJSON)
+	_, err = fmt.Fprintf(out, "%s %s\n%s %s/%s at commit %s, %d tx(s)\n", ui.ok("Proof written"), path, ui.key("proves:"), proof.Collection, proof.DocID, proof.Inclusion.Commit, len(proof.Inclusion.Txs))
+	return err
+}
+
+func writeProofResult(cmd *cobra.Command, result integrityapp.ProofResult, asJSON bool) error {
+	out := cmd.OutOrStdout()
+	if asJSON {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(proofResultOutput{
+			Valid:      true,
+			Collection: result.Collection,
+			DocID:      result.DocID,
+			Commit:     result.Commit,
+			StreamPath: result.StreamPath,
+			TxHash:     result.TxHash,
+			TxID:       result.TxID,
+			Op:         result.Op.String(),
+			Timestamp:  result.Timestamp,
+			Signer:     result.Signer,
+			Chain:      result.Chain,
+		})
+	}
+
+	ui := newRenderer(out, asJSON)
+	if _, err := fmt.Fprintf(out, "%s: %s/%s is in commit %s\n", ui.ok("OK"), result.Collection, result.DocID, result.Commit); err != nil {
+		return err
+	}
+	if err := writeKV(out, ui, "Tx Hash", result.TxHash); err != nil {
+		return err
+	}
+	if err := writeKV(out, ui, "Tx ID", result.TxID); err != nil {
+		return err
+	}
+	if err := writeKV(out, ui, "Op", colorOp(ui, result.Op.String())); err != nil {
+		return err
+	}
+	if err := writeKV(out, ui, "Timestamp", strconv.FormatInt(result.Timestamp, 10)); err != nil {
+		return err
+	}
+	if result.Signer != "" {
+		if err := writeKV(out, ui, "Signer", result.Signer); err != nil {
+			return err
+		}
+	}
+	return writeKV(out, ui, "Chain", fmt.Sprintf("%d tx(s)", result.Chain))
+}
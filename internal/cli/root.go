@@ -1,12 +1,14 @@
 package cli
 
 import (
+	"crypto/ed25519"
 	"os"
 	"strconv"
 	"strings"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txkey"
 	"github.com/osvaldoandrade/ledgerdb/internal/platform"
 	"github.com/spf13/cobra"
 )
@@ -18,9 +20,12 @@ type RootOptions struct {
 	LogFormat    string
 	SignCommits  bool
 	SignKey      string
+	TxKeyPath    string
 	AutoSync     bool
 	StreamLayout domain.StreamLayout
 	HistoryMode  domain.HistoryMode
+
+	txKey ed25519.PrivateKey
 }
 
 func newRootCmd() *cobra.Command {
@@ -29,6 +34,7 @@ func newRootCmd() *cobra.Command {
 		LogFormat:    envDefault("LEDGERDB_LOG_FORMAT", "text"),
 		SignCommits:  envBoolDefault("LEDGERDB_GIT_SIGN", false),
 		SignKey:      envDefault("LEDGERDB_GIT_SIGN_KEY", ""),
+		TxKeyPath:    envDefault("LEDGERDB_TX_KEY", ""),
 		AutoSync:     envBoolDefault("LEDGERDB_AUTO_SYNC", true),
 		StreamLayout: domain.StreamLayoutFlat,
 		HistoryMode:  domain.HistoryModeAppend,
@@ -46,7 +52,13 @@ func newRootCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			if cmd.Name() == "init" || cmd.Name() == "clone" {
+			if strings.TrimSpace(opts.TxKeyPath) != "" {
+				opts.txKey, err = txkey.Load(opts.TxKeyPath)
+				if err != nil {
+					return err
+				}
+			}
+			if cmd.Name() == "init" || cmd.Name() == "clone" || cmd.Name() == "check-proof" || (cmd.HasParent() && cmd.Parent().Name() == "key") {
 				return nil
 			}
 			manifest, err := gitrepo.LoadManifest(opts.RepoPath)
@@ -65,6 +77,7 @@ func newRootCmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&opts.LogFormat, "log-format", opts.LogFormat, "Log format (text, json)")
 	cmd.PersistentFlags().BoolVar(&opts.SignCommits, "sign", opts.SignCommits, "Sign git commits (requires gpg/ssh configuration)")
 	cmd.PersistentFlags().StringVar(&opts.SignKey, "sign-key", opts.SignKey, "Signing key id for git commit signing")
+	cmd.PersistentFlags().StringVar(&opts.TxKeyPath, "tx-key", opts.TxKeyPath, "Ed25519 private key (PKCS#8 PEM) used to sign every transaction")
 	cmd.PersistentFlags().BoolVar(&opts.AutoSync, "sync", opts.AutoSync, "Auto-fetch before writes and auto-push after")
 
 	cmd.AddCommand(
@@ -72,10 +85,15 @@ func newRootCmd() *cobra.Command {
 		newInitCmd(opts),
 		newStatusCmd(opts),
 		newPushCmd(opts),
+		newSyncCmd(opts),
 		newCollectionCmd(opts),
 		newDocCmd(opts),
 		newIndexCmd(opts),
+		newQueryCmd(opts),
+		newChangesCmd(opts),
+		newServeCmd(opts),
 		newInspectCmd(opts),
+		newKeyCmd(opts),
 		newMaintenanceCmd(opts),
 		newIntegrityCmd(opts),
 	)
@@ -0,0 +1,601 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+	collectionapp "github.com/osvaldoandrade/ledgerdb/internal/app/collection"
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/platform"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+const (
+	maxRequestBody  = 16 << 20
+	shutdownTimeout = 10 * time.Second
+	// requestSchemaPath stands in for the schema file when the schema
+	// arrives in the request body.
+	requestSchemaPath = "schema.json"
+)
+
+var errInvalidRequestBody = errors.New("invalid request body")
+
+func newServeCmd(opts *RootOptions) *cobra.Command {
+	var listen string
+	var grpcListen string
+	var cfg serverConfig
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the ledger over HTTP/JSON and optionally gRPC",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			listener, err := net.Listen("tcp", listen)
+			if err != nil {
+				return err
+			}
+			srv := newServer(opts, cfg)
+			// The writer outlives the signal context so requests still in
+			// flight during Shutdown can finish their writes.
+			writerCtx, stopWriter := context.WithCancel(context.Background())
+			defer stopWriter()
+			go srv.runWriter(writerCtx)
+
+			httpServer := &http.Server{
+				Handler:           srv.routes(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			errs := make(chan error, 2)
+			go func() {
+				errs <- httpServer.Serve(listener)
+			}()
+
+			ui := newRenderer(cmd.ErrOrStderr(), opts.JSONOutput)
+			if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "%s http://%s\n", ui.accent("Listening on"), listener.Addr()); err != nil {
+				return err
+			}
+
+			var grpcServer *grpc.Server
+			if grpcListen != "" {
+				grpcListener, err := net.Listen("tcp", grpcListen)
+				if err != nil {
+					_ = httpServer.Close()
+					return err
+				}
+				grpcServer = newGRPCServer(srv)
+				go func() {
+					errs <- grpcServer.Serve(grpcListener)
+				}()
+				if _, err := fmt.Fprintf(cmd.ErrOrStderr(), "%s grpc://%s\n", ui.accent("Listening on"), grpcListener.Addr()); err != nil {
+					return err
+				}
+			}
+
+			select {
+			case err := <-errs:
+				return err
+			case <-ctx.Done():
+			}
+			srv.stopWatch()
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+			defer cancel()
+			err = httpServer.Shutdown(shutdownCtx)
+			stopWriter()
+			return err
+		},
+	}
+	cmd.Flags().StringVar(&listen, "listen", "127.0.0.1:8080", "Address to listen on (use 0.0.0.0:8080 to accept remote clients)")
+	cmd.Flags().StringVar(&grpcListen, "grpc", "", "Also serve the gRPC API on this address (e.g. 127.0.0.1:9090)")
+	cmd.Flags().DurationVar(&cfg.WatchInterval, "watch-interval", time.Second, "How often gRPC Watch streams poll main for new commits")
+	cmd.Flags().StringVar(&cfg.IndexDB, "db", "", "SQLite index database for POST /v1/index/sync")
+	cmd.Flags().StringVar(&cfg.IndexSink, "sink", "", "Index sink URI for POST /v1/index/sync instead of --db")
+	cmd.Flags().BoolVar(&cfg.IndexFast, "fast", false, "Relax SQLite durability for faster indexing")
+	return cmd
+}
+
+type serverConfig struct {
+	IndexDB       string
+	IndexSink     string
+	IndexFast     bool
+	WatchInterval time.Duration
+}
+
+// server exposes the app services over HTTP. Every write goes through a
+// single writer goroutine so concurrent requests queue instead of racing on
+// the stream heads. Watch streams end when watching is cancelled, which
+// shutdown does before waiting on them.
+type server struct {
+	opts      *RootOptions
+	cfg       serverConfig
+	store     *gitrepo.Store
+	writes    chan writeJob
+	indexMu   sync.Mutex
+	watching  context.Context
+	stopWatch context.CancelFunc
+
+	put       *docapp.PutService
+	get       *docapp.GetService
+	patch     *docapp.PatchService
+	del       *docapp.DeleteService
+	revert    *docapp.RevertService
+	log       *docapp.LogService
+	list      *docapp.ListService
+	batch     *docapp.BatchService
+	colLog    *collectionapp.LogService
+	changes   *changesapp.Service
+	verify    *integrityapp.VerifyService
+	indexSync func(store indexapp.Store) *indexapp.SyncService
+}
+
+type writeJob struct {
+	ctx  context.Context
+	fn   func(ctx context.Context) error
+	done chan error
+}
+
+func newServer(opts *RootOptions, cfg serverConfig) *server {
+	store := newGitStore(opts)
+	watching, stopWatch := context.WithCancel(context.Background())
+	return &server{
+		opts:      opts,
+		cfg:       cfg,
+		store:     store,
+		writes:    make(chan writeJob),
+		watching:  watching,
+		stopWatch: stopWatch,
+		put: docapp.NewPutService(
+			store,
+			canonicaljson.Canonicalizer{},
+			txEncoder(opts),
+			hash.SHA256{},
+			platform.RealClock{},
+			ident.NewULIDGenerator(),
+			store,
+			schema.JSONSchemaValidator{},
+			opts.StreamLayout,
+			opts.HistoryMode,
+		),
+		get: docapp.NewGetService(store, store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, store, opts.StreamLayout),
+		patch: docapp.NewPatchService(
+			store,
+			store,
+			canonicaljson.Canonicalizer{},
+			txEncoder(opts),
+			txv3.Decoder{},
+			jsonpatch.Patcher{},
+			hash.SHA256{},
+			platform.RealClock{},
+			ident.NewULIDGenerator(),
+			store,
+			schema.JSONSchemaValidator{},
+			docapp.DefaultRetryPolicy(),
+			opts.StreamLayout,
+			opts.HistoryMode,
+		),
+		del: docapp.NewDeleteService(
+			store,
+			store,
+			txEncoder(opts),
+			txv3.Decoder{},
+			hash.SHA256{},
+			platform.RealClock{},
+			ident.NewULIDGenerator(),
+			opts.StreamLayout,
+			opts.HistoryMode,
+		),
+		revert: docapp.NewRevertService(
+			store,
+			store,
+			canonicaljson.Canonicalizer{},
+			txEncoder(opts),
+			txv3.Decoder{},
+			jsonpatch.Patcher{},
+			hash.SHA256{},
+			platform.RealClock{},
+			ident.NewULIDGenerator(),
+			store,
+			schema.JSONSchemaValidator{},
+			opts.StreamLayout,
+			opts.HistoryMode,
+		),
+		log:  docapp.NewLogService(store, txv3.Decoder{}, hash.SHA256{}, opts.StreamLayout),
+		list: docapp.NewListService(store, txv3.Decoder{}),
+		batch: docapp.NewBatchService(
+			store,
+			store,
+			canonicaljson.Canonicalizer{},
+			txEncoder(opts),
+			txv3.Decoder{},
+			jsonpatch.Patcher{},
+			hash.SHA256{},
+			platform.RealClock{},
+			ident.NewULIDGenerator(),
+			store,
+			schema.JSONSchemaValidator{},
+			opts.StreamLayout,
+			opts.HistoryMode,
+		),
+		colLog:  collectionapp.NewLogService(store, hash.SHA256{}),
+		changes: changesapp.NewService(store, store, txv3.Decoder{}, hash.SHA256{}),
+		verify:  integrityapp.NewVerifyService(store, store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, canonicaljson.Canonicalizer{}, store, store, store, store),
+		indexSync: func(index indexapp.Store) *indexapp.SyncService {
+			return indexapp.NewSyncService(
+				store,
+				store,
+				store,
+				index,
+				canonicaljson.Canonicalizer{},
+				txv3.Decoder{},
+				jsonpatch.Patcher{},
+				hash.SHA256{},
+				store,
+			)
+		},
+	}
+}
+
+func (s *server) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealth)
+	mux.HandleFunc("PUT /v1/collections/{collection}", s.handleCollectionApply)
+	mux.HandleFunc("GET /v1/collections/{collection}/docs/{id}", s.handleDocGet)
+	mux.HandleFunc("PUT /v1/collections/{collection}/docs/{id}", s.handleDocPut)
+	mux.HandleFunc("PATCH /v1/collections/{collection}/docs/{id}", s.handleDocPatch)
+	mux.HandleFunc("DELETE /v1/collections/{collection}/docs/{id}", s.handleDocDelete)
+	mux.HandleFunc("GET /v1/collections/{collection}/docs/{id}/log", s.handleDocLog)
+	mux.HandleFunc("POST /v1/collections/{collection}/docs/{id}/revert", s.handleDocRevert)
+	mux.HandleFunc("POST /v1/index/sync", s.handleIndexSync)
+	mux.HandleFunc("POST /v1/integrity/verify", s.handleVerify)
+	return mux
+}
+
+// runWriter executes queued writes one at a time until ctx is done, wrapping
+// each in the same fetch/push as the CLI when auto-sync is on.
+func (s *server) runWriter(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.writes:
+			if err := job.ctx.Err(); err != nil {
+				job.done <- err
+				continue
+			}
+			job.done <- s.runWrite(job)
+		}
+	}
+}
+
+func (s *server) runWrite(job writeJob) error {
+	if !s.opts.AutoSync {
+		return job.fn(job.ctx)
+	}
+	if err := s.store.Fetch(job.ctx, s.opts.RepoPath); err != nil {
+		return err
+	}
+	if err := job.fn(job.ctx); err != nil {
+		return err
+	}
+	return s.store.Push(job.ctx, s.opts.RepoPath)
+}
+
+func (s *server) write(ctx context.Context, fn func(ctx context.Context) error) error {
+	job := writeJob{ctx: ctx, fn: fn, done: make(chan error, 1)}
+	select {
+	case s.writes <- job:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return <-job.done
+}
+
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeHTTPJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// collectionApplyRequest keeps the current trusted keys when trusted_keys
+// is absent or null; an explicit [] removes them.
+type collectionApplyRequest struct {
+	Schema      json.RawMessage `json:"schema"`
+	Indexes     []string        `json:"indexes"`
+	FullText    []string        `json:"fulltext"`
+	TrustedKeys []string        `json:"trusted_keys"`
+}
+
+func (s *server) handleCollectionApply(w http.ResponseWriter, r *http.Request) {
+	var req collectionApplyRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	service := collectionapp.NewService(s.store, requestSchemaSource(req.Schema), schema.JSONSchemaValidator{})
+	err := s.write(r.Context(), func(ctx context.Context) error {
+		return service.Apply(ctx, s.opts.RepoPath, r.PathValue("collection"), requestSchemaPath, req.Indexes, req.FullText, req.TrustedKeys)
+	})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *server) handleDocGet(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	readOpts := docapp.ReadOptions{
+		AtTx:     query.Get("at_tx"),
+		AtCommit: query.Get("at_commit"),
+	}
+	if asOf := query.Get("as_of"); asOf != "" {
+		parsed, err := time.Parse(time.RFC3339, asOf)
+		if err != nil {
+			writeHTTPError(w, fmt.Errorf("%w: %v", errInvalidAsOf, err))
+			return
+		}
+		readOpts.AsOf = parsed
+	}
+	result, err := s.get.GetAt(r.Context(), s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"), readOpts)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newGetOutput(result))
+}
+
+func (s *server) handleDocPut(w http.ResponseWriter, r *http.Request) {
+	data, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	var result docapp.PutResult
+	err = s.write(r.Context(), func(ctx context.Context) error {
+		var err error
+		result, err = s.put.Put(ctx, s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"), data, writeOptions(r))
+		return err
+	})
+	writePutResponse(w, result, err)
+}
+
+func (s *server) handleDocPatch(w http.ResponseWriter, r *http.Request) {
+	data, err := readRequestBody(w, r)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	var result docapp.PutResult
+	err = s.write(r.Context(), func(ctx context.Context) error {
+		var err error
+		result, err = s.patch.Patch(ctx, s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"), data, writeOptions(r))
+		return err
+	})
+	writePutResponse(w, result, err)
+}
+
+func (s *server) handleDocDelete(w http.ResponseWriter, r *http.Request) {
+	var result docapp.PutResult
+	err := s.write(r.Context(), func(ctx context.Context) error {
+		var err error
+		result, err = s.del.Delete(ctx, s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"), writeOptions(r))
+		return err
+	})
+	writePutResponse(w, result, err)
+}
+
+func (s *server) handleDocLog(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.log.Log(r.Context(), s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"))
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newLogOutput(entries))
+}
+
+type revertRequest struct {
+	TxID   string `json:"tx_id"`
+	TxHash string `json:"tx_hash"`
+}
+
+func (s *server) handleDocRevert(w http.ResponseWriter, r *http.Request) {
+	var req revertRequest
+	if err := decodeRequest(w, r, &req); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	var result docapp.PutResult
+	err := s.write(r.Context(), func(ctx context.Context) error {
+		var err error
+		result, err = s.revert.Revert(ctx, s.opts.RepoPath, r.PathValue("collection"), r.PathValue("id"), docapp.RevertOptions{
+			TxID:   req.TxID,
+			TxHash: req.TxHash,
+		})
+		return err
+	})
+	writePutResponse(w, result, err)
+}
+
+type indexSyncRequest struct {
+	Fetch        *bool  `json:"fetch"`
+	BatchCommits int    `json:"batch_commits"`
+	Mode         string `json:"mode"`
+	History      bool   `json:"history"`
+}
+
+func (s *server) handleIndexSync(w http.ResponseWriter, r *http.Request) {
+	req := indexSyncRequest{BatchCommits: 1, Mode: string(indexapp.ModeState)}
+	if err := decodeOptionalRequest(w, r, &req); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.BatchCommits <= 0 {
+		writeHTTPError(w, indexapp.ErrInvalidBatchCommits)
+		return
+	}
+	mode, err := indexapp.ParseMode(req.Mode)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	fetch := s.opts.AutoSync
+	if req.Fetch != nil {
+		fetch = *req.Fetch
+	}
+
+	s.indexMu.Lock()
+	defer s.indexMu.Unlock()
+	index, err := openIndexSink(s.cfg.IndexDB, s.cfg.IndexSink, s.cfg.IndexFast)
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	defer func() {
+		_ = index.Close()
+	}()
+	result, err := s.indexSync(index).Sync(r.Context(), s.opts.RepoPath, indexapp.SyncOptions{
+		Fetch:        fetch,
+		AllowReset:   s.opts.HistoryMode == domain.HistoryModeAmend,
+		BatchCommits: req.BatchCommits,
+		Mode:         mode,
+		History:      req.History,
+	})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newIndexSyncOutput(result))
+}
+
+type verifyRequest struct {
+	Deep    bool `json:"deep"`
+	State   bool `json:"state"`
+	Workers int  `json:"workers"`
+}
+
+func (s *server) handleVerify(w http.ResponseWriter, r *http.Request) {
+	req := verifyRequest{Workers: runtime.NumCPU()}
+	if err := decodeOptionalRequest(w, r, &req); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	if req.Workers <= 0 {
+		writeHTTPError(w, integrityapp.ErrInvalidWorkers)
+		return
+	}
+	// A request gets at most one worker per server CPU.
+	req.Workers = min(req.Workers, runtime.NumCPU())
+	result, err := s.verify.Verify(r.Context(), s.opts.RepoPath, integrityapp.VerifyOptions{Deep: req.Deep, State: req.State, Workers: req.Workers})
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newIntegrityOutput(result))
+}
+
+// writeOptions maps HTTP preconditions onto write options: If-Match carries
+// the expected head tx hash and If-None-Match: * requires a new stream.
+func writeOptions(r *http.Request) docapp.WriteOptions {
+	return docapp.WriteOptions{
+		IfMatch:     strings.Trim(strings.TrimSpace(r.Header.Get("If-Match")), `"`),
+		IfNoneMatch: strings.TrimSpace(r.Header.Get("If-None-Match")) == "*",
+	}
+}
+
+func readRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBody))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+	return data, nil
+}
+
+func decodeRequest(w http.ResponseWriter, r *http.Request, dst any) error {
+	data, err := readRequestBody(w, r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+	return nil
+}
+
+func decodeOptionalRequest(w http.ResponseWriter, r *http.Request, dst any) error {
+	data, err := readRequestBody(w, r)
+	if err != nil || len(strings.TrimSpace(string(data))) == 0 {
+		return err
+	}
+	if err := json.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("%w: %v", errInvalidRequestBody, err)
+	}
+	return nil
+}
+
+func writePutResponse(w http.ResponseWriter, result docapp.PutResult, err error) {
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+	writeHTTPJSON(w, http.StatusOK, newPutOutput(result))
+}
+
+func writeHTTPJSON(w http.ResponseWriter, status int, payload any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(payload)
+}
+
+// writeHTTPError sends the same JSON error body as the CLI's --json mode,
+// with the error kind mapped onto an HTTP status.
+func writeHTTPError(w http.ResponseWriter, err error) {
+	exitErr := NormalizeError(err)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus(exitErr.Kind))
+	_ = writeCLIError(w, exitErr, true)
+}
+
+func httpStatus(kind ErrorKind) int {
+	switch kind {
+	case KindValidation:
+		return http.StatusBadRequest
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+type requestSchemaSource []byte
+
+func (s requestSchemaSource) ReadSchema(ctx context.Context, _ string) ([]byte, error) {
+	return s, nil
+}
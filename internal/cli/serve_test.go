@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	repoapp "github.com/osvaldoandrade/ledgerdb/internal/app/repo"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/platform"
+)
+
+// newTestBackend initializes a repository and starts a server's writer on
+// it; the HTTP and gRPC fixtures serve it over their own listeners.
+func newTestBackend(t *testing.T, cfg serverConfig) (*RootOptions, *server) {
+	t.Helper()
+	opts := &RootOptions{
+		RepoPath:     filepath.Join(t.TempDir(), "repo"),
+		StreamLayout: domain.StreamLayoutSharded,
+		HistoryMode:  domain.HistoryModeAppend,
+	}
+	service := repoapp.NewInitService(newGitStore(opts), platform.RealClock{})
+	if err := service.Init(context.Background(), opts.RepoPath, repoapp.InitOptions{
+		StreamLayout: opts.StreamLayout,
+		HistoryMode:  opts.HistoryMode,
+	}); err != nil {
+		t.Fatalf("init repo: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := newServer(opts, cfg)
+	go srv.runWriter(ctx)
+	t.Cleanup(cancel)
+	return opts, srv
+}
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	_, srv := newTestBackend(t, serverConfig{IndexDB: filepath.Join(t.TempDir(), "index.db")})
+	httpServer := httptest.NewServer(srv.routes())
+	t.Cleanup(httpServer.Close)
+	return httpServer
+}
+
+func doRequest(t *testing.T, method, url, body string, header map[string]string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	if out != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("decode %s: %v", data, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServeDocumentLifecycle(t *testing.T) {
+	server := newTestServer(t)
+	docURL := server.URL + "/v1/collections/tasks/docs/t1"
+
+	var put putOutput
+	if status := doRequest(t, http.MethodPut, docURL, `{"status":"open"}`, nil, &put); status != http.StatusOK || put.TxHash == "" {
+		t.Fatalf("put: status %d, %+v", status, put)
+	}
+	var conflict struct {
+		Kind    string `json:"kind"`
+		Message string `json:"message"`
+	}
+	if status := doRequest(t, http.MethodPut, docURL, `{}`, map[string]string{"If-None-Match": "*"}, &conflict); status != http.StatusConflict || conflict.Kind != string(KindConflict) {
+		t.Fatalf("expected a 409 conflict body, got %d %+v", status, conflict)
+	}
+
+	var patched putOutput
+	if status := doRequest(t, http.MethodPatch, docURL, `[{"op":"replace","path":"/status","value":"done"}]`, map[string]string{"If-Match": `"` + put.TxHash + `"`}, &patched); status != http.StatusOK {
+		t.Fatalf("patch: status %d", status)
+	}
+	var got getOutput
+	if status := doRequest(t, http.MethodGet, docURL, "", nil, &got); status != http.StatusOK || string(got.Doc) != `{"status":"done"}` {
+		t.Fatalf("get: status %d, %+v", status, got)
+	}
+
+	var reverted putOutput
+	if status := doRequest(t, http.MethodPost, docURL+"/revert", fmt.Sprintf(`{"tx_hash":%q}`, put.TxHash), nil, &reverted); status != http.StatusOK {
+		t.Fatalf("revert: status %d", status)
+	}
+	var log logOutput
+	if status := doRequest(t, http.MethodGet, docURL+"/log", "", nil, &log); status != http.StatusOK || len(log.Entries) != 3 {
+		t.Fatalf("log: status %d, %+v", status, log)
+	}
+
+	if status := doRequest(t, http.MethodDelete, docURL, "", nil, nil); status != http.StatusOK {
+		t.Fatalf("delete: status %d", status)
+	}
+	var missing struct {
+		Kind string `json:"kind"`
+	}
+	if status := doRequest(t, http.MethodGet, docURL, "", nil, &missing); status != http.StatusNotFound || missing.Kind != string(KindNotFound) {
+		t.Fatalf("expected 404 after delete, got %d %+v", status, missing)
+	}
+	if status := doRequest(t, http.MethodPost, docURL+"/revert", `{`, nil, &missing); status != http.StatusBadRequest || missing.Kind != string(KindValidation) {
+		t.Fatalf("expected 400 for a malformed body, got %d %+v", status, missing)
+	}
+}
+
+func TestServeCollectionIndexAndVerify(t *testing.T) {
+	server := newTestServer(t)
+
+	apply := `{"schema":{"type":"object","required":["status"]},"indexes":["status"]}`
+	if status := doRequest(t, http.MethodPut, server.URL+"/v1/collections/tasks", apply, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("collection apply: status %d", status)
+	}
+	var violation struct {
+		Kind       string `json:"kind"`
+		Violations []struct {
+			Pointer string `json:"pointer"`
+		} `json:"violations"`
+	}
+	if status := doRequest(t, http.MethodPut, server.URL+"/v1/collections/tasks/docs/t1", `{}`, nil, &violation); status != http.StatusBadRequest || len(violation.Violations) == 0 {
+		t.Fatalf("expected schema violations, got %d %+v", status, violation)
+	}
+	if status := doRequest(t, http.MethodPut, server.URL+"/v1/collections/tasks/docs/t1", `{"status":"open"}`, nil, nil); status != http.StatusOK {
+		t.Fatalf("put: status %d", status)
+	}
+
+	var synced indexSyncOutput
+	if status := doRequest(t, http.MethodPost, server.URL+"/v1/index/sync", `{"fetch":false}`, nil, &synced); status != http.StatusOK || synced.DocsUpserted != 1 {
+		t.Fatalf("index sync: status %d, %+v", status, synced)
+	}
+	var verified integrityOutput
+	if status := doRequest(t, http.MethodPost, server.URL+"/v1/integrity/verify", "", nil, &verified); status != http.StatusOK || verified.Streams != 1 || verified.Valid != 1 {
+		t.Fatalf("verify: status %d, %+v", status, verified)
+	}
+	tooMany := fmt.Sprintf(`{"workers":%d}`, runtime.NumCPU()*64)
+	if status := doRequest(t, http.MethodPost, server.URL+"/v1/integrity/verify", tooMany, nil, &verified); status != http.StatusOK || verified.Valid != 1 {
+		t.Fatalf("expected more workers than CPUs to be capped, got %d, %+v", status, verified)
+	}
+	if status := doRequest(t, http.MethodPost, server.URL+"/v1/integrity/verify", `{"workers":0}`, nil, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected 400 for zero workers, got %d", status)
+	}
+}
+
+func TestServeCollectionApplyKeepsTrustedKeys(t *testing.T) {
+	server := newTestServer(t)
+	collectionURL := server.URL + "/v1/collections/users"
+	docURL := collectionURL + "/docs/u1"
+
+	keyed := fmt.Sprintf(`{"schema":{},"trusted_keys":[%q]}`, strings.Repeat("ab", 32))
+	if status := doRequest(t, http.MethodPut, collectionURL, keyed, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("collection apply: status %d", status)
+	}
+	if status := doRequest(t, http.MethodPut, collectionURL, `{"schema":{},"indexes":["name"]}`, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("collection re-apply: status %d", status)
+	}
+	if status := doRequest(t, http.MethodPut, docURL, `{}`, nil, nil); status != http.StatusBadRequest {
+		t.Fatalf("expected the re-applied schema to keep rejecting unsigned writes, got %d", status)
+	}
+
+	if status := doRequest(t, http.MethodPut, collectionURL, `{"schema":{},"trusted_keys":[]}`, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("collection clear: status %d", status)
+	}
+	if status := doRequest(t, http.MethodPut, docURL, `{}`, nil, nil); status != http.StatusOK {
+		t.Fatalf("expected an explicit empty list to clear the keys, got %d", status)
+	}
+}
+
+func TestServeQueuesConcurrentWrites(t *testing.T) {
+	server := newTestServer(t)
+	docURL := server.URL + "/v1/collections/counters/docs/c1"
+	if status := doRequest(t, http.MethodPut, docURL, `{"hits":[]}`, nil, nil); status != http.StatusOK {
+		t.Fatalf("put: status %d", status)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	statuses := make(chan int, writers)
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			body := fmt.Sprintf(`[{"op":"add","path":"/hits/-","value":%d}]`, i)
+			statuses <- doRequest(t, http.MethodPatch, docURL, body, nil, nil)
+		}(i)
+	}
+	wg.Wait()
+	close(statuses)
+	for status := range statuses {
+		if status != http.StatusOK {
+			t.Fatalf("expected every queued patch to succeed, got %d", status)
+		}
+	}
+
+	var got getOutput
+	doRequest(t, http.MethodGet, docURL, "", nil, &got)
+	var doc struct {
+		Hits []int `json:"hits"`
+	}
+	if err := json.Unmarshal(got.Doc, &doc); err != nil || len(doc.Hits) != writers {
+		t.Fatalf("expected %d hits, got %s (%v)", writers, got.Doc, err)
+	}
+}
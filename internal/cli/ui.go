@@ -114,6 +114,12 @@ func (r renderer) bar(width int, ratio float64) string {
 }
 
 func withSpinner(ctx context.Context, out io.Writer, enabled bool, label string, fn func() error) error {
+	return withSpinnerLabel(ctx, out, enabled, func() string { return label }, fn)
+}
+
+// withSpinnerLabel re-reads label on every frame so long tasks can report
+// progress.
+func withSpinnerLabel(ctx context.Context, out io.Writer, enabled bool, label func() string, fn func() error) error {
 	if !enabled {
 		return fn()
 	}
@@ -133,7 +139,7 @@ func withSpinner(ctx context.Context, out io.Writer, enabled bool, label string,
 			clearLine(out)
 			return err
 		case <-ticker.C:
-			fmt.Fprintf(out, "\r%s %s", frames[frame%len(frames)], label)
+			fmt.Fprintf(out, "\r%s %s", frames[frame%len(frames)], label())
 			frame++
 		case <-ctx.Done():
 			// Continue until fn returns to avoid breaking output mid-flight.
@@ -43,3 +43,7 @@ func StatePath(layout StreamLayout, collection, key string) string {
 		return filepath.Join(StateRoot, collection, "DOC_"+hash)
 	}
 }
+
+func CollectionPath(collection string) string {
+	return filepath.Join(CollectionsRoot, collection)
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	IndexesRoot      = "indexes"
+	IndexValuePrefix = "VAL_"
+)
+
+// IndexDir is the directory holding every value bucket of a declared field.
+func IndexDir(collection, field string) string {
+	return filepath.Join(IndexesRoot, collection, IndexEntryName(field))
+}
+
+// IndexValuePath is indexes/<collection>/<field>/VAL_<sha256(value)>, where
+// value is the JSON encoding returned by IndexValues or ParseIndexValue.
+func IndexValuePath(collection, field string, value []byte) string {
+	sum := sha256.Sum256(value)
+	return filepath.Join(IndexDir(collection, field), IndexValuePrefix+hex.EncodeToString(sum[:]))
+}
+
+// IndexEntryName escapes a document id or field so it is a single tree entry.
+func IndexEntryName(name string) string {
+	escaped := url.PathEscape(name)
+	if escaped == "." || escaped == ".." {
+		return strings.ReplaceAll(escaped, ".", "%2E")
+	}
+	return escaped
+}
+
+func ParseIndexEntryName(name string) (string, error) {
+	return url.PathUnescape(name)
+}
+
+// IndexValues extracts the declared dotted fields from a JSON document. Only
+// scalar values are indexed; missing fields, nulls, objects and arrays are
+// left out of the result.
+func IndexValues(doc []byte, fields []string) map[string][]byte {
+	values := make(map[string][]byte, len(fields))
+	if len(doc) == 0 || len(fields) == 0 {
+		return values
+	}
+	var root any
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return values
+	}
+	for _, field := range fields {
+		if encoded, ok := indexScalar(lookupField(root, field)); ok {
+			values[field] = encoded
+		}
+	}
+	return values
+}
+
+func lookupField(value any, field string) any {
+	for _, key := range strings.Split(field, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+		value = object[key]
+	}
+	return value
+}
+
+func indexScalar(value any) ([]byte, bool) {
+	switch value.(type) {
+	case string, json.Number, bool:
+	default:
+		return nil, false
+	}
+	encoded, err := json.Marshal(value)
+	return encoded, err == nil
+}
+
+// ParseIndexValue turns a query literal into the encoding IndexValues produces:
+// JSON scalars are kept as written and anything else is taken as a string.
+func ParseIndexValue(raw string) []byte {
+	var value any
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err == nil && !decoder.More() {
+		if encoded, ok := indexScalar(value); ok {
+			return encoded
+		}
+	}
+	encoded, _ := json.Marshal(raw)
+	return encoded
+}
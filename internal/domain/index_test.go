@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIndexValuesMatchParsedQueryValues(t *testing.T) {
+	doc := []byte(`{"status":"open","n":1,"ok":true,"tags":["x"],"owner":{"name":"ana"},"none":null}`)
+	values := IndexValues(doc, []string{"status", "n", "ok", "tags", "owner.name", "none", "missing"})
+
+	expected := map[string][]byte{
+		"status":     ParseIndexValue("open"),
+		"n":          ParseIndexValue("1"),
+		"ok":         ParseIndexValue("true"),
+		"owner.name": ParseIndexValue(`"ana"`),
+	}
+	if !reflect.DeepEqual(values, expected) {
+		t.Fatalf("expected %q, got %q", expected, values)
+	}
+	if string(ParseIndexValue(`"1"`)) == string(ParseIndexValue("1")) {
+		t.Fatalf("expected quoted and bare numbers to differ")
+	}
+}
+
+func TestIndexEntryNameRoundTrips(t *testing.T) {
+	for _, name := range []string{"user_1", "a/b", "..", "café %"} {
+		escaped := IndexEntryName(name)
+		if escaped == "." || escaped == ".." || escaped == "" {
+			t.Fatalf("expected %q to escape to a safe entry, got %q", name, escaped)
+		}
+		got, err := ParseIndexEntryName(escaped)
+		if err != nil || got != name {
+			t.Fatalf("expected %q to round-trip, got %q (%v)", name, got, err)
+		}
+	}
+}
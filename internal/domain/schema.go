@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrSchemaViolation = errors.New("document violates collection schema")
+
+type SchemaViolation struct {
+	Pointer string
+	Reason  string
+}
+
+type SchemaViolationError struct {
+	Collection string
+	Violations []SchemaViolation
+}
+
+func (e *SchemaViolationError) Error() string {
+	if len(e.Violations) == 0 {
+		return fmt.Sprintf("%s: %s", ErrSchemaViolation, e.Collection)
+	}
+	parts := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", pointer, violation.Reason))
+	}
+	return fmt.Sprintf("%s %s (%s)", ErrSchemaViolation, e.Collection, strings.Join(parts, "; "))
+}
+
+func (e *SchemaViolationError) Unwrap() error {
+	return ErrSchemaViolation
+}
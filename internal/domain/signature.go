@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalidSignature = errors.New("invalid transaction signature")
+var ErrInvalidPublicKey = errors.New("invalid ed25519 public key")
+var ErrUnsignedTx = errors.New("transaction is not signed")
+var ErrUntrustedSigner = errors.New("transaction signed by an untrusted key")
+
+// NormalizePublicKey returns key as lowercase hex, rejecting anything that is
+// not a 32-byte Ed25519 public key.
+func NormalizePublicKey(key string) (string, error) {
+	key = strings.ToLower(strings.TrimSpace(key))
+	raw, err := hex.DecodeString(key)
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPublicKey, key)
+	}
+	return key, nil
+}
+
+// CheckSigner applies a collection's trusted keys to a transaction. With no
+// trusted keys every transaction is accepted; otherwise it must be signed by
+// one of them.
+func CheckSigner(trustedKeys []string, tx Transaction) error {
+	if len(trustedKeys) == 0 {
+		return nil
+	}
+	if tx.Signer == "" {
+		return fmt.Errorf("%w: %s/%s tx %s", ErrUnsignedTx, tx.Collection, tx.DocID, tx.TxID)
+	}
+	for _, key := range trustedKeys {
+		if key == tx.Signer {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s/%s tx %s signed by %s", ErrUntrustedSigner, tx.Collection, tx.DocID, tx.TxID, tx.Signer)
+}
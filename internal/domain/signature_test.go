@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNormalizePublicKey(t *testing.T) {
+	key := strings.Repeat("AB", 32)
+	normalized, err := NormalizePublicKey(" " + key + " ")
+	if err != nil || normalized != strings.ToLower(key) {
+		t.Fatalf("expected a lowercase key, got %q (%v)", normalized, err)
+	}
+	for _, bad := range []string{"", "zz", strings.Repeat("ab", 31)} {
+		if _, err := NormalizePublicKey(bad); !errors.Is(err, ErrInvalidPublicKey) {
+			t.Fatalf("expected ErrInvalidPublicKey for %q, got %v", bad, err)
+		}
+	}
+}
+
+func TestCheckSigner(t *testing.T) {
+	tx := Transaction{TxID: "01H123", Collection: "users", DocID: "user_1"}
+	if err := CheckSigner(nil, tx); err != nil {
+		t.Fatalf("expected no policy to accept unsigned txs, got %v", err)
+	}
+	if err := CheckSigner([]string{"aa"}, tx); !errors.Is(err, ErrUnsignedTx) {
+		t.Fatalf("expected ErrUnsignedTx, got %v", err)
+	}
+	tx.Signer = "bb"
+	if err := CheckSigner([]string{"aa"}, tx); !errors.Is(err, ErrUntrustedSigner) {
+		t.Fatalf("expected ErrUntrustedSigner, got %v", err)
+	}
+	if err := CheckSigner([]string{"aa", "bb"}, tx); err != nil {
+		t.Fatalf("expected a trusted signer to pass, got %v", err)
+	}
+}
@@ -5,4 +5,10 @@ const (
 	TxDirName      = "tx"
 	TxFileExt      = ".txpb"
 	TxCompactFile  = "current" + TxFileExt
+
+	CollectionsRoot        = "collections"
+	CollectionSchemaFile   = "schema.json"
+	CollectionIndexesFile  = "indexes.json"
+	CollectionFullTextFile = "fulltext.json"
+	CollectionKeysFile     = "trusted_keys.json"
 )
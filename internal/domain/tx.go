@@ -21,6 +21,7 @@ var (
 	ErrMissingPayload     = errors.New("payload is required")
 	ErrUnexpectedPayload  = errors.New("payload is not allowed")
 	ErrMultiplePayloads   = errors.New("multiple payloads provided")
+	ErrUnexpectedParents  = errors.New("merge parents are only allowed on merge transactions")
 )
 
 type Transaction struct {
@@ -33,6 +34,10 @@ type Transaction struct {
 	Patch         []byte
 	ParentHash    string
 	SchemaVersion string
+	MergeParents  []string
+	// Signer is the hex Ed25519 public key of a signed envelope, set by the
+	// decoder. It is empty for unsigned transactions and ignored on encode.
+	Signer string
 }
 
 func (op TxOp) IsValid() bool {
@@ -54,6 +59,20 @@ func (op TxOp) String() string {
 	}
 }
 
+// Parents returns the first parent followed by any merge parents.
+func (t Transaction) Parents() []string {
+	parents := make([]string, 0, 1+len(t.MergeParents))
+	if t.ParentHash != "" {
+		parents = append(parents, t.ParentHash)
+	}
+	for _, parent := range t.MergeParents {
+		if parent != "" {
+			parents = append(parents, parent)
+		}
+	}
+	return parents
+}
+
 func (t Transaction) Validate() error {
 	if t.TxID == "" {
 		return ErrTxIDRequired
@@ -73,6 +92,9 @@ func (t Transaction) Validate() error {
 	if len(t.Snapshot) > 0 && len(t.Patch) > 0 {
 		return ErrMultiplePayloads
 	}
+	if len(t.MergeParents) > 0 && t.Op != TxOpMerge {
+		return ErrUnexpectedParents
+	}
 
 	switch t.Op {
 	case TxOpPut:
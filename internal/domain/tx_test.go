@@ -74,3 +74,19 @@ func TestTransactionValidateOp(t *testing.T) {
 		t.Fatalf("expected ErrInvalidOp, got %v", err)
 	}
 }
+
+func TestTransactionValidateRejectsMergeParentsOnPut(t *testing.T) {
+	tx := Transaction{
+		TxID:         "01H123",
+		Timestamp:    1,
+		Collection:   "users",
+		DocID:        "user_1",
+		Op:           TxOpPut,
+		Snapshot:     []byte(`{"name":"Ada"}`),
+		MergeParents: []string{"abc"},
+	}
+
+	if err := tx.Validate(); err != ErrUnexpectedParents {
+		t.Fatalf("expected ErrUnexpectedParents, got %v", err)
+	}
+}
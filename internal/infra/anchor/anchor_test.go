@@ -0,0 +1,143 @@
+package anchor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+)
+
+func TestFileLogDetectsEditedEntries(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "anchors.log")
+	log := NewFileLog(path)
+	log.now = ticker(time.Unix(100, 0))
+
+	if _, err := log.ReadAnchors(ctx, "repo"); err == nil {
+		t.Fatal("expected a missing log to fail")
+	}
+	for _, commit := range []string{"c1", "c2", "c3"} {
+		if _, err := log.Anchor(ctx, "repo", commit); err != nil {
+			t.Fatalf("Anchor returned error: %v", err)
+		}
+	}
+
+	anchors, err := log.ReadAnchors(ctx, "repo")
+	if err != nil {
+		t.Fatalf("ReadAnchors returned error: %v", err)
+	}
+	if len(anchors) != 3 || anchors[2].Commit != "c3" || !anchors[1].Time.Equal(time.Unix(101, 0)) {
+		t.Fatalf("unexpected anchors %+v", anchors)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if err := os.WriteFile(path, bytes.Replace(data, []byte(`"c2"`), []byte(`"c9"`), 1), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	anchors, err = log.ReadAnchors(ctx, "repo")
+	if !errors.Is(err, integrityapp.ErrAnchorMismatch) {
+		t.Fatalf("expected ErrAnchorMismatch after an edit, got %v", err)
+	}
+	if len(anchors) != 2 || anchors[1].Commit != "c9" {
+		t.Fatalf("expected the entries up to the break, got %+v", anchors)
+	}
+}
+
+// ticker returns a clock that starts at start and advances a second per
+// call.
+func ticker(start time.Time) func() time.Time {
+	next := start
+	return func() time.Time {
+		now := next
+		next = next.Add(time.Second)
+		return now
+	}
+}
+
+func TestAuthorityChecksKeyAndReceiptLog(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "tsa.log")
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	authority := NewAuthority(private, path)
+	authority.log.now = ticker(time.Unix(0, 1700000000123456789))
+	var issued []integrityapp.Anchor
+	for _, commit := range []string{"c1", "c2", "c3"} {
+		anchor, err := authority.Anchor(ctx, "repo", commit)
+		if err != nil {
+			t.Fatalf("Anchor returned error: %v", err)
+		}
+		issued = append(issued, anchor)
+	}
+	if !issued[0].Time.Equal(time.Unix(0, 1700000000123456789)) || issued[0].Backend != "tsa" {
+		t.Fatalf("expected the authority's own time, got %+v", issued[0])
+	}
+
+	if _, err := NewAuthorityChecker("", path); err == nil {
+		t.Fatal("expected a checker without a trusted key to be refused")
+	}
+	trusting, err := NewAuthorityChecker(hex.EncodeToString(public), path)
+	if err != nil {
+		t.Fatalf("NewAuthorityChecker returned error: %v", err)
+	}
+	anchors, err := trusting.ReadAnchors(ctx, "repo")
+	if err != nil || len(anchors) != 3 || anchors[1].Commit != "c2" || !anchors[1].Time.Equal(issued[1].Time) {
+		t.Fatalf("expected every logged receipt to verify, got %+v (%v)", anchors, err)
+	}
+
+	other, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	distrusting, err := NewAuthorityChecker(hex.EncodeToString(other), path)
+	if err != nil {
+		t.Fatalf("NewAuthorityChecker returned error: %v", err)
+	}
+	if anchors, err := distrusting.ReadAnchors(ctx, "repo"); !errors.Is(err, integrityapp.ErrAnchorMismatch) || len(anchors) != 0 {
+		t.Fatalf("expected ErrAnchorMismatch for an untrusted key, got %+v (%v)", anchors, err)
+	}
+
+	// A rebuilt log whose chain holds but whose receipt was moved to another
+	// commit still fails on the signature.
+	forgedPath := filepath.Join(t.TempDir(), "forged.log")
+	forged := NewFileLog(forgedPath)
+	if _, err := forged.append("c1", issued[0].Time, issued[0].Receipt); err != nil {
+		t.Fatalf("append returned error: %v", err)
+	}
+	if _, err := forged.append("c9", issued[1].Time, issued[1].Receipt); err != nil {
+		t.Fatalf("append returned error: %v", err)
+	}
+	forgedChecker, err := NewAuthorityChecker(hex.EncodeToString(public), forgedPath)
+	if err != nil {
+		t.Fatalf("NewAuthorityChecker returned error: %v", err)
+	}
+	if anchors, err := forgedChecker.ReadAnchors(ctx, "repo"); !errors.Is(err, integrityapp.ErrAnchorMismatch) || len(anchors) != 1 || anchors[0].Commit != "c1" {
+		t.Fatalf("expected ErrAnchorMismatch for a receipt moved to another commit, got %+v (%v)", anchors, err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	if err := os.WriteFile(path, append(append([]byte(nil), lines[0]...), lines[2]...), 0o644); err != nil {
+		t.Fatalf("WriteFile returned error: %v", err)
+	}
+	anchors, err = trusting.ReadAnchors(ctx, "repo")
+	if !errors.Is(err, integrityapp.ErrAnchorMismatch) || len(anchors) != 1 {
+		t.Fatalf("expected a dropped receipt to break the log, got %+v (%v)", anchors, err)
+	}
+}
@@ -0,0 +1,130 @@
+package anchor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+// Authority stands in for an RFC 3161 timestamp authority: it signs the
+// commit hash and the anchor time with its own Ed25519 key, and a receipt
+// is only as trustworthy as the key that is expected to have signed it. The
+// authority keeps its receipts in a hash-chained log of its own, outside
+// the repository, so dropping one from the ledger's copy changes nothing.
+type Authority struct {
+	key     ed25519.PrivateKey
+	trusted string
+	log     *FileLog
+}
+
+// NewAuthority signs anchors with key and appends each receipt to the log at
+// receiptsPath.
+func NewAuthority(key ed25519.PrivateKey, receiptsPath string) *Authority {
+	return &Authority{key: key, log: NewFileLog(receiptsPath)}
+}
+
+// NewAuthorityChecker reads the receipts logged at receiptsPath and accepts
+// those signed by trustedKey, an Ed25519 public key in hex. The key is
+// required: the one recorded in a receipt proves nothing about who signed
+// it.
+func NewAuthorityChecker(trustedKey, receiptsPath string) (*Authority, error) {
+	key, err := domain.NormalizePublicKey(trustedKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Authority{trusted: key, log: NewFileLog(receiptsPath)}, nil
+}
+
+type authorityReceipt struct {
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+func (a *Authority) Name() string {
+	return "tsa"
+}
+
+// Anchor signs commit with the authority's own time and logs the receipt.
+func (a *Authority) Anchor(ctx context.Context, _, commit string) (integrityapp.Anchor, error) {
+	if a.key == nil {
+		return integrityapp.Anchor{}, integrityapp.ErrAnchorBackendRequired
+	}
+	at := a.log.now().UTC()
+	signature := ed25519.Sign(a.key, timestampToken(commit, at))
+	receipt, err := json.Marshal(authorityReceipt{
+		PublicKey: hex.EncodeToString(a.key.Public().(ed25519.PublicKey)),
+		Signature: hex.EncodeToString(signature),
+	})
+	if err != nil {
+		return integrityapp.Anchor{}, err
+	}
+	if _, err := a.log.append(commit, at, receipt); err != nil {
+		return integrityapp.Anchor{}, err
+	}
+	return integrityapp.Anchor{Backend: a.Name(), Commit: commit, Time: at, Receipt: receipt}, nil
+}
+
+// ReadAnchors returns the logged anchors whose receipts the trusted key
+// signed. Receipts it cannot confirm, and a log broken by an edited or
+// dropped entry, are reported with ErrAnchorMismatch.
+func (a *Authority) ReadAnchors(ctx context.Context, repoPath string) ([]integrityapp.Anchor, error) {
+	if a.trusted == "" {
+		return nil, integrityapp.ErrAnchorBackendRequired
+	}
+	logged, err := a.log.ReadAnchors(ctx, repoPath)
+	if err != nil && !errors.Is(err, integrityapp.ErrAnchorMismatch) {
+		return nil, err
+	}
+
+	var anchors []integrityapp.Anchor
+	var bad []string
+	for _, anchor := range logged {
+		anchor.Backend = a.Name()
+		if err := a.checkReceipt(anchor); err != nil {
+			bad = append(bad, fmt.Sprintf("%s (%v)", anchor.Commit, err))
+			continue
+		}
+		anchors = append(anchors, anchor)
+	}
+	if len(bad) > 0 {
+		if err == nil {
+			err = integrityapp.ErrAnchorMismatch
+		}
+		return anchors, fmt.Errorf("%w; %d tsa receipt(s) do not verify: %s", err, len(bad), strings.Join(bad, ", "))
+	}
+	return anchors, err
+}
+
+func (a *Authority) checkReceipt(anchor integrityapp.Anchor) error {
+	var receipt authorityReceipt
+	if err := json.Unmarshal(anchor.Receipt, &receipt); err != nil {
+		return errors.New("unreadable receipt")
+	}
+	if receipt.PublicKey != a.trusted {
+		return fmt.Errorf("signed by untrusted key %s", receipt.PublicKey)
+	}
+	publicKey, err := hex.DecodeString(receipt.PublicKey)
+	if err != nil || len(publicKey) != ed25519.PublicKeySize {
+		return errors.New("bad public key")
+	}
+	signature, err := hex.DecodeString(receipt.Signature)
+	if err != nil || !ed25519.Verify(publicKey, timestampToken(anchor.Commit, anchor.Time), signature) {
+		return errors.New("signature does not cover this commit and time")
+	}
+	return nil
+}
+
+// timestampToken is what the authority signs: a fixed label, the commit and
+// the anchor time in Unix nanoseconds, one per line.
+func timestampToken(commit string, at time.Time) []byte {
+	return []byte("ledgerdb-anchor\n" + commit + "\n" + strconv.FormatInt(at.UnixNano(), 10) + "\n")
+}
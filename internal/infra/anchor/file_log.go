@@ -0,0 +1,152 @@
+package anchor
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+)
+
+// FileLog anchors to an append-only JSON lines file, typically on storage the
+// ledger's writers cannot modify. Each entry carries the SHA-256 of the line
+// before it, so editing or dropping an earlier entry breaks every later one.
+// It expects a single writer.
+type FileLog struct {
+	path string
+	now  func() time.Time
+}
+
+// NewFileLog appends to and reads back from path.
+func NewFileLog(path string) *FileLog {
+	return &FileLog{path: path, now: time.Now}
+}
+
+// logEntry is one line of the log. Receipt is only set by backends that
+// keep their own receipts in a log, like the timestamp authority.
+type logEntry struct {
+	Seq     int             `json:"seq"`
+	Commit  string          `json:"commit"`
+	Time    string          `json:"time"`
+	Prev    string          `json:"prev,omitempty"`
+	Receipt json.RawMessage `json:"receipt,omitempty"`
+}
+
+type fileReceipt struct {
+	Path string `json:"path"`
+	Seq  int    `json:"seq"`
+	Hash string `json:"hash"`
+}
+
+func (l *FileLog) Name() string {
+	return "file"
+}
+
+// Anchor appends commit with the time it was written to the log.
+func (l *FileLog) Anchor(ctx context.Context, _, commit string) (integrityapp.Anchor, error) {
+	at := l.now().UTC()
+	receipt, err := l.append(commit, at, nil)
+	if err != nil {
+		return integrityapp.Anchor{}, err
+	}
+	return integrityapp.Anchor{Backend: l.Name(), Commit: commit, Time: at, Receipt: receipt}, nil
+}
+
+func (l *FileLog) append(commit string, at time.Time, receipt []byte) ([]byte, error) {
+	if l.path == "" {
+		return nil, integrityapp.ErrAnchorBackendRequired
+	}
+	path, err := filepath.Abs(l.path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve anchor log: %w", err)
+	}
+	lines, err := readLines(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	entry := logEntry{Seq: len(lines), Commit: commit, Time: at.UTC().Format(time.RFC3339Nano), Receipt: receipt}
+	if len(lines) > 0 {
+		entry.Prev = lineHash(lines[len(lines)-1])
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return nil, fmt.Errorf("encode anchor entry: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open anchor log: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("write anchor log: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("sync anchor log: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("close anchor log: %w", err)
+	}
+	return json.Marshal(fileReceipt{Path: path, Seq: entry.Seq, Hash: lineHash(line)})
+}
+
+// ReadAnchors returns every entry in the log. Entries must chain from the
+// first one; it stops at the first that does not, as the log was edited
+// there.
+func (l *FileLog) ReadAnchors(ctx context.Context, _ string) ([]integrityapp.Anchor, error) {
+	if l.path == "" {
+		return nil, integrityapp.ErrAnchorBackendRequired
+	}
+	lines, err := readLines(l.path)
+	if err != nil {
+		return nil, err
+	}
+
+	anchors := make([]integrityapp.Anchor, 0, len(lines))
+	prev := ""
+	for i, line := range lines {
+		var entry logEntry
+		if err := json.Unmarshal(line, &entry); err != nil || entry.Seq != i || entry.Prev != prev {
+			return anchors, fmt.Errorf("%w: %s is broken at entry %d", integrityapp.ErrAnchorMismatch, l.path, i)
+		}
+		at, err := time.Parse(time.RFC3339Nano, entry.Time)
+		if err != nil {
+			return anchors, fmt.Errorf("%w: entry %d of %s has a bad time", integrityapp.ErrAnchorMismatch, i, l.path)
+		}
+		anchors = append(anchors, integrityapp.Anchor{Backend: l.Name(), Commit: entry.Commit, Time: at, Receipt: entry.Receipt})
+		prev = lineHash(line)
+	}
+	return anchors, nil
+}
+
+func readLines(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read anchor log: %w", err)
+	}
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read anchor log: %w", err)
+	}
+	return lines, nil
+}
+
+func lineHash(line []byte) string {
+	sum := sha256.Sum256(bytes.TrimSuffix(line, []byte("\n")))
+	return hex.EncodeToString(sum[:])
+}
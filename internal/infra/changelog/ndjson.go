@@ -0,0 +1,125 @@
+package changelog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// OpenNDJSON opens (or creates) a change log with one JSON entry per line.
+func OpenNDJSON(path string) (*Store, error) {
+	log, err := openNDJSONLog(path)
+	if err != nil {
+		return nil, err
+	}
+	store, err := New(log)
+	if err != nil {
+		_ = log.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+type ndjsonLog struct {
+	file *os.File
+}
+
+func openNDJSONLog(path string) (*ndjsonLog, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errors.New("ndjson path required")
+	}
+	if dir := filepath.Dir(path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("create ndjson dir: %w", err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open ndjson log: %w", err)
+	}
+	return &ndjsonLog{file: file}, nil
+}
+
+// Load reads every complete batch and truncates a trailing partial one left
+// by an interrupted append.
+func (l *ndjsonLog) Load() ([]Entry, error) {
+	if _, err := l.file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek ndjson log: %w", err)
+	}
+	reader := bufio.NewReader(l.file)
+	var entries []Entry
+	var committed int
+	var offset, committedOffset int64
+	for lineNo := 1; ; lineNo++ {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read ndjson log: %w", err)
+		}
+		offset += int64(len(line))
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf("decode ndjson log line %d: %w", lineNo, err)
+		}
+		entries = append(entries, entry)
+		if entry.Kind == KindCheckpoint {
+			committed = len(entries)
+			committedOffset = offset
+		}
+	}
+
+	info, err := l.file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat ndjson log: %w", err)
+	}
+	if info.Size() != committedOffset {
+		if err := l.file.Truncate(committedOffset); err != nil {
+			return nil, fmt.Errorf("truncate ndjson log: %w", err)
+		}
+	}
+	if _, err := l.file.Seek(committedOffset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek ndjson log: %w", err)
+	}
+	return entries[:committed], nil
+}
+
+func (l *ndjsonLog) Append(entries []Entry) error {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return fmt.Errorf("encode ndjson entry: %w", err)
+		}
+	}
+	offset, err := l.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("seek ndjson log: %w", err)
+	}
+	if _, err := l.file.Write(buf.Bytes()); err != nil {
+		return l.rollback(offset, fmt.Errorf("append ndjson log: %w", err))
+	}
+	if err := l.file.Sync(); err != nil {
+		return l.rollback(offset, fmt.Errorf("sync ndjson log: %w", err))
+	}
+	return nil
+}
+
+// rollback drops a partially written batch so the next append starts on a
+// batch boundary.
+func (l *ndjsonLog) rollback(offset int64, cause error) error {
+	_ = l.file.Truncate(offset)
+	_, _ = l.file.Seek(offset, io.SeekStart)
+	return cause
+}
+
+func (l *ndjsonLog) Close() error {
+	return l.file.Close()
+}
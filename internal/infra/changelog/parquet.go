@@ -0,0 +1,118 @@
+package changelog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	parquetSegmentPrefix = "changes-"
+	parquetSegmentSuffix = ".parquet"
+	parquetTempSuffix    = ".tmp"
+)
+
+// OpenParquet opens (or creates) a change log stored as one Parquet file per
+// committed batch under dir, readable with read_parquet('dir/*.parquet').
+func OpenParquet(dir string) (*Store, error) {
+	log, err := openParquetLog(dir)
+	if err != nil {
+		return nil, err
+	}
+	return New(log)
+}
+
+type parquetLog struct {
+	dir  string
+	next int
+}
+
+func openParquetLog(dir string) (*parquetLog, error) {
+	if strings.TrimSpace(dir) == "" {
+		return nil, errors.New("parquet directory required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create parquet dir: %w", err)
+	}
+	return &parquetLog{dir: dir, next: 1}, nil
+}
+
+// Load reads the segments in order. Segments are renamed into place only once
+// complete, so leftover temporary files are incomplete batches.
+func (l *parquetLog) Load() ([]Entry, error) {
+	dirEntries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, fmt.Errorf("read parquet dir: %w", err)
+	}
+	var segments []string
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if strings.HasSuffix(name, parquetTempSuffix) {
+			if err := os.Remove(filepath.Join(l.dir, name)); err != nil {
+				return nil, fmt.Errorf("remove partial segment: %w", err)
+			}
+			continue
+		}
+		if strings.HasPrefix(name, parquetSegmentPrefix) && strings.HasSuffix(name, parquetSegmentSuffix) {
+			segments = append(segments, name)
+		}
+	}
+	sort.Strings(segments)
+
+	var entries []Entry
+	for _, name := range segments {
+		var seq int
+		if _, err := fmt.Sscanf(strings.TrimPrefix(name, parquetSegmentPrefix), "%d", &seq); err != nil {
+			return nil, fmt.Errorf("parse segment name %s: %w", name, err)
+		}
+		rows, err := parquet.ReadFile[Entry](filepath.Join(l.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("read segment %s: %w", name, err)
+		}
+		entries = append(entries, rows...)
+		if seq >= l.next {
+			l.next = seq + 1
+		}
+	}
+	return entries, nil
+}
+
+func (l *parquetLog) Append(entries []Entry) error {
+	name := fmt.Sprintf("%s%020d%s", parquetSegmentPrefix, l.next, parquetSegmentSuffix)
+	target := filepath.Join(l.dir, name)
+	temp := target + parquetTempSuffix
+
+	file, err := os.Create(temp)
+	if err != nil {
+		return fmt.Errorf("create segment: %w", err)
+	}
+	if err := parquet.Write(file, entries); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("write segment: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("sync segment: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("close segment: %w", err)
+	}
+	if err := os.Rename(temp, target); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("publish segment: %w", err)
+	}
+	l.next++
+	return nil
+}
+
+func (l *parquetLog) Close() error {
+	return nil
+}
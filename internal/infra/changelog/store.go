@@ -0,0 +1,236 @@
+package changelog
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+const (
+	KindChange     = "change"
+	KindCheckpoint = "checkpoint"
+	KindReset      = "reset"
+)
+
+// Entry is one record of the change log. Every committed batch ends with a
+// checkpoint entry carrying the sync cursor; anything after the last
+// checkpoint is an incomplete batch and is discarded on open.
+type Entry struct {
+	Kind          string          `json:"kind" parquet:"kind"`
+	Collection    string          `json:"collection,omitempty" parquet:"collection,optional"`
+	DocID         string          `json:"doc_id,omitempty" parquet:"doc_id,optional"`
+	TxHash        string          `json:"tx_hash,omitempty" parquet:"tx_hash,optional"`
+	TxID          string          `json:"tx_id,omitempty" parquet:"tx_id,optional"`
+	Op            string          `json:"op,omitempty" parquet:"op,optional"`
+	SchemaVersion string          `json:"schema_version,omitempty" parquet:"schema_version,optional"`
+	UpdatedAt     int64           `json:"updated_at,omitempty" parquet:"updated_at"`
+	Deleted       bool            `json:"deleted,omitempty" parquet:"deleted"`
+	Payload       json.RawMessage `json:"payload,omitempty" parquet:"payload,optional,json"`
+	LastCommit    string          `json:"last_commit,omitempty" parquet:"last_commit,optional"`
+	LastStateTree string          `json:"last_state_tree,omitempty" parquet:"last_state_tree,optional"`
+}
+
+// Log persists batches of entries. Append must make a batch visible to Load
+// only once it has been written completely.
+type Log interface {
+	Load() ([]Entry, error)
+	Append(entries []Entry) error
+	Close() error
+}
+
+// Store is an append-only index sink. It keeps the latest record of every
+// document in memory so patches can be applied, and rebuilds that view by
+// replaying the log on open.
+type Store struct {
+	log   Log
+	state indexapp.State
+	docs  map[string]map[string]indexapp.DocRecord
+}
+
+func New(log Log) (*Store, error) {
+	entries, err := log.Load()
+	if err != nil {
+		return nil, err
+	}
+	store := &Store{log: log, docs: make(map[string]map[string]indexapp.DocRecord)}
+	for _, entry := range entries {
+		switch entry.Kind {
+		case KindChange:
+			store.collection(entry.Collection)[entry.DocID] = entry.record()
+		case KindCheckpoint:
+			store.state = indexapp.State{LastCommit: entry.LastCommit, LastStateTree: entry.LastStateTree}
+		case KindReset:
+			store.docs = make(map[string]map[string]indexapp.DocRecord)
+			store.state = indexapp.State{}
+		}
+	}
+	return store, nil
+}
+
+func (s *Store) Close() error {
+	if s == nil || s.log == nil {
+		return nil
+	}
+	return s.log.Close()
+}
+
+func (s *Store) GetState(ctx context.Context) (indexapp.State, error) {
+	return s.state, nil
+}
+
+func (s *Store) Begin(ctx context.Context) (indexapp.StoreTx, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return &storeTx{store: s, docs: make(map[string]map[string]indexapp.DocRecord)}, nil
+}
+
+// Reset appends a reset marker rather than rewriting the log, so consumers
+// see where the projection restarted.
+func (s *Store) Reset(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.log.Append([]Entry{{Kind: KindReset}, {Kind: KindCheckpoint}}); err != nil {
+		return err
+	}
+	s.docs = make(map[string]map[string]indexapp.DocRecord)
+	s.state = indexapp.State{}
+	return nil
+}
+
+func (s *Store) collection(name string) map[string]indexapp.DocRecord {
+	docs, ok := s.docs[name]
+	if !ok {
+		docs = make(map[string]indexapp.DocRecord)
+		s.docs[name] = docs
+	}
+	return docs
+}
+
+type storeTx struct {
+	store   *Store
+	docs    map[string]map[string]indexapp.DocRecord
+	entries []Entry
+	state   *indexapp.State
+}
+
+func (s *storeTx) EnsureCollection(ctx context.Context, collection string) (string, error) {
+	if _, ok := s.docs[collection]; !ok {
+		s.docs[collection] = make(map[string]indexapp.DocRecord)
+	}
+	return collection, nil
+}
+
+func (s *storeTx) GetDoc(ctx context.Context, collection, docID string) (indexapp.DocRecord, bool, error) {
+	if record, ok := s.docs[collection][docID]; ok {
+		return record, true, nil
+	}
+	record, ok := s.store.docs[collection][docID]
+	return record, ok, nil
+}
+
+func (s *storeTx) UpsertDoc(ctx context.Context, collection string, record indexapp.DocRecord) error {
+	if _, err := s.EnsureCollection(ctx, collection); err != nil {
+		return err
+	}
+	s.docs[collection][record.DocID] = record
+	s.entries = append(s.entries, Entry{
+		Kind:          KindChange,
+		Collection:    collection,
+		DocID:         record.DocID,
+		TxHash:        record.TxHash,
+		TxID:          record.TxID,
+		Op:            record.Op,
+		SchemaVersion: record.SchemaVersion,
+		UpdatedAt:     record.UpdatedAt,
+		Deleted:       record.Deleted,
+		Payload:       json.RawMessage(record.Payload),
+	})
+	return nil
+}
+
+// AppendHistory is a no-op: every applied transaction is already a change
+// entry in the log.
+func (s *storeTx) AppendHistory(ctx context.Context, collection string, record indexapp.HistoryRecord) error {
+	return nil
+}
+
+func (s *storeTx) SetState(ctx context.Context, state indexapp.State) error {
+	s.state = &state
+	return nil
+}
+
+func (s *storeTx) Collections(ctx context.Context) ([]string, error) {
+	names := make(map[string]struct{}, len(s.store.docs)+len(s.docs))
+	for name := range s.store.docs {
+		names[name] = struct{}{}
+	}
+	for name := range s.docs {
+		names[name] = struct{}{}
+	}
+	collections := make([]string, 0, len(names))
+	for name := range names {
+		collections = append(collections, name)
+	}
+	sort.Strings(collections)
+	return collections, nil
+}
+
+// Declared indexes and full-text fields are materialized by the SQLite sink
+// only; consumers of the log build their own.
+func (s *storeTx) EnsureIndexes(ctx context.Context, collection string, fields []string) (indexapp.IndexChanges, error) {
+	return indexapp.IndexChanges{}, nil
+}
+
+func (s *storeTx) EnsureFullText(ctx context.Context, collection string, fields []string) (bool, error) {
+	return false, nil
+}
+
+func (s *storeTx) UpdateFullText(ctx context.Context, collection, docID string) error {
+	return nil
+}
+
+func (s *storeTx) Commit() error {
+	if len(s.entries) == 0 && s.state == nil {
+		return nil
+	}
+	state := s.store.state
+	if s.state != nil {
+		state = *s.state
+	}
+	entries := append(s.entries, Entry{Kind: KindCheckpoint, LastCommit: state.LastCommit, LastStateTree: state.LastStateTree})
+	if err := s.store.log.Append(entries); err != nil {
+		return err
+	}
+	for collection, docs := range s.docs {
+		target := s.store.collection(collection)
+		for docID, record := range docs {
+			target[docID] = record
+		}
+	}
+	s.store.state = state
+	return nil
+}
+
+func (s *storeTx) Rollback() error {
+	s.docs = nil
+	s.entries = nil
+	s.state = nil
+	return nil
+}
+
+func (e Entry) record() indexapp.DocRecord {
+	return indexapp.DocRecord{
+		DocID:         e.DocID,
+		Payload:       []byte(e.Payload),
+		TxHash:        e.TxHash,
+		TxID:          e.TxID,
+		Op:            e.Op,
+		SchemaVersion: e.SchemaVersion,
+		UpdatedAt:     e.UpdatedAt,
+		Deleted:       e.Deleted,
+	}
+}
@@ -0,0 +1,204 @@
+package changelog
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+func commitBatch(t *testing.T, store *Store, state indexapp.State, records ...indexapp.DocRecord) {
+	t.Helper()
+	ctx := context.Background()
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	for _, record := range records {
+		if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+			t.Fatalf("EnsureCollection returned error: %v", err)
+		}
+		if err := tx.UpsertDoc(ctx, "tasks", record); err != nil {
+			t.Fatalf("UpsertDoc returned error: %v", err)
+		}
+	}
+	if err := tx.SetState(ctx, state); err != nil {
+		t.Fatalf("SetState returned error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+}
+
+func readDoc(t *testing.T, store *Store, docID string) (indexapp.DocRecord, bool) {
+	t.Helper()
+	tx, err := store.Begin(context.Background())
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	record, found, err := tx.GetDoc(context.Background(), "tasks", docID)
+	if err != nil {
+		t.Fatalf("GetDoc returned error: %v", err)
+	}
+	return record, found
+}
+
+func TestNDJSONStoreReplaysCommittedBatches(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "out", "changes.ndjson")
+	store, err := OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("OpenNDJSON returned error: %v", err)
+	}
+	commitBatch(t, store, indexapp.State{LastCommit: "c1"},
+		indexapp.DocRecord{DocID: "t1", Payload: []byte(`{"status":"open"}`), TxHash: "h1", Op: "put"},
+	)
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	if err := tx.UpsertDoc(ctx, "tasks", indexapp.DocRecord{DocID: "t2", Payload: []byte(`{}`), TxHash: "h2", Op: "put"}); err != nil {
+		t.Fatalf("UpsertDoc returned error: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback returned error: %v", err)
+	}
+	if _, found := readDoc(t, store, "t2"); found {
+		t.Fatalf("expected a rolled back doc to be invisible")
+	}
+	commitBatch(t, store, indexapp.State{LastCommit: "c2", LastStateTree: "s2"},
+		indexapp.DocRecord{DocID: "t1", Payload: []byte(`{"status":"done"}`), TxHash: "h3", Op: "patch"},
+	)
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	if _, err := file.WriteString(`{"kind":"change","collection":"tasks","doc_id":"t9"}` + "\n" + `{"kind":"chan`); err != nil {
+		t.Fatalf("write torn batch: %v", err)
+	}
+	_ = file.Close()
+
+	store, err = OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	defer store.Close()
+	state, err := store.GetState(ctx)
+	if err != nil {
+		t.Fatalf("GetState returned error: %v", err)
+	}
+	if state.LastCommit != "c2" || state.LastStateTree != "s2" {
+		t.Fatalf("unexpected state after reopen: %+v", state)
+	}
+	record, found := readDoc(t, store, "t1")
+	if !found || string(record.Payload) != `{"status":"done"}` || record.TxHash != "h3" {
+		t.Fatalf("unexpected t1 after reopen: %+v", record)
+	}
+	if _, found := readDoc(t, store, "t9"); found {
+		t.Fatalf("expected the torn batch to be discarded")
+	}
+
+	file, err = os.Open(path)
+	if err != nil {
+		t.Fatalf("open log: %v", err)
+	}
+	defer file.Close()
+	var kinds []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("expected every line to be JSON: %v", err)
+		}
+		kinds = append(kinds, entry.Kind)
+	}
+	want := []string{KindChange, KindCheckpoint, KindChange, KindCheckpoint}
+	if len(kinds) != len(want) {
+		t.Fatalf("expected %v after truncation, got %v", want, kinds)
+	}
+	for i := range want {
+		if kinds[i] != want[i] {
+			t.Fatalf("expected %v after truncation, got %v", want, kinds)
+		}
+	}
+}
+
+func TestNDJSONStoreReset(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "changes.ndjson")
+	store, err := OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("OpenNDJSON returned error: %v", err)
+	}
+	commitBatch(t, store, indexapp.State{LastCommit: "c1"},
+		indexapp.DocRecord{DocID: "t1", Payload: []byte(`{}`), TxHash: "h1", Op: "put"},
+	)
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset returned error: %v", err)
+	}
+	_ = store.Close()
+
+	store, err = OpenNDJSON(path)
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	defer store.Close()
+	if state, _ := store.GetState(ctx); state.LastCommit != "" {
+		t.Fatalf("expected the reset to clear the checkpoint, got %+v", state)
+	}
+	if _, found := readDoc(t, store, "t1"); found {
+		t.Fatalf("expected the reset to clear documents")
+	}
+}
+
+func TestParquetStoreReplaysSegments(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "parquet")
+	store, err := OpenParquet(dir)
+	if err != nil {
+		t.Fatalf("OpenParquet returned error: %v", err)
+	}
+	commitBatch(t, store, indexapp.State{LastCommit: "c1"},
+		indexapp.DocRecord{DocID: "t1", Payload: []byte(`{"status":"open"}`), TxHash: "h1", Op: "put", UpdatedAt: 10},
+	)
+	commitBatch(t, store, indexapp.State{LastCommit: "c2"},
+		indexapp.DocRecord{DocID: "t1", TxHash: "h2", Op: "delete", UpdatedAt: 20, Deleted: true},
+		indexapp.DocRecord{DocID: "t2", Payload: []byte(`{"status":"open"}`), TxHash: "h3", Op: "put", UpdatedAt: 30},
+	)
+	if err := os.WriteFile(filepath.Join(dir, "changes-00000000000000000003.parquet.tmp"), []byte("partial"), 0o644); err != nil {
+		t.Fatalf("write partial segment: %v", err)
+	}
+
+	store, err = OpenParquet(dir)
+	if err != nil {
+		t.Fatalf("reopen returned error: %v", err)
+	}
+	if state, _ := store.GetState(ctx); state.LastCommit != "c2" {
+		t.Fatalf("unexpected state after reopen: %+v", state)
+	}
+	if record, found := readDoc(t, store, "t1"); !found || !record.Deleted || record.UpdatedAt != 20 {
+		t.Fatalf("unexpected t1 after reopen: %+v", record)
+	}
+	if record, found := readDoc(t, store, "t2"); !found || string(record.Payload) != `{"status":"open"}` {
+		t.Fatalf("unexpected t2 after reopen: %+v", record)
+	}
+
+	commitBatch(t, store, indexapp.State{LastCommit: "c3"})
+	segments, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatalf("glob segments: %v", err)
+	}
+	if len(segments) != 3 || filepath.Base(segments[2]) != "changes-00000000000000000003.parquet" {
+		t.Fatalf("expected three published segments and no temp files, got %v", segments)
+	}
+}
@@ -0,0 +1,132 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+)
+
+// VerifyCheckpoint stores integrity verification progress as JSON in a single
+// file, replaced atomically on every save.
+type VerifyCheckpoint struct {
+	path string
+}
+
+func NewVerifyCheckpoint(path string) *VerifyCheckpoint {
+	return &VerifyCheckpoint{path: path}
+}
+
+type verifyProgressFile struct {
+	Snapshot   string            `json:"snapshot"`
+	Deep       bool              `json:"deep"`
+	State      bool              `json:"state"`
+	LastStream string            `json:"last_stream"`
+	Streams    int               `json:"streams"`
+	Valid      int               `json:"valid"`
+	Issues     []verifyIssueFile `json:"issues,omitempty"`
+}
+
+type verifyIssueFile struct {
+	StreamPath string `json:"stream_path,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	DocID      string `json:"doc_id,omitempty"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+}
+
+func (c *VerifyCheckpoint) Load(ctx context.Context) (integrityapp.Progress, bool, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return integrityapp.Progress{}, false, nil
+	}
+	if err != nil {
+		return integrityapp.Progress{}, false, fmt.Errorf("read verify checkpoint: %w", err)
+	}
+
+	var raw verifyProgressFile
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return integrityapp.Progress{}, false, fmt.Errorf("parse verify checkpoint %s: %w", c.path, err)
+	}
+	progress := integrityapp.Progress{
+		Snapshot:   raw.Snapshot,
+		Deep:       raw.Deep,
+		State:      raw.State,
+		LastStream: raw.LastStream,
+		Streams:    raw.Streams,
+		Valid:      raw.Valid,
+	}
+	for _, issue := range raw.Issues {
+		progress.Issues = append(progress.Issues, integrityapp.Issue{
+			StreamPath: issue.StreamPath,
+			Collection: issue.Collection,
+			DocID:      issue.DocID,
+			Code:       issue.Code,
+			Message:    issue.Message,
+		})
+	}
+	return progress, true, nil
+}
+
+func (c *VerifyCheckpoint) Save(ctx context.Context, progress integrityapp.Progress) error {
+	raw := verifyProgressFile{
+		Snapshot:   progress.Snapshot,
+		Deep:       progress.Deep,
+		State:      progress.State,
+		LastStream: progress.LastStream,
+		Streams:    progress.Streams,
+		Valid:      progress.Valid,
+	}
+	for _, issue := range progress.Issues {
+		raw.Issues = append(raw.Issues, verifyIssueFile{
+			StreamPath: issue.StreamPath,
+			Collection: issue.Collection,
+			DocID:      issue.DocID,
+			Code:       issue.Code,
+			Message:    issue.Message,
+		})
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("encode verify checkpoint: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("create verify checkpoint dir: %w", err)
+	}
+	temp := c.path + ".tmp"
+	file, err := os.Create(temp)
+	if err != nil {
+		return fmt.Errorf("write verify checkpoint: %w", err)
+	}
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("write verify checkpoint: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("sync verify checkpoint: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("close verify checkpoint: %w", err)
+	}
+	if err := os.Rename(temp, c.path); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("publish verify checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (c *VerifyCheckpoint) Clear(ctx context.Context) error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove verify checkpoint: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package gitrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/storage"
+)
+
+// anchorsRefName holds one commit per saved anchor, outside main so that
+// rewriting main does not rewrite the receipts.
+const anchorsRefName = "refs/ledgerdb/anchors"
+
+type anchorFile struct {
+	Backend string `json:"backend"`
+	Commit  string `json:"commit"`
+	Time    string `json:"time"`
+	Receipt []byte `json:"receipt"`
+}
+
+func (s *Store) LoadMainHead(ctx context.Context, repoPath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("open git repo: %w", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("read main ref: %w", err)
+	}
+	return ref.Hash().String(), nil
+}
+
+// SaveAnchor commits the anchor as a new file on the anchors ref. Files are
+// named by anchor time so the tree lists them oldest first.
+func (s *Store) SaveAnchor(ctx context.Context, repoPath string, anchor integrityapp.Anchor) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("open git repo: %w", err)
+	}
+
+	data, err := json.Marshal(anchorFile{
+		Backend: anchor.Backend,
+		Commit:  anchor.Commit,
+		Time:    anchor.Time.UTC().Format(time.RFC3339Nano),
+		Receipt: anchor.Receipt,
+	})
+	if err != nil {
+		return fmt.Errorf("encode anchor: %w", err)
+	}
+	blobHash, err := writeBlob(repo.Storer, append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%020d_%s.json", anchor.Time.UnixNano(), anchor.Backend)
+	message := fmt.Sprintf("ledgerdb anchor %s %s", anchor.Backend, anchor.Commit)
+
+	refName := plumbing.ReferenceName(anchorsRefName)
+	for attempt := 0; attempt < casMaxRetries; attempt++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		baseRef, err := repo.Reference(refName, true)
+		if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return fmt.Errorf("read anchors ref: %w", err)
+		}
+		baseTreeHash := plumbing.ZeroHash
+		var parents []plumbing.Hash
+		if baseRef != nil {
+			base, err := repo.CommitObject(baseRef.Hash())
+			if err != nil {
+				return fmt.Errorf("read anchors commit: %w", err)
+			}
+			baseTreeHash = base.TreeHash
+			parents = []plumbing.Hash{base.Hash}
+		}
+
+		treeHash, err := updateTree(repo.Storer, baseTreeHash, name, blobHash, filemode.Regular)
+		if err != nil {
+			return err
+		}
+		commitHash, err := s.writeCommit(ctx, repoPath, repo, treeHash, parents, message)
+		if err != nil {
+			return err
+		}
+
+		newRef := plumbing.NewHashReference(refName, commitHash)
+		if err := repo.Storer.CheckAndSetReference(newRef, baseRef); err != nil {
+			if errors.Is(err, storage.ErrReferenceHasChanged) {
+				if err := sleepWithBackoff(ctx, attempt); err != nil {
+					return err
+				}
+				continue
+			}
+			return fmt.Errorf("update anchors ref: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("update anchors ref: %w", storage.ErrReferenceHasChanged)
+}
+
+func (s *Store) ListAnchors(ctx context.Context, repoPath string) ([]integrityapp.Anchor, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+	commit, err := referenceCommit(repo, plumbing.ReferenceName(anchorsRefName))
+	if err != nil || commit == nil {
+		return nil, err
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read anchors tree: %w", err)
+	}
+
+	anchors := make([]integrityapp.Anchor, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		if entry.Mode == filemode.Dir || !strings.HasSuffix(entry.Name, ".json") {
+			continue
+		}
+		data, err := readBlob(tree, entry)
+		if err != nil {
+			return nil, err
+		}
+		var raw anchorFile
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parse anchor %s: %w", entry.Name, err)
+		}
+		at, err := time.Parse(time.RFC3339Nano, raw.Time)
+		if err != nil {
+			return nil, fmt.Errorf("parse anchor %s: %w", entry.Name, err)
+		}
+		anchors = append(anchors, integrityapp.Anchor{
+			Backend: raw.Backend,
+			Commit:  raw.Commit,
+			Time:    at,
+			Receipt: raw.Receipt,
+		})
+	}
+	return anchors, nil
+}
+
+// IsMainAncestor treats a commit missing from the object store as not
+// contained: a rewrite followed by gc drops it.
+func (s *Store) IsMainAncestor(ctx context.Context, repoPath, commit string) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return false, fmt.Errorf("open git repo: %w", err)
+	}
+	head, err := referenceCommit(repo, plumbing.ReferenceName(mainRefName))
+	if err != nil || head == nil {
+		return false, err
+	}
+	anchored, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("read commit %s: %w", commit, err)
+	}
+	contained, err := anchored.IsAncestor(head)
+	if err != nil {
+		return false, fmt.Errorf("walk main history: %w", err)
+	}
+	return contained, nil
+}
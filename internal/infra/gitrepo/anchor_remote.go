@@ -0,0 +1,142 @@
+package gitrepo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+const anchoredRefPrefix = "refs/ledgerdb/anchored/"
+
+// RemoteAnchor anchors a commit by pushing it to a second Git remote under
+// refs/ledgerdb/anchored/<unix nanos>. The remote vouches for the anchor for
+// as long as the ref points at the commit.
+type RemoteAnchor struct {
+	url string
+}
+
+// NewRemoteAnchor pushes to and lists anchors from url.
+func NewRemoteAnchor(url string) *RemoteAnchor {
+	return &RemoteAnchor{url: strings.TrimSpace(url)}
+}
+
+type remoteReceipt struct {
+	URL string `json:"url"`
+	Ref string `json:"ref"`
+}
+
+func (a *RemoteAnchor) Name() string {
+	return "git"
+}
+
+// Anchor pushes commit under a ref named after the push time, which is the
+// time ReadAnchors reports back from the remote.
+func (a *RemoteAnchor) Anchor(ctx context.Context, repoPath, commit string) (integrityapp.Anchor, error) {
+	if a.url == "" {
+		return integrityapp.Anchor{}, integrityapp.ErrAnchorBackendRequired
+	}
+	url, err := absoluteRemoteURL(a.url)
+	if err != nil {
+		return integrityapp.Anchor{}, err
+	}
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return integrityapp.Anchor{}, fmt.Errorf("open git repo: %w", err)
+	}
+	auth, err := authForURL(url)
+	if err != nil {
+		return integrityapp.Anchor{}, err
+	}
+
+	at := time.Now().UTC()
+	ref := fmt.Sprintf("%s%d", anchoredRefPrefix, at.UnixNano())
+	remote := git.NewRemote(repo.Storer, &config.RemoteConfig{Name: "anchor", URLs: []string{url}})
+	err = remote.PushContext(ctx, &git.PushOptions{
+		RemoteName: "anchor",
+		RefSpecs:   []config.RefSpec{config.RefSpec(commit + ":" + ref)},
+		Auth:       auth,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return integrityapp.Anchor{}, fmt.Errorf("push anchor to %s: %w", url, err)
+	}
+	receipt, err := json.Marshal(remoteReceipt{URL: url, Ref: ref})
+	if err != nil {
+		return integrityapp.Anchor{}, err
+	}
+	return integrityapp.Anchor{Backend: a.Name(), Commit: commit, Time: at, Receipt: receipt}, nil
+}
+
+// ReadAnchors lists every anchored ref on the remote, oldest first, taking
+// each anchor's time from its ref name.
+func (a *RemoteAnchor) ReadAnchors(ctx context.Context, _ string) ([]integrityapp.Anchor, error) {
+	if a.url == "" {
+		return nil, integrityapp.ErrAnchorBackendRequired
+	}
+	url, err := absoluteRemoteURL(a.url)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := authForURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: "anchor", URLs: []string{url}})
+	refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+	if err != nil {
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("list anchor remote %s: %w", url, err)
+	}
+	var anchors []integrityapp.Anchor
+	var bad []string
+	for _, ref := range refs {
+		name := ref.Name().String()
+		if !strings.HasPrefix(name, anchoredRefPrefix) {
+			continue
+		}
+		nanos, err := strconv.ParseInt(strings.TrimPrefix(name, anchoredRefPrefix), 10, 64)
+		if err != nil {
+			bad = append(bad, name)
+			continue
+		}
+		anchors = append(anchors, integrityapp.Anchor{Backend: a.Name(), Commit: ref.Hash().String(), Time: time.Unix(0, nanos)})
+	}
+	sort.Slice(anchors, func(i, j int) bool {
+		return anchors[i].Time.Before(anchors[j].Time)
+	})
+	if len(bad) > 0 {
+		return anchors, fmt.Errorf("%w: %s has malformed anchor refs %s", integrityapp.ErrAnchorMismatch, url, strings.Join(bad, ", "))
+	}
+	return anchors, nil
+}
+
+// absoluteRemoteURL resolves a local path so that the receipt stays valid
+// from any working directory.
+func absoluteRemoteURL(url string) (string, error) {
+	ep, err := transport.NewEndpoint(url)
+	if err != nil {
+		return "", fmt.Errorf("parse remote URL: %w", err)
+	}
+	if ep.Protocol != "file" || strings.HasPrefix(url, "file://") {
+		return url, nil
+	}
+	abs, err := filepath.Abs(url)
+	if err != nil {
+		return "", fmt.Errorf("resolve remote path: %w", err)
+	}
+	return abs, nil
+}
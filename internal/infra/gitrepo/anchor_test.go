@@ -0,0 +1,106 @@
+package gitrepo
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestAnchorsSurviveMainRewrite(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT1", Timestamp: 1, Collection: "users", DocID: "doc1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	first, err := store.LoadMainHead(ctx, repoDir)
+	if err != nil || first == "" {
+		t.Fatalf("expected a main head, got %q (%v)", first, err)
+	}
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT2", Timestamp: 2, Collection: "users", DocID: "doc2", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	second, err := store.LoadMainHead(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("LoadMainHead returned error: %v", err)
+	}
+
+	at := time.Unix(0, 1700000000123456789).UTC()
+	saved := []integrityapp.Anchor{
+		{Backend: "file", Commit: first, Time: at, Receipt: []byte(`{"seq":0}`)},
+		{Backend: "file", Commit: second, Time: at.Add(time.Second), Receipt: []byte(`{"seq":1}`)},
+	}
+	for _, anchor := range saved {
+		if err := store.SaveAnchor(ctx, repoDir, anchor); err != nil {
+			t.Fatalf("SaveAnchor returned error: %v", err)
+		}
+	}
+
+	anchors, err := store.ListAnchors(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("ListAnchors returned error: %v", err)
+	}
+	if len(anchors) != 2 || anchors[1].Commit != second || !anchors[0].Time.Equal(at) || string(anchors[1].Receipt) != `{"seq":1}` {
+		t.Fatalf("unexpected anchors %+v", anchors)
+	}
+	for _, commit := range []string{first, second} {
+		if ok, err := store.IsMainAncestor(ctx, repoDir, commit); err != nil || !ok {
+			t.Fatalf("expected main to contain %s (%v)", commit, err)
+		}
+	}
+
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("PlainOpen returned error: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(mainRefName, plumbing.NewHash(first))); err != nil {
+		t.Fatalf("SetReference returned error: %v", err)
+	}
+	if ok, err := store.IsMainAncestor(ctx, repoDir, second); err != nil || ok {
+		t.Fatalf("expected the rewound main not to contain %s (%v)", second, err)
+	}
+	if anchors, err := store.ListAnchors(ctx, repoDir); err != nil || len(anchors) != 2 {
+		t.Fatalf("expected the anchors to survive the rewrite, got %d (%v)", len(anchors), err)
+	}
+}
+
+func TestRemoteAnchor(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT1", Timestamp: 1, Collection: "users", DocID: "doc1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	head, err := store.LoadMainHead(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("LoadMainHead returned error: %v", err)
+	}
+
+	remoteDir := filepath.Join(t.TempDir(), "anchors.git")
+	if _, err := git.PlainInit(remoteDir, true); err != nil {
+		t.Fatalf("PlainInit returned error: %v", err)
+	}
+	remote := NewRemoteAnchor(remoteDir)
+	if anchors, err := remote.ReadAnchors(ctx, repoDir); err != nil || len(anchors) != 0 {
+		t.Fatalf("expected an empty remote to hold no anchors, got %+v (%v)", anchors, err)
+	}
+	anchored, err := remote.Anchor(ctx, repoDir, head)
+	if err != nil {
+		t.Fatalf("Anchor returned error: %v", err)
+	}
+
+	anchors, err := remote.ReadAnchors(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("ReadAnchors returned error: %v", err)
+	}
+	if len(anchors) != 1 || anchors[0].Commit != head || !anchors[0].Time.Equal(anchored.Time) {
+		t.Fatalf("unexpected anchors %+v", anchors)
+	}
+}
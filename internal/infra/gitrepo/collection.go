@@ -3,42 +3,436 @@ package gitrepo
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
+
+	collectionapp "github.com/osvaldoandrade/ledgerdb/internal/app/collection"
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 )
 
-func (s *Store) WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes []string) error {
+func (s *Store) WriteSchema(ctx context.Context, repoPath, collection string, schema []byte, indexes, fullText, trustedKeys []string) error {
 	if err := ctx.Err(); err != nil {
 		return err
 	}
 
-	collectionDir := filepath.Join(repoPath, "collections", collection)
-	if err := os.MkdirAll(collectionDir, 0o755); err != nil {
-		return fmt.Errorf("create collection dir: %w", err)
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("open git repo: %w", err)
+	}
+
+	collectionPath := normalizeTreePath(domain.CollectionPath(collection))
+	schemaPath := path.Join(collectionPath, domain.CollectionSchemaFile)
+	indexPath := path.Join(collectionPath, domain.CollectionIndexesFile)
+	fullTextPath := path.Join(collectionPath, domain.CollectionFullTextFile)
+	keysPath := path.Join(collectionPath, domain.CollectionKeysFile)
+
+	schemaBlobHash, err := writeBlob(repo.Storer, schema)
+	if err != nil {
+		return err
+	}
+
+	indexBlobHash, err := writeFieldList(repo.Storer, indexes)
+	if err != nil {
+		return fmt.Errorf("encode indexes: %w", err)
+	}
+	fullTextBlobHash, err := writeFieldList(repo.Storer, fullText)
+	if err != nil {
+		return fmt.Errorf("encode full-text fields: %w", err)
+	}
+	var keysBlobHash plumbing.Hash
+	if trustedKeys != nil {
+		keysBlobHash, err = writeFieldList(repo.Storer, trustedKeys)
+		if err != nil {
+			return fmt.Errorf("encode trusted keys: %w", err)
+		}
+	}
+
+	message := fmt.Sprintf("ledgerdb schema %s", collection)
+	_, err = s.commitTree(ctx, repoPath, repo, message, func(baseTree *object.Tree, baseTreeHash plumbing.Hash) (plumbing.Hash, error) {
+		treeHash, err := updateTree(repo.Storer, baseTreeHash, schemaPath, schemaBlobHash, filemode.Regular)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		treeHash, err = setTreeFile(repo.Storer, treeHash, fullTextPath, fullTextBlobHash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if trustedKeys != nil {
+			treeHash, err = setTreeFile(repo.Storer, treeHash, keysPath, keysBlobHash)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+		treeHash, err = setTreeFile(repo.Storer, treeHash, indexPath, indexBlobHash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if baseTree != nil {
+			if current, err := baseTree.File(indexPath); err == nil && current.Hash == indexBlobHash {
+				return treeHash, nil
+			}
+		}
+		writer, err := s.newIndexWriter(repo.Storer, baseTree)
+		if err != nil || writer == nil {
+			return treeHash, err
+		}
+		return writer.rebuild(ctx, treeHash, collection, indexes)
+	})
+	return err
+}
+
+func (s *Store) LoadSchema(ctx context.Context, repoPath, collection string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadMainTree(repoPath)
+	if err != nil && !errors.Is(err, doc.ErrDocNotFound) {
+		return nil, err
+	}
+	if tree != nil {
+		schemaPath := path.Join(normalizeTreePath(domain.CollectionPath(collection)), domain.CollectionSchemaFile)
+		schema, err := readTreeFile(tree, schemaPath)
+		if err == nil {
+			return schema, nil
+		}
+		if !errors.Is(err, object.ErrFileNotFound) {
+			return nil, err
+		}
+	}
+
+	return loadLegacySchema(repoPath, collection)
+}
+
+func (s *Store) LoadIndexes(ctx context.Context, repoPath, collection string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadMainTree(repoPath)
+	if err != nil {
+		if errors.Is(err, doc.ErrDocNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return readDeclaredIndexes(tree, collection)
+}
+
+func (s *Store) LoadFullText(ctx context.Context, repoPath, collection string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadMainTree(repoPath)
+	if err != nil {
+		if errors.Is(err, doc.ErrDocNotFound) {
+			return nil, nil
+		}
+		return nil, err
 	}
+	return readCollectionFields(tree, collection, domain.CollectionFullTextFile)
+}
 
-	schemaPath := filepath.Join(collectionDir, "schema.json")
-	if err := os.WriteFile(schemaPath, schema, 0o644); err != nil {
-		return fmt.Errorf("write schema: %w", err)
+// LoadTrustedKeys returns the Ed25519 public keys allowed to sign
+// transactions in collection; none means unsigned writes are accepted.
+func (s *Store) LoadTrustedKeys(ctx context.Context, repoPath, collection string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	if len(indexes) > 0 {
-		indexPath := filepath.Join(collectionDir, "indexes.json")
-		payload, err := json.MarshalIndent(indexes, "", "  ")
+	tree, err := loadMainTree(repoPath)
+	if err != nil {
+		if errors.Is(err, doc.ErrDocNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return readCollectionFields(tree, collection, domain.CollectionKeysFile)
+}
+
+// ListTrustChanges reports every commit reachable from ref (main when empty)
+// that changed a collection's trusted keys, merges included. A merge is only
+// reported for collections whose keys match none of its parents, so taking
+// one side's keys as they were is not a change.
+func (s *Store) ListTrustChanges(ctx context.Context, repoPath, ref string) ([]integrityapp.TrustChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		ref = mainRefName
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: *hash})
+	if err != nil {
+		return nil, fmt.Errorf("read git log: %w", err)
+	}
+	defer iter.Close()
+
+	blobs := make(map[plumbing.Hash]map[string]plumbing.Hash)
+	keysOf := func(commit *object.Commit) (map[string]plumbing.Hash, *object.Tree, error) {
+		tree, err := commit.Tree()
 		if err != nil {
-			return fmt.Errorf("encode indexes: %w", err)
+			return nil, nil, fmt.Errorf("read commit tree: %w", err)
 		}
-		payload = append(payload, '\n')
-		if err := os.WriteFile(indexPath, payload, 0o644); err != nil {
-			return fmt.Errorf("write indexes: %w", err)
+		if keys, ok := blobs[tree.Hash]; ok {
+			return keys, tree, nil
 		}
-	} else {
-		indexPath := filepath.Join(collectionDir, "indexes.json")
-		if err := os.Remove(indexPath); err != nil && !os.IsNotExist(err) {
-			return fmt.Errorf("remove indexes: %w", err)
+		keys, err := trustedKeyBlobs(tree)
+		if err != nil {
+			return nil, nil, err
 		}
+		blobs[tree.Hash] = keys
+		return keys, tree, nil
 	}
 
-	return nil
+	var changes []integrityapp.TrustChange
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		current, tree, err := keysOf(commit)
+		if err != nil {
+			return err
+		}
+		var parents []map[string]plumbing.Hash
+		var firstTree *object.Tree
+		err = commit.Parents().ForEach(func(parent *object.Commit) error {
+			keys, parentTree, err := keysOf(parent)
+			if err != nil {
+				return err
+			}
+			if firstTree == nil {
+				firstTree = parentTree
+			}
+			parents = append(parents, keys)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if len(parents) == 0 {
+			parents = append(parents, nil)
+		}
+
+		collections := make(map[string]struct{})
+		for _, keys := range append(parents, current) {
+			for collection := range keys {
+				collections[collection] = struct{}{}
+			}
+		}
+		names := make([]string, 0, len(collections))
+		for collection := range collections {
+			names = append(names, collection)
+		}
+		sort.Strings(names)
+		for _, collection := range names {
+			changed := true
+			for _, keys := range parents {
+				if keys[collection] == current[collection] {
+					changed = false
+					break
+				}
+			}
+			if !changed {
+				continue
+			}
+			before, err := readCollectionFields(firstTree, collection, domain.CollectionKeysFile)
+			if err != nil {
+				return err
+			}
+			after, err := readCollectionFields(tree, collection, domain.CollectionKeysFile)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, integrityapp.TrustChange{
+				Commit:     commit.Hash.String(),
+				Collection: collection,
+				Before:     before,
+				After:      after,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// trustedKeyBlobs maps each collection in tree to the blob of its trusted
+// keys file.
+func trustedKeyBlobs(tree *object.Tree) (map[string]plumbing.Hash, error) {
+	root, err := tree.Tree(domain.CollectionsRoot)
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s tree: %w", domain.CollectionsRoot, err)
+	}
+	keys := make(map[string]plumbing.Hash)
+	for _, entry := range root.Entries {
+		if entry.Mode != filemode.Dir {
+			continue
+		}
+		collection, err := root.Tree(entry.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read collection tree: %w", err)
+		}
+		if file, err := collection.FindEntry(domain.CollectionKeysFile); err == nil {
+			keys[entry.Name] = file.Hash
+		}
+	}
+	return keys, nil
+}
+
+func (s *Store) ListSchemaChanges(ctx context.Context, repoPath, collection string) ([]collectionapp.SchemaChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read main ref: %w", err)
+	}
+
+	collectionPath := normalizeTreePath(domain.CollectionPath(collection))
+	var changes []collectionapp.SchemaChange
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("read commit: %w", err)
+	}
+	for commit != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		tree, err := commit.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("read commit tree: %w", err)
+		}
+		current := subtreeHash(tree, collectionPath)
+
+		var parent *object.Commit
+		previous := plumbing.ZeroHash
+		if commit.NumParents() > 0 {
+			parent, err = commit.Parent(0)
+			if err != nil {
+				return nil, fmt.Errorf("read parent commit: %w", err)
+			}
+			parentTree, err := parent.Tree()
+			if err != nil {
+				return nil, fmt.Errorf("read commit tree: %w", err)
+			}
+			previous = subtreeHash(parentTree, collectionPath)
+		}
+
+		if current != previous {
+			change, err := readSchemaChange(tree, collectionPath)
+			if err != nil {
+				return nil, err
+			}
+			change.CommitHash = commit.Hash.String()
+			change.Timestamp = commit.Committer.When
+			changes = append(changes, change)
+		}
+		commit = parent
+	}
+
+	return changes, nil
+}
+
+func readSchemaChange(tree *object.Tree, collectionPath string) (collectionapp.SchemaChange, error) {
+	var change collectionapp.SchemaChange
+	schema, err := readTreeFile(tree, path.Join(collectionPath, domain.CollectionSchemaFile))
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			change.Removed = true
+			return change, nil
+		}
+		return change, err
+	}
+	change.Schema = schema
+
+	collection := path.Base(collectionPath)
+	change.Indexes, err = readCollectionFields(tree, collection, domain.CollectionIndexesFile)
+	if err != nil {
+		return change, err
+	}
+	change.FullText, err = readCollectionFields(tree, collection, domain.CollectionFullTextFile)
+	if err != nil {
+		return change, err
+	}
+	change.TrustedKeys, err = readCollectionFields(tree, collection, domain.CollectionKeysFile)
+	return change, err
+}
+
+// writeFieldList stores a declared field list as an indented JSON array. An
+// empty list yields the zero hash so the file is removed instead.
+func writeFieldList(s storer.EncodedObjectStorer, fields []string) (plumbing.Hash, error) {
+	if len(fields) == 0 {
+		return plumbing.ZeroHash, nil
+	}
+	payload, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return writeBlob(s, append(payload, '\n'))
+}
+
+func setTreeFile(s storer.EncodedObjectStorer, treeHash plumbing.Hash, filePath string, blobHash plumbing.Hash) (plumbing.Hash, error) {
+	if blobHash.IsZero() {
+		return removeTreePath(s, treeHash, filePath)
+	}
+	return updateTree(s, treeHash, filePath, blobHash, filemode.Regular)
+}
+
+func subtreeHash(tree *object.Tree, treePath string) plumbing.Hash {
+	entry, err := tree.FindEntry(treePath)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return entry.Hash
+}
+
+func loadLegacySchema(repoPath, collection string) ([]byte, error) {
+	schemaPath := filepath.Join(repoPath, domain.CollectionPath(collection), domain.CollectionSchemaFile)
+	schema, err := os.ReadFile(schemaPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+	return schema, nil
 }
@@ -0,0 +1,100 @@
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestWriteSchemaCommitsToTree(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	schemaV1 := []byte(`{"type":"object"}`)
+	if err := store.WriteSchema(ctx, repoDir, "users", schemaV1, []string{"email"}, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, domain.CollectionsRoot)); !os.IsNotExist(err) {
+		t.Fatalf("expected no schema files outside the git tree, got %v", err)
+	}
+
+	loaded, err := store.LoadSchema(ctx, repoDir, "users")
+	if err != nil {
+		t.Fatalf("LoadSchema returned error: %v", err)
+	}
+	if string(loaded) != string(schemaV1) {
+		t.Fatalf("expected schema %s, got %s", schemaV1, loaded)
+	}
+
+	writeTx(t, ctx, store, repoDir, domain.Transaction{
+		TxID:       "01HINT",
+		Timestamp:  1,
+		Collection: "users",
+		DocID:      "doc1",
+		Op:         domain.TxOpPut,
+		Snapshot:   []byte(`{"a":1}`),
+	})
+
+	schemaV2 := []byte(`{"type":"object","required":["email"]}`)
+	if err := store.WriteSchema(ctx, repoDir, "users", schemaV2, nil, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	if err := store.WriteSchema(ctx, repoDir, "users", schemaV2, nil, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+
+	changes, err := store.ListSchemaChanges(ctx, repoDir, "users")
+	if err != nil {
+		t.Fatalf("ListSchemaChanges returned error: %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 schema changes, got %d", len(changes))
+	}
+	if string(changes[0].Schema) != string(schemaV2) || len(changes[0].Indexes) != 0 {
+		t.Fatalf("unexpected latest change: %+v", changes[0])
+	}
+	if string(changes[1].Schema) != string(schemaV1) || !reflect.DeepEqual(changes[1].Indexes, []string{"email"}) {
+		t.Fatalf("unexpected first change: %+v", changes[1])
+	}
+}
+
+func TestLoadSchemaFallsBackToLegacyFiles(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	legacyDir := filepath.Join(repoDir, domain.CollectionsRoot, "users")
+	if err := os.MkdirAll(legacyDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(legacyDir, domain.CollectionSchemaFile), []byte(`{}`), 0o644); err != nil {
+		t.Fatalf("write legacy schema: %v", err)
+	}
+
+	loaded, err := store.LoadSchema(ctx, repoDir, "users")
+	if err != nil {
+		t.Fatalf("LoadSchema returned error: %v", err)
+	}
+	if string(loaded) != `{}` {
+		t.Fatalf("expected legacy schema, got %s", loaded)
+	}
+
+	missing, err := store.LoadSchema(ctx, repoDir, "orders")
+	if err != nil {
+		t.Fatalf("LoadSchema returned error: %v", err)
+	}
+	if missing != nil {
+		t.Fatalf("expected no schema, got %s", missing)
+	}
+}
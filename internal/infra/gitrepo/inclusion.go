@@ -0,0 +1,221 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// LoadInclusion reads the raw objects on the path from a commit, or main
+// when commit is empty, to a stream's tx directory: the commit, one tree per
+// directory level, the stream's HEAD file and every tx file.
+func (s *Store) LoadInclusion(ctx context.Context, repoPath, commit, streamPath string) (integrityapp.Inclusion, error) {
+	if err := ctx.Err(); err != nil {
+		return integrityapp.Inclusion{}, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return integrityapp.Inclusion{}, fmt.Errorf("open git repo: %w", err)
+	}
+	ref := commit
+	if ref == "" {
+		ref = mainRefName
+	}
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return integrityapp.Inclusion{}, fmt.Errorf("%w: %s", doc.ErrRefNotFound, ref)
+		}
+		return integrityapp.Inclusion{}, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+	commitObj, err := repo.CommitObject(*hash)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return integrityapp.Inclusion{}, fmt.Errorf("%w: %s", doc.ErrRefNotFound, ref)
+		}
+		return integrityapp.Inclusion{}, fmt.Errorf("read commit %s: %w", ref, err)
+	}
+	commitRaw, err := readRawObject(repo.Storer, plumbing.CommitObject, commitObj.Hash)
+	if err != nil {
+		return integrityapp.Inclusion{}, err
+	}
+	tree, err := commitObj.Tree()
+	if err != nil {
+		return integrityapp.Inclusion{}, fmt.Errorf("read commit tree: %w", err)
+	}
+
+	streamPath = normalizeTreePath(streamPath)
+	inclusion := integrityapp.Inclusion{
+		Commit:       commitObj.Hash.String(),
+		CommitObject: commitRaw,
+		StreamPath:   streamPath,
+	}
+	names := append(strings.Split(streamPath, "/"), domain.TxDirName)
+	treePath := ""
+	var streamTree *object.Tree
+	for i := 0; ; i++ {
+		raw, err := readRawObject(repo.Storer, plumbing.TreeObject, tree.Hash)
+		if err != nil {
+			return integrityapp.Inclusion{}, err
+		}
+		inclusion.Trees = append(inclusion.Trees, integrityapp.ProofTree{Path: treePath, Object: raw})
+		if treePath == streamPath {
+			streamTree = tree
+		}
+		if i == len(names) {
+			break
+		}
+
+		tree, err = tree.Tree(names[i])
+		if err != nil {
+			if errors.Is(err, object.ErrDirectoryNotFound) {
+				return integrityapp.Inclusion{}, doc.ErrDocNotFound
+			}
+			return integrityapp.Inclusion{}, fmt.Errorf("read tree %s: %w", path.Join(treePath, names[i]), err)
+		}
+		treePath = path.Join(treePath, names[i])
+	}
+
+	inclusion.Head, err = readTreeFile(streamTree, domain.StreamHeadFile)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return integrityapp.Inclusion{}, doc.ErrDocNotFound
+		}
+		return integrityapp.Inclusion{}, err
+	}
+	for _, entry := range tree.Entries {
+		if err := ctx.Err(); err != nil {
+			return integrityapp.Inclusion{}, err
+		}
+		if entry.Mode == filemode.Dir || !strings.HasSuffix(entry.Name, domain.TxFileExt) {
+			continue
+		}
+		blob, err := readBlob(tree, entry)
+		if err != nil {
+			return integrityapp.Inclusion{}, err
+		}
+		inclusion.Txs = append(inclusion.Txs, integrityapp.ProofTx{Name: entry.Name, Blob: blob})
+	}
+	return inclusion, nil
+}
+
+// VerifyInclusion recomputes git object hashes from the commit down: the
+// commit names the root tree, each tree names the next directory on the
+// stream path, and the last two name the HEAD and tx files.
+func (s *Store) VerifyInclusion(ctx context.Context, inclusion integrityapp.Inclusion) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if got := plumbing.ComputeHash(plumbing.CommitObject, inclusion.CommitObject).String(); got != inclusion.Commit {
+		return fmt.Errorf("%w: commit object hashes to %s, not %s", integrityapp.ErrInvalidProof, got, inclusion.Commit)
+	}
+	var commit object.Commit
+	if err := commit.Decode(memoryObject(plumbing.CommitObject, inclusion.CommitObject)); err != nil {
+		return fmt.Errorf("%w: decode commit: %v", integrityapp.ErrInvalidProof, err)
+	}
+
+	streamPath := normalizeTreePath(inclusion.StreamPath)
+	names := append(strings.Split(streamPath, "/"), domain.TxDirName)
+	if len(inclusion.Trees) != len(names)+1 {
+		return fmt.Errorf("%w: expected %d trees, got %d", integrityapp.ErrInvalidProof, len(names)+1, len(inclusion.Trees))
+	}
+
+	want := commit.TreeHash
+	treePath := ""
+	var streamTree, txTree *object.Tree
+	for i, proofTree := range inclusion.Trees {
+		if proofTree.Path != treePath {
+			return fmt.Errorf("%w: tree %d is %q, expected %q", integrityapp.ErrInvalidProof, i, proofTree.Path, treePath)
+		}
+		if got := plumbing.ComputeHash(plumbing.TreeObject, proofTree.Object); got != want {
+			return fmt.Errorf("%w: tree %q hashes to %s, not %s", integrityapp.ErrInvalidProof, treePath, got, want)
+		}
+		tree := &object.Tree{}
+		if err := tree.Decode(memoryObject(plumbing.TreeObject, proofTree.Object)); err != nil {
+			return fmt.Errorf("%w: decode tree %q: %v", integrityapp.ErrInvalidProof, treePath, err)
+		}
+		if treePath == streamPath {
+			streamTree = tree
+		}
+		if i == len(names) {
+			txTree = tree
+			break
+		}
+		entry, ok := findTreeEntry(tree, names[i])
+		if !ok || entry.Mode != filemode.Dir {
+			return fmt.Errorf("%w: tree %q has no directory %s", integrityapp.ErrInvalidProof, treePath, names[i])
+		}
+		want = entry.Hash
+		treePath = path.Join(treePath, names[i])
+	}
+
+	if err := checkBlobEntry(streamTree, domain.StreamHeadFile, inclusion.Head); err != nil {
+		return err
+	}
+	for _, tx := range inclusion.Txs {
+		if err := checkBlobEntry(txTree, tx.Name, tx.Blob); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkBlobEntry(tree *object.Tree, name string, data []byte) error {
+	entry, ok := findTreeEntry(tree, name)
+	if !ok || entry.Mode == filemode.Dir {
+		return fmt.Errorf("%w: no file %s", integrityapp.ErrInvalidProof, name)
+	}
+	if got := plumbing.ComputeHash(plumbing.BlobObject, data); got != entry.Hash {
+		return fmt.Errorf("%w: file %s hashes to %s, not %s", integrityapp.ErrInvalidProof, name, got, entry.Hash)
+	}
+	return nil
+}
+
+func findTreeEntry(tree *object.Tree, name string) (object.TreeEntry, bool) {
+	for _, entry := range tree.Entries {
+		if entry.Name == name {
+			return entry, true
+		}
+	}
+	return object.TreeEntry{}, false
+}
+
+func memoryObject(objectType plumbing.ObjectType, data []byte) plumbing.EncodedObject {
+	obj := &plumbing.MemoryObject{}
+	obj.SetType(objectType)
+	_, _ = obj.Write(data)
+	return obj
+}
+
+func readRawObject(s storer.EncodedObjectStorer, objectType plumbing.ObjectType, hash plumbing.Hash) ([]byte, error) {
+	obj, err := s.EncodedObject(objectType, hash)
+	if err != nil {
+		return nil, fmt.Errorf("read %s %s: %w", objectType, hash, err)
+	}
+	reader, err := obj.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("read %s %s: %w", objectType, hash, err)
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read %s %s: %w", objectType, hash, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,52 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestLoadInclusionVerifiesAndDetectsTampering(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	streamPath, _, txBytes := writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT1", Timestamp: 1, Collection: "users", DocID: "doc1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT2", Timestamp: 2, Collection: "users", DocID: "doc2", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	streamPath = filepath.ToSlash(streamPath)
+
+	inclusion, err := store.LoadInclusion(ctx, repoDir, "", streamPath)
+	if err != nil {
+		t.Fatalf("LoadInclusion returned error: %v", err)
+	}
+	if len(inclusion.Txs) != 1 || string(inclusion.Txs[0].Blob) != string(txBytes) {
+		t.Fatalf("expected the stream's tx, got %+v", inclusion.Txs)
+	}
+	if err := store.VerifyInclusion(ctx, inclusion); err != nil {
+		t.Fatalf("VerifyInclusion returned error: %v", err)
+	}
+
+	tampered := inclusion
+	tampered.Txs = []integrityapp.ProofTx{{Name: inclusion.Txs[0].Name, Blob: append([]byte("x"), txBytes...)}}
+	if err := store.VerifyInclusion(ctx, tampered); !errors.Is(err, integrityapp.ErrInvalidProof) {
+		t.Fatalf("expected ErrInvalidProof for a modified tx, got %v", err)
+	}
+	tampered = inclusion
+	tampered.Commit = "0000000000000000000000000000000000000000"
+	if err := store.VerifyInclusion(ctx, tampered); !errors.Is(err, integrityapp.ErrInvalidProof) {
+		t.Fatalf("expected ErrInvalidProof for another commit, got %v", err)
+	}
+
+	missing := filepath.ToSlash(domain.StreamPath(domain.StreamLayoutSharded, "users", "nope"))
+	if _, err := store.LoadInclusion(ctx, repoDir, "", missing); !errors.Is(err, doc.ErrDocNotFound) {
+		t.Fatalf("expected ErrDocNotFound, got %v", err)
+	}
+}
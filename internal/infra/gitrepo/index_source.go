@@ -11,7 +11,6 @@ import (
 	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/go-git/go-git/v5"
-	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/utils/merkletrie"
@@ -27,36 +26,37 @@ func (s *Store) Fetch(ctx context.Context, repoPath string) error {
 		return fmt.Errorf("open git repo: %w", err)
 	}
 
-	remote, err := repo.Remote("origin")
-	if err != nil {
-		if errors.Is(err, git.ErrRemoteNotFound) {
-			return nil
-		}
-		return fmt.Errorf("read git remote: %w", err)
-	}
-	remoteURL := ""
-	if cfg := remote.Config(); cfg != nil && len(cfg.URLs) > 0 {
-		remoteURL = cfg.URLs[0]
-	}
-	auth, err := authForURL(remoteURL)
-	if err != nil {
+	found, err := fetchOrigin(ctx, repo)
+	if err != nil || !found {
 		return err
 	}
 
-	err = repo.FetchContext(ctx, &git.FetchOptions{
-		RemoteName: "origin",
-		RefSpecs: []config.RefSpec{
-			"+refs/heads/*:refs/heads/*",
-		},
-		Auth: auth,
-	})
+	local, err := referenceCommit(repo, plumbing.ReferenceName(mainRefName))
 	if err != nil {
-		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+	remote, err := referenceCommit(repo, plumbing.ReferenceName(remoteMainRefName))
+	if err != nil || remote == nil {
+		return err
+	}
+	if local == nil {
+		return setMainRef(repo, "", remote.Hash)
+	}
+	if local.Hash == remote.Hash {
+		return nil
+	}
+	// Amend-mode histories never share ancestors, so the remote always wins.
+	// Otherwise main only moves forward; diverged heads are left for sync.
+	if s.historyMode() != domain.HistoryModeAmend {
+		behind, err := local.IsAncestor(remote)
+		if err != nil {
+			return fmt.Errorf("compare with remote: %w", err)
+		}
+		if !behind {
 			return nil
 		}
-		return fmt.Errorf("fetch git repo: %w", err)
 	}
-	return nil
+	return setMainRef(repo, local.Hash.String(), remote.Hash)
 }
 
 func (s *Store) ListCommitHashes(ctx context.Context, repoPath, sinceHash string) ([]string, error) {
@@ -77,38 +77,44 @@ func (s *Store) ListCommitHashes(ctx context.Context, repoPath, sinceHash string
 		return nil, fmt.Errorf("read main ref: %w", err)
 	}
 
-	iter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	commits, found, err := firstParentCommits(ctx, repo, ref.Hash(), sinceHash)
 	if err != nil {
-		return nil, fmt.Errorf("read git log: %w", err)
+		return nil, err
+	}
+	if !found {
+		return nil, indexapp.ErrCommitNotFound
+	}
+
+	reverseStrings(commits)
+	return commits, nil
+}
+
+// firstParentCommits walks from head along first parents until sinceHash.
+// Merge commits are indexed as a diff against their first parent, which
+// already covers the commits they brought in from the other side.
+func firstParentCommits(ctx context.Context, repo *git.Repository, head plumbing.Hash, sinceHash string) ([]string, bool, error) {
+	commit, err := repo.CommitObject(head)
+	if err != nil {
+		return nil, false, fmt.Errorf("read git log: %w", err)
 	}
-	defer iter.Close()
 
 	var commits []string
-	found := sinceHash == ""
 	for {
 		if err := ctx.Err(); err != nil {
-			return nil, err
-		}
-		commit, err := iter.Next()
-		if err != nil {
-			if errors.Is(err, io.EOF) {
-				break
-			}
-			return nil, fmt.Errorf("read git log: %w", err)
+			return nil, false, err
 		}
 		if sinceHash != "" && commit.Hash.String() == sinceHash {
-			found = true
-			break
+			return commits, true, nil
 		}
 		commits = append(commits, commit.Hash.String())
+		if commit.NumParents() == 0 {
+			return commits, sinceHash == "", nil
+		}
+		commit, err = commit.Parent(0)
+		if err != nil {
+			return nil, false, fmt.Errorf("read git log: %w", err)
+		}
 	}
-
-	if !found {
-		return nil, indexapp.ErrCommitNotFound
-	}
-
-	reverseStrings(commits)
-	return commits, nil
 }
 
 func (s *Store) CommitTxs(ctx context.Context, repoPath, commitHash string) ([]indexapp.CommitTx, error) {
@@ -265,10 +271,6 @@ func (s *Store) commitTxsForRoot(ctx context.Context, repoPath, commitHash, root
 	if err != nil {
 		return nil, fmt.Errorf("read commit: %w", err)
 	}
-	if commit.NumParents() > 1 {
-		return nil, indexapp.ErrMergeCommitUnsupported
-	}
-
 	tree, err := commit.Tree()
 	if err != nil {
 		return nil, fmt.Errorf("read commit tree: %w", err)
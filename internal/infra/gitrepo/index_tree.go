@@ -0,0 +1,232 @@
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// LookupIndex lists the document ids filed under one value of a declared
+// field in indexes/ on main.
+func (s *Store) LookupIndex(ctx context.Context, repoPath, collection, field string, value []byte) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadMainTree(repoPath)
+	if err != nil {
+		if errors.Is(err, doc.ErrDocNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	valueTree, err := tree.Tree(normalizeTreePath(domain.IndexValuePath(collection, field, value)))
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read index tree: %w", err)
+	}
+
+	docIDs := make([]string, 0, len(valueTree.Entries))
+	for _, entry := range valueTree.Entries {
+		docID, err := domain.ParseIndexEntryName(entry.Name)
+		if err != nil {
+			return nil, fmt.Errorf("decode index entry %s: %w", entry.Name, err)
+		}
+		docIDs = append(docIDs, docID)
+	}
+	sort.Strings(docIDs)
+	return docIDs, nil
+}
+
+// indexWriter keeps indexes/ in step with state/ inside a single tree edit.
+// It is rebuilt for every commit attempt because it reads the base tree.
+type indexWriter struct {
+	storer    storer.EncodedObjectStorer
+	baseTree  *object.Tree
+	decoder   doc.Decoder
+	emptyBlob plumbing.Hash
+	fields    map[string][]string
+	docs      map[string][]byte
+}
+
+func (s *Store) newIndexWriter(st storer.EncodedObjectStorer, baseTree *object.Tree) (*indexWriter, error) {
+	if s.options.TxDecoder == nil {
+		return nil, nil
+	}
+	emptyBlob, err := writeBlob(st, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &indexWriter{
+		storer:    st,
+		baseTree:  baseTree,
+		decoder:   s.options.TxDecoder,
+		emptyBlob: emptyBlob,
+		fields:    make(map[string][]string),
+		docs:      make(map[string][]byte),
+	}, nil
+}
+
+func (w *indexWriter) declaredFields(collection string) ([]string, error) {
+	if fields, ok := w.fields[collection]; ok {
+		return fields, nil
+	}
+	fields, err := readDeclaredIndexes(w.baseTree, collection)
+	if err != nil {
+		return nil, err
+	}
+	w.fields[collection] = fields
+	return fields, nil
+}
+
+// update moves a document's entries from the values of its previous state to
+// the values of its new one. A nil doc removes every entry.
+func (w *indexWriter) update(treeHash plumbing.Hash, statePath, collection, docID string, newDoc []byte) (plumbing.Hash, error) {
+	fields, err := w.declaredFields(collection)
+	if err != nil || len(fields) == 0 {
+		return treeHash, err
+	}
+
+	oldDoc, ok := w.docs[statePath]
+	if !ok {
+		oldDoc, err = w.loadStateDoc(statePath)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	w.docs[statePath] = newDoc
+
+	oldValues := domain.IndexValues(oldDoc, fields)
+	newValues := domain.IndexValues(newDoc, fields)
+	entryName := domain.IndexEntryName(docID)
+	for _, field := range fields {
+		oldValue, hadOld := oldValues[field]
+		newValue, hasNew := newValues[field]
+		if hadOld && hasNew && bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		if hadOld {
+			entryPath := path.Join(normalizeTreePath(domain.IndexValuePath(collection, field, oldValue)), entryName)
+			if treeHash, err = removeTreePath(w.storer, treeHash, entryPath); err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+		if hasNew {
+			entryPath := path.Join(normalizeTreePath(domain.IndexValuePath(collection, field, newValue)), entryName)
+			if treeHash, err = updateTree(w.storer, treeHash, entryPath, w.emptyBlob, filemode.Regular); err != nil {
+				return plumbing.ZeroHash, err
+			}
+		}
+	}
+	return treeHash, nil
+}
+
+func (w *indexWriter) loadStateDoc(statePath string) ([]byte, error) {
+	if w.baseTree == nil {
+		return nil, nil
+	}
+	blob, err := readStateTx(w.baseTree, statePath, statePath)
+	if err != nil {
+		if errors.Is(err, doc.ErrDocNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return w.decodeStateDoc(blob.Bytes)
+}
+
+func (w *indexWriter) decodeStateDoc(data []byte) ([]byte, error) {
+	tx, err := w.decoder.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	if tx.Op == domain.TxOpDelete {
+		return nil, nil
+	}
+	return tx.Snapshot, nil
+}
+
+// rebuild replaces indexes/<collection> with entries for the given fields
+// computed from every document under state/<collection>.
+func (w *indexWriter) rebuild(ctx context.Context, treeHash plumbing.Hash, collection string, fields []string) (plumbing.Hash, error) {
+	treeHash, err := removeTreePath(w.storer, treeHash, path.Join(domain.IndexesRoot, collection))
+	if err != nil || len(fields) == 0 || w.baseTree == nil {
+		return treeHash, err
+	}
+
+	basePath := path.Join(domain.StateRoot, collection)
+	stateTree, err := w.baseTree.Tree(basePath)
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return treeHash, nil
+		}
+		return plumbing.ZeroHash, fmt.Errorf("read state tree: %w", err)
+	}
+
+	var scanErr error
+	scanStateTree(ctx, stateTree, basePath, "", "", func(entry doc.StateEntry, err error) bool {
+		if err != nil {
+			scanErr = err
+			return false
+		}
+		tx, err := w.decoder.Decode(entry.Blob.Bytes)
+		if err != nil {
+			scanErr = err
+			return false
+		}
+		if tx.Op == domain.TxOpDelete {
+			return true
+		}
+		entryName := domain.IndexEntryName(tx.DocID)
+		for field, value := range domain.IndexValues(tx.Snapshot, fields) {
+			entryPath := path.Join(normalizeTreePath(domain.IndexValuePath(collection, field, value)), entryName)
+			if treeHash, err = updateTree(w.storer, treeHash, entryPath, w.emptyBlob, filemode.Regular); err != nil {
+				scanErr = err
+				return false
+			}
+		}
+		return true
+	})
+	if scanErr != nil {
+		return plumbing.ZeroHash, scanErr
+	}
+	return treeHash, nil
+}
+
+func readDeclaredIndexes(tree *object.Tree, collection string) ([]string, error) {
+	return readCollectionFields(tree, collection, domain.CollectionIndexesFile)
+}
+
+// readCollectionFields decodes a field list such as indexes.json or
+// fulltext.json from collections/<collection>; a missing file means none.
+func readCollectionFields(tree *object.Tree, collection, fileName string) ([]string, error) {
+	if tree == nil {
+		return nil, nil
+	}
+	filePath := path.Join(normalizeTreePath(domain.CollectionPath(collection)), fileName)
+	payload, err := readTreeFile(tree, filePath)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var fields []string
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", fileName, err)
+	}
+	return fields, nil
+}
@@ -0,0 +1,84 @@
+package gitrepo
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+)
+
+func TestPutTxMaintainsIndexEntries(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStoreWithOptions(StoreOptions{TxDecoder: txv3.Decoder{}})
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	if err := store.WriteSchema(ctx, repoDir, "tasks", []byte(`{}`), []string{"status"}, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+
+	heads := make(map[string]string)
+	write := func(docID string, op domain.TxOp, snapshot string) {
+		t.Helper()
+		tx := domain.Transaction{TxID: "tx-" + docID + op.String(), Timestamp: 1, Collection: "tasks", DocID: docID, Op: op, ParentHash: heads[docID]}
+		if snapshot != "" {
+			tx.Snapshot = []byte(snapshot)
+		}
+		txBytes, err := txv3.Encoder{}.Encode(tx)
+		if err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+		heads[docID] = hash.SHA256{}.SumHex(txBytes)
+		_, err = store.PutTx(ctx, doc.TxWrite{
+			RepoPath:     repoDir,
+			StreamPath:   domain.StreamPath(domain.StreamLayoutFlat, "tasks", docID),
+			TxBytes:      txBytes,
+			TxHash:       heads[docID],
+			Tx:           tx,
+			StatePath:    domain.StatePath(domain.StreamLayoutFlat, "tasks", docID),
+			StateTxBytes: txBytes,
+			StateTx:      tx,
+		})
+		if err != nil {
+			t.Fatalf("PutTx returned error: %v", err)
+		}
+	}
+	lookup := func(field, value string) []string {
+		t.Helper()
+		docIDs, err := store.LookupIndex(ctx, repoDir, "tasks", field, domain.ParseIndexValue(value))
+		if err != nil {
+			t.Fatalf("LookupIndex returned error: %v", err)
+		}
+		return docIDs
+	}
+
+	write("a", domain.TxOpPut, `{"status":"open","n":1}`)
+	write("b/1", domain.TxOpPut, `{"status":"open","n":2}`)
+	if got := lookup("status", "open"); !reflect.DeepEqual(got, []string{"a", "b/1"}) {
+		t.Fatalf("expected both docs open, got %v", got)
+	}
+
+	write("a", domain.TxOpMerge, `{"status":"done","n":1}`)
+	write("b/1", domain.TxOpDelete, "")
+	if got := lookup("status", "open"); len(got) != 0 {
+		t.Fatalf("expected stale entries removed, got %v", got)
+	}
+	if got := lookup("status", "done"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("expected a done, got %v", got)
+	}
+
+	if err := store.WriteSchema(ctx, repoDir, "tasks", []byte(`{}`), []string{"n"}, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	if got := lookup("n", "1"); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("expected new declaration to be backfilled, got %v", got)
+	}
+	if got := lookup("status", "done"); len(got) != 0 {
+		t.Fatalf("expected undeclared field entries dropped, got %v", got)
+	}
+}
@@ -23,19 +23,8 @@ func (s *Store) Push(ctx context.Context, repoPath string) error {
 		return fmt.Errorf("open git repo: %w", err)
 	}
 
-	remote, err := repo.Remote("origin")
-	if err != nil {
-		if errors.Is(err, git.ErrRemoteNotFound) {
-			return nil
-		}
-		return fmt.Errorf("read git remote: %w", err)
-	}
-	remoteURL := ""
-	if cfg := remote.Config(); cfg != nil && len(cfg.URLs) > 0 {
-		remoteURL = cfg.URLs[0]
-	}
-	auth, err := authForURL(remoteURL)
-	if err != nil {
+	auth, found, err := originAuth(repo)
+	if err != nil || !found {
 		return err
 	}
 
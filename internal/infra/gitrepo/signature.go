@@ -0,0 +1,129 @@
+package gitrepo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ListMainCommits returns every commit reachable from main, including the
+// commits merges brought in, newest first.
+func (s *Store) ListMainCommits(ctx context.Context, repoPath string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read main ref: %w", err)
+	}
+
+	iter, err := repo.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("read git log: %w", err)
+	}
+	defer iter.Close()
+
+	var commits []string
+	err = iter.ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		commits = append(commits, commit.Hash.String())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// VerifyCommitSignature runs git verify-commit on a commit. A keyring
+// directory is used as GNUPGHOME and a keyring file as the SSH
+// allowed_signers file; an empty keyring keeps the host's git configuration.
+func (s *Store) VerifyCommitSignature(ctx context.Context, repoPath, commitHash, keyring string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("open git repo: %w", err)
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		return fmt.Errorf("read commit %s: %w", commitHash, err)
+	}
+	if strings.TrimSpace(commit.PGPSignature) == "" {
+		return integrityapp.ErrCommitUnsigned
+	}
+
+	args := []string{"-C", repoPath}
+	env := os.Environ()
+	if keyring != "" {
+		absKeyring, err := filepath.Abs(keyring)
+		if err != nil {
+			return fmt.Errorf("resolve keyring: %w", err)
+		}
+		info, err := os.Stat(absKeyring)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("%w: %s", integrityapp.ErrKeyringNotFound, keyring)
+			}
+			return fmt.Errorf("read keyring: %w", err)
+		}
+		if info.IsDir() {
+			env = append(env, "GNUPGHOME="+absKeyring)
+		} else {
+			args = append(args, "-c", "gpg.ssh.allowedSignersFile="+absKeyring)
+		}
+	}
+	args = append(args, "verify-commit", commitHash)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = env
+	cmd.Stdout = io.Discard
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return fmt.Errorf("git verify-commit: %w", err)
+		}
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return integrityapp.ErrBadSignature
+		}
+		return fmt.Errorf("%w: %s", integrityapp.ErrBadSignature, lastLine(msg))
+	}
+	return nil
+}
+
+func lastLine(msg string) string {
+	if i := strings.LastIndex(msg, "\n"); i >= 0 {
+		return msg[i+1:]
+	}
+	return msg
+}
@@ -0,0 +1,84 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestVerifyCommitSignatureWithSSHKeys(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+	repoDir := filepath.Join(dir, "repo")
+	if err := NewStore().Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	signer := generateSSHKey(t, filepath.Join(dir, "signer"))
+	stranger := generateSSHKey(t, filepath.Join(dir, "stranger"))
+	runGit(t, repoDir, "config", "gpg.format", "ssh")
+
+	unsignedStore := NewStore()
+	writeTx(t, ctx, unsignedStore, repoDir, domain.Transaction{TxID: "tx1", Timestamp: 1, Collection: "users", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	signedStore := NewStoreWithOptions(StoreOptions{SignCommits: true, SignKey: filepath.Join(dir, "signer")})
+	writeTx(t, ctx, signedStore, repoDir, domain.Transaction{TxID: "tx2", Timestamp: 2, Collection: "users", DocID: "b", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	commits, err := signedStore.ListMainCommits(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("ListMainCommits returned error: %v", err)
+	}
+	if len(commits) < 2 {
+		t.Fatalf("expected at least 2 commits, got %v", commits)
+	}
+	signed, unsigned := commits[0], commits[1]
+
+	trusted := filepath.Join(dir, "allowed_signers")
+	writeAllowedSigners(t, trusted, signer)
+	if err := signedStore.VerifyCommitSignature(ctx, repoDir, signed, trusted); err != nil {
+		t.Fatalf("expected the signed commit to verify, got %v", err)
+	}
+	if err := signedStore.VerifyCommitSignature(ctx, repoDir, unsigned, trusted); !errors.Is(err, integrityapp.ErrCommitUnsigned) {
+		t.Fatalf("expected ErrCommitUnsigned, got %v", err)
+	}
+
+	untrusted := filepath.Join(dir, "other_signers")
+	writeAllowedSigners(t, untrusted, stranger)
+	if err := signedStore.VerifyCommitSignature(ctx, repoDir, signed, untrusted); !errors.Is(err, integrityapp.ErrBadSignature) {
+		t.Fatalf("expected ErrBadSignature for an unknown key, got %v", err)
+	}
+}
+
+func generateSSHKey(t *testing.T, path string) string {
+	t.Helper()
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", "ledgerdb", "-f", path).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen: %v: %s", err, out)
+	}
+	pub, err := os.ReadFile(path + ".pub")
+	if err != nil {
+		t.Fatalf("read public key: %v", err)
+	}
+	return strings.TrimSpace(string(pub))
+}
+
+func writeAllowedSigners(t *testing.T, path, publicKey string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte("ledgerdb@local "+publicKey+"\n"), 0o644); err != nil {
+		t.Fatalf("write allowed signers: %v", err)
+	}
+}
+
+func runGit(t *testing.T, repoDir string, args ...string) {
+	t.Helper()
+	if out, err := exec.Command("git", append([]string{"-C", repoDir}, args...)...).CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+}
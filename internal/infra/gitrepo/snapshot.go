@@ -0,0 +1,101 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	integrityapp "github.com/osvaldoandrade/ledgerdb/internal/app/integrity"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// treeSnapshot serves reads from one commit's tree, loaded once. Like the
+// repository handle behind it, it is not safe for concurrent use.
+type treeSnapshot struct {
+	commit string
+	tree   *object.Tree
+}
+
+// OpenSnapshot pins a commit, or main's current head when commit is empty,
+// for repeated reads. A repository without main yields an empty snapshot.
+func (s *Store) OpenSnapshot(ctx context.Context, repoPath, commit string) (integrityapp.Snapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+
+	hash := plumbing.NewHash(commit)
+	if commit == "" {
+		ref, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				return &treeSnapshot{}, nil
+			}
+			return nil, fmt.Errorf("read main ref: %w", err)
+		}
+		hash = ref.Hash()
+	}
+
+	commitObj, err := repo.CommitObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("read commit %s: %w", hash, err)
+	}
+	tree, err := commitObj.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read commit tree: %w", err)
+	}
+	return &treeSnapshot{commit: hash.String(), tree: tree}, nil
+}
+
+func (s *treeSnapshot) Commit() string {
+	return s.commit
+}
+
+func (s *treeSnapshot) ListDocStreams(ctx context.Context, _ string) ([]string, error) {
+	if s.tree == nil {
+		return nil, nil
+	}
+	return listTreeStreams(ctx, s.tree, domain.DocumentsRoot)
+}
+
+func (s *treeSnapshot) ListStateStreams(ctx context.Context, _ string) ([]string, error) {
+	if s.tree == nil {
+		return nil, nil
+	}
+	return listTreeStreams(ctx, s.tree, domain.StateRoot)
+}
+
+func (s *treeSnapshot) LoadStreamHead(ctx context.Context, _, streamPath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return loadStreamHeadHash(s.tree, normalizeTreePath(streamPath))
+}
+
+func (s *treeSnapshot) LoadHeadTx(ctx context.Context, _, streamPath string) (doc.TxBlob, error) {
+	if err := ctx.Err(); err != nil {
+		return doc.TxBlob{}, err
+	}
+	if s.tree == nil {
+		return doc.TxBlob{}, doc.ErrDocNotFound
+	}
+	return readHeadTx(s.tree, streamPath)
+}
+
+func (s *treeSnapshot) LoadStreamTxs(ctx context.Context, _, streamPath string) ([]doc.TxBlob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if s.tree == nil {
+		return nil, doc.ErrDocNotFound
+	}
+	return readStreamTxs(ctx, s.tree, streamPath)
+}
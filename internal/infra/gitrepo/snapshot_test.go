@@ -0,0 +1,49 @@
+package gitrepo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+func TestOpenSnapshotPinsCommit(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	empty, err := store.OpenSnapshot(ctx, repoDir, "")
+	if err != nil {
+		t.Fatalf("OpenSnapshot returned error: %v", err)
+	}
+	if streams, err := empty.ListDocStreams(ctx, repoDir); err != nil || len(streams) != 0 {
+		t.Fatalf("expected no streams before the first write, got %v (%v)", streams, err)
+	}
+
+	streamPath, txHash, _ := writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT1", Timestamp: 1, Collection: "users", DocID: "doc1", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	snapshot, err := store.OpenSnapshot(ctx, repoDir, "")
+	if err != nil {
+		t.Fatalf("OpenSnapshot returned error: %v", err)
+	}
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT2", Timestamp: 2, Collection: "users", DocID: "doc2", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	streams, err := snapshot.ListDocStreams(ctx, repoDir)
+	if err != nil || len(streams) != 1 || streams[0] != streamPath {
+		t.Fatalf("expected the snapshot to list only %s, got %v (%v)", streamPath, streams, err)
+	}
+	head, err := snapshot.LoadStreamHead(ctx, repoDir, streamPath)
+	if err != nil || head != txHash {
+		t.Fatalf("expected head %s, got %s (%v)", txHash, head, err)
+	}
+
+	reopened, err := store.OpenSnapshot(ctx, repoDir, snapshot.Commit())
+	if err != nil {
+		t.Fatalf("OpenSnapshot returned error: %v", err)
+	}
+	if streams, err := reopened.ListDocStreams(ctx, repoDir); err != nil || len(streams) != 1 {
+		t.Fatalf("expected the pinned commit to list 1 stream, got %v (%v)", streams, err)
+	}
+}
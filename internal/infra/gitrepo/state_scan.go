@@ -0,0 +1,107 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"path"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ScanStates walks state/<collection> on main in stream-hash order, yielding
+// the state tx of every document whose hash sorts after the given key.
+func (s *Store) ScanStates(ctx context.Context, repoPath, collection, after string) iter.Seq2[doc.StateEntry, error] {
+	return func(yield func(doc.StateEntry, error) bool) {
+		if err := ctx.Err(); err != nil {
+			yield(doc.StateEntry{}, err)
+			return
+		}
+
+		tree, err := loadMainTree(repoPath)
+		if err != nil {
+			if !errors.Is(err, doc.ErrDocNotFound) {
+				yield(doc.StateEntry{}, err)
+			}
+			return
+		}
+
+		basePath := path.Join(domain.StateRoot, collection)
+		stateTree, err := tree.Tree(basePath)
+		if err != nil {
+			if !errors.Is(err, object.ErrDirectoryNotFound) {
+				yield(doc.StateEntry{}, fmt.Errorf("read state tree: %w", err))
+			}
+			return
+		}
+		scanStateTree(ctx, stateTree, basePath, "", strings.ToLower(after), yield)
+	}
+}
+
+func scanStateTree(ctx context.Context, tree *object.Tree, basePath, shard, after string, yield func(doc.StateEntry, error) bool) bool {
+	for _, entry := range tree.Entries {
+		if err := ctx.Err(); err != nil {
+			yield(doc.StateEntry{}, err)
+			return false
+		}
+		if entry.Mode != filemode.Dir {
+			continue
+		}
+		fullPath := path.Join(basePath, entry.Name)
+
+		if key, ok := strings.CutPrefix(entry.Name, "DOC_"); ok {
+			if after != "" && key <= after {
+				continue
+			}
+			blob, err := readStateTx(tree, entry.Name, fullPath)
+			if errors.Is(err, doc.ErrDocNotFound) {
+				continue
+			}
+			if !yield(doc.StateEntry{Key: key, Blob: blob}, err) || err != nil {
+				return false
+			}
+			continue
+		}
+
+		prefix := shard + entry.Name
+		if after != "" && len(prefix) <= len(after) && prefix < after[:len(prefix)] {
+			continue
+		}
+		childTree, err := tree.Tree(entry.Name)
+		if err != nil {
+			yield(doc.StateEntry{}, fmt.Errorf("read state tree %s: %w", fullPath, err))
+			return false
+		}
+		if !scanStateTree(ctx, childTree, fullPath, prefix, after, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func readStateTx(tree *object.Tree, name, fullPath string) (doc.TxBlob, error) {
+	headContent, err := readTreeFile(tree, path.Join(name, domain.StreamHeadFile))
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return doc.TxBlob{}, doc.ErrDocNotFound
+		}
+		return doc.TxBlob{}, err
+	}
+	relPath := strings.TrimSpace(string(headContent))
+	if relPath == "" {
+		return doc.TxBlob{}, doc.ErrDocNotFound
+	}
+	txBytes, err := readTreeFile(tree, path.Join(name, relPath))
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return doc.TxBlob{}, doc.ErrDocNotFound
+		}
+		return doc.TxBlob{}, err
+	}
+	return doc.TxBlob{Path: path.Join(fullPath, relPath), Bytes: txBytes}, nil
+}
@@ -0,0 +1,68 @@
+package gitrepo
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+)
+
+func TestScanStatesResumesAfterCursor(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	var keys []string
+	for _, docID := range []string{"a", "b", "c", "d", "e"} {
+		tx := domain.Transaction{TxID: "01H" + docID, Timestamp: 1, Collection: "users", DocID: docID, Op: domain.TxOpPut, Snapshot: []byte(`{}`)}
+		txBytes, err := txv3.Encoder{}.Encode(tx)
+		if err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+		_, err = store.PutTx(ctx, doc.TxWrite{
+			RepoPath:     repoDir,
+			StreamPath:   domain.StreamPath(domain.StreamLayoutSharded, "users", docID),
+			TxBytes:      txBytes,
+			TxHash:       hash.SHA256{}.SumHex(txBytes),
+			Tx:           tx,
+			StatePath:    domain.StatePath(domain.StreamLayoutSharded, "users", docID),
+			StateTxBytes: txBytes,
+		})
+		if err != nil {
+			t.Fatalf("PutTx returned error: %v", err)
+		}
+		keys = append(keys, domain.HDSHash("users", docID))
+	}
+	sort.Strings(keys)
+
+	scan := func(after string) []string {
+		var got []string
+		for entry, err := range store.ScanStates(ctx, repoDir, "users", after) {
+			if err != nil {
+				t.Fatalf("ScanStates returned error: %v", err)
+			}
+			got = append(got, entry.Key)
+		}
+		return got
+	}
+	if got := scan(""); !reflect.DeepEqual(got, keys) {
+		t.Fatalf("expected keys %v in order, got %v", keys, got)
+	}
+	if got := scan(keys[1]); !reflect.DeepEqual(got, keys[2:]) {
+		t.Fatalf("expected scan to resume after %s, got %v", keys[1], got)
+	}
+	if got := scan(keys[4]); len(got) != 0 {
+		t.Fatalf("expected nothing after the last key, got %v", got)
+	}
+	for range store.ScanStates(ctx, repoDir, "missing", "") {
+		t.Fatalf("expected no entries for a missing collection")
+	}
+}
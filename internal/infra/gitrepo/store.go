@@ -7,6 +7,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/go-git/go-git/v5"
 )
@@ -19,6 +20,7 @@ type StoreOptions struct {
 	SignCommits bool
 	SignKey     string
 	HistoryMode domain.HistoryMode
+	TxDecoder   doc.Decoder
 }
 
 func NewStore() *Store {
@@ -15,6 +15,16 @@ import (
 )
 
 func (s *Store) ListDocStreams(ctx context.Context, repoPath string) ([]string, error) {
+	return listStreams(ctx, repoPath, domain.DocumentsRoot)
+}
+
+// ListStateStreams returns the state/ entries, which mirror the document
+// stream paths under the state root.
+func (s *Store) ListStateStreams(ctx context.Context, repoPath string) ([]string, error) {
+	return listStreams(ctx, repoPath, domain.StateRoot)
+}
+
+func listStreams(ctx context.Context, repoPath, root string) ([]string, error) {
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
@@ -26,13 +36,16 @@ func (s *Store) ListDocStreams(ctx context.Context, repoPath string) ([]string,
 		}
 		return nil, err
 	}
+	return listTreeStreams(ctx, tree, root)
+}
 
-	docsTree, err := tree.Tree(domain.DocumentsRoot)
+func listTreeStreams(ctx context.Context, tree *object.Tree, root string) ([]string, error) {
+	docsTree, err := tree.Tree(root)
 	if err != nil {
 		if errors.Is(err, object.ErrDirectoryNotFound) {
 			return nil, nil
 		}
-		return nil, fmt.Errorf("read documents tree: %w", err)
+		return nil, fmt.Errorf("read %s tree: %w", root, err)
 	}
 
 	var streams []string
@@ -48,7 +61,7 @@ func (s *Store) ListDocStreams(ctx context.Context, repoPath string) ([]string,
 		if err != nil {
 			return nil, fmt.Errorf("read collection tree %s: %w", collectionName, err)
 		}
-		collectionBase := path.Join(domain.DocumentsRoot, collectionName)
+		collectionBase := path.Join(root, collectionName)
 		colStreams, err := collectDocStreams(ctx, collectionTree, collectionBase)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,510 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/utils/merkletrie"
+)
+
+const remoteMainRefName = "refs/remotes/origin/main"
+
+func (s *Store) FetchRemote(ctx context.Context, repoPath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("open git repo: %w", err)
+	}
+	found, err := fetchOrigin(ctx, repo)
+	if err != nil || !found {
+		return "", err
+	}
+	return remoteMainRefName, nil
+}
+
+func (s *Store) CompareRemote(ctx context.Context, repoPath, ref string) (doc.SyncDivergence, error) {
+	if err := ctx.Err(); err != nil {
+		return doc.SyncDivergence{}, err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return doc.SyncDivergence{}, fmt.Errorf("open git repo: %w", err)
+	}
+
+	divergence := doc.SyncDivergence{Ref: ref}
+	local, err := referenceCommit(repo, plumbing.ReferenceName(mainRefName))
+	if err != nil {
+		return doc.SyncDivergence{}, err
+	}
+	remote, err := referenceCommit(repo, plumbing.ReferenceName(ref))
+	if err != nil {
+		return doc.SyncDivergence{}, err
+	}
+	if local != nil {
+		divergence.LocalHead = local.Hash.String()
+	}
+	if remote != nil {
+		divergence.RemoteHead = remote.Hash.String()
+	}
+	if local == nil || remote == nil || local.Hash == remote.Hash {
+		return divergence, nil
+	}
+
+	base, err := mergeBase(local, remote)
+	if err != nil {
+		return doc.SyncDivergence{}, err
+	}
+	if base != nil {
+		divergence.BaseHead = base.Hash.String()
+		if base.Hash == local.Hash || base.Hash == remote.Hash {
+			return divergence, nil
+		}
+	}
+
+	localChanges, err := changesSince(ctx, base, local)
+	if err != nil {
+		return doc.SyncDivergence{}, err
+	}
+	remoteChanges, err := changesSince(ctx, base, remote)
+	if err != nil {
+		return doc.SyncDivergence{}, err
+	}
+
+	streams := make(map[string]struct{})
+	for filePath, remoteHash := range remoteChanges {
+		localHash, ok := localChanges[filePath]
+		if !ok || localHash == remoteHash || isIndexPath(filePath) {
+			continue
+		}
+		streamPath, ok := streamRootOf(filePath)
+		if !ok {
+			return doc.SyncDivergence{}, fmt.Errorf("%w: %s", doc.ErrSyncPathConflict, filePath)
+		}
+		if strings.HasPrefix(filePath, domain.DocumentsRoot+"/") {
+			streams[streamPath] = struct{}{}
+		}
+	}
+	for streamPath := range streams {
+		divergence.Streams = append(divergence.Streams, streamPath)
+	}
+	sort.Strings(divergence.Streams)
+	return divergence, nil
+}
+
+func (s *Store) FastForward(ctx context.Context, repoPath string, divergence doc.SyncDivergence) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("open git repo: %w", err)
+	}
+	if s.options.TxDecoder != nil {
+		if err := s.checkFastForward(ctx, repo, divergence); err != nil {
+			return err
+		}
+	}
+	return setMainRef(repo, divergence.LocalHead, plumbing.NewHash(divergence.RemoteHead))
+}
+
+// checkFastForward verifies the transactions a fast-forward would adopt. The
+// local trusted keys apply; a repository with no local commits uses the
+// remote's.
+func (s *Store) checkFastForward(ctx context.Context, repo *git.Repository, divergence doc.SyncDivergence) error {
+	remote, err := repo.CommitObject(plumbing.NewHash(divergence.RemoteHead))
+	if err != nil {
+		return fmt.Errorf("read remote commit: %w", err)
+	}
+	remoteTree, err := remote.Tree()
+	if err != nil {
+		return fmt.Errorf("read remote tree: %w", err)
+	}
+	var base *object.Commit
+	policyTree := remoteTree
+	if divergence.BaseHead != "" {
+		base, err = repo.CommitObject(plumbing.NewHash(divergence.BaseHead))
+		if err != nil {
+			return fmt.Errorf("read merge base commit: %w", err)
+		}
+		policyTree, err = base.Tree()
+		if err != nil {
+			return fmt.Errorf("read merge base tree: %w", err)
+		}
+	}
+	remoteChanges, err := changesSince(ctx, base, remote)
+	if err != nil {
+		return err
+	}
+	return s.checkRemoteTxs(policyTree, remoteTree, remoteChanges)
+}
+
+// checkRemoteTxs decodes every transaction blob the remote added, which
+// verifies signed envelopes, and applies the trusted keys in policyTree.
+func (s *Store) checkRemoteTxs(policyTree, remoteTree *object.Tree, remoteChanges map[string]plumbing.Hash) error {
+	txPaths := make([]string, 0, len(remoteChanges))
+	for filePath, blobHash := range remoteChanges {
+		if blobHash.IsZero() || !strings.HasPrefix(filePath, domain.DocumentsRoot+"/") || path.Ext(filePath) != domain.TxFileExt {
+			continue
+		}
+		txPaths = append(txPaths, filePath)
+	}
+	sort.Strings(txPaths)
+
+	trusted := make(map[string][]string)
+	for _, filePath := range txPaths {
+		txBytes, err := readTreeFile(remoteTree, filePath)
+		if err != nil {
+			return fmt.Errorf("read remote tx %s: %w", filePath, err)
+		}
+		tx, err := s.options.TxDecoder.Decode(txBytes)
+		if err != nil {
+			return fmt.Errorf("remote tx %s: %w", filePath, err)
+		}
+		if err := checkTrustedSigner(policyTree, stagedTxWrite{tx: tx, decoded: true}, trusted); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) CommitMerge(ctx context.Context, repoPath string, divergence doc.SyncDivergence, writes []doc.TxWrite) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("open git repo: %w", err)
+	}
+
+	local, err := repo.CommitObject(plumbing.NewHash(divergence.LocalHead))
+	if err != nil {
+		return "", fmt.Errorf("read local commit: %w", err)
+	}
+	remote, err := repo.CommitObject(plumbing.NewHash(divergence.RemoteHead))
+	if err != nil {
+		return "", fmt.Errorf("read remote commit: %w", err)
+	}
+	var base *object.Commit
+	if divergence.BaseHead != "" {
+		base, err = repo.CommitObject(plumbing.NewHash(divergence.BaseHead))
+		if err != nil {
+			return "", fmt.Errorf("read merge base commit: %w", err)
+		}
+	}
+
+	localTree, err := local.Tree()
+	if err != nil {
+		return "", fmt.Errorf("read local tree: %w", err)
+	}
+	remoteTree, err := remote.Tree()
+	if err != nil {
+		return "", fmt.Errorf("read remote tree: %w", err)
+	}
+	localChanges, err := changesSince(ctx, base, local)
+	if err != nil {
+		return "", err
+	}
+	remoteChanges, err := changesSince(ctx, base, remote)
+	if err != nil {
+		return "", err
+	}
+
+	if s.options.TxDecoder != nil {
+		if err := s.checkRemoteTxs(localTree, remoteTree, remoteChanges); err != nil {
+			return "", err
+		}
+	}
+
+	merged := make(map[string]struct{}, len(divergence.Streams))
+	for _, streamPath := range divergence.Streams {
+		merged[normalizeTreePath(streamPath)] = struct{}{}
+	}
+
+	remotePaths := make([]string, 0, len(remoteChanges))
+	for filePath := range remoteChanges {
+		remotePaths = append(remotePaths, filePath)
+	}
+	sort.Strings(remotePaths)
+
+	// Index entries are derived from state, so remote ones are dropped and the
+	// affected collections are rebuilt from the merged tree below.
+	reindex := make(map[string]struct{})
+	treeHash := local.TreeHash
+	for _, filePath := range remotePaths {
+		if collection, ok := indexedCollectionOf(filePath); ok {
+			reindex[collection] = struct{}{}
+		}
+		if isIndexPath(filePath) {
+			continue
+		}
+		if streamPath, ok := streamRootOf(filePath); ok {
+			if _, skip := merged[streamPath]; skip {
+				continue
+			}
+		}
+		remoteHash := remoteChanges[filePath]
+		if localHash, ok := localChanges[filePath]; ok {
+			if localHash == remoteHash {
+				continue
+			}
+			return "", fmt.Errorf("%w: %s", doc.ErrSyncPathConflict, filePath)
+		}
+		if remoteHash.IsZero() {
+			treeHash, err = removeTreePath(repo.Storer, treeHash, filePath)
+			if err != nil {
+				return "", err
+			}
+			continue
+		}
+		file, err := remoteTree.File(filePath)
+		if err != nil {
+			return "", fmt.Errorf("read remote file %s: %w", filePath, err)
+		}
+		treeHash, err = updateTree(repo.Storer, treeHash, filePath, remoteHash, file.Mode)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	trusted := make(map[string][]string)
+	for _, write := range writes {
+		item, err := s.stageTxWrite(repo, write)
+		if err != nil {
+			return "", err
+		}
+		if err := checkTrustedSigner(localTree, item, trusted); err != nil {
+			return "", err
+		}
+		currentHead, err := loadStreamHeadHash(localTree, item.streamPath)
+		if err != nil {
+			return "", err
+		}
+		if currentHead != item.parentHash {
+			return "", domain.ErrHeadChanged
+		}
+		treeHash, err = writeStagedTx(repo.Storer, localTree, treeHash, item)
+		if err != nil {
+			return "", err
+		}
+		if item.stateCollection != "" {
+			reindex[item.stateCollection] = struct{}{}
+		}
+	}
+	treeHash, err = s.reindexMerged(ctx, repo, treeHash, reindex)
+	if err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf("ledgerdb merge %s (%d txs)", strings.TrimPrefix(divergence.Ref, "refs/remotes/"), len(writes))
+	commitHash, err := s.writeCommit(ctx, repoPath, repo, treeHash, []plumbing.Hash{local.Hash, remote.Hash}, message)
+	if err != nil {
+		return "", err
+	}
+	if err := setMainRef(repo, divergence.LocalHead, commitHash); err != nil {
+		return "", err
+	}
+	return commitHash.String(), nil
+}
+
+func (s *Store) reindexMerged(ctx context.Context, repo *git.Repository, treeHash plumbing.Hash, collections map[string]struct{}) (plumbing.Hash, error) {
+	if len(collections) == 0 {
+		return treeHash, nil
+	}
+	mergedTree, err := object.GetTree(repo.Storer, treeHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("load tree: %w", err)
+	}
+	writer, err := s.newIndexWriter(repo.Storer, mergedTree)
+	if err != nil || writer == nil {
+		return treeHash, err
+	}
+	names := make([]string, 0, len(collections))
+	for collection := range collections {
+		names = append(names, collection)
+	}
+	sort.Strings(names)
+	for _, collection := range names {
+		fields, err := readDeclaredIndexes(mergedTree, collection)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		treeHash, err = writer.rebuild(ctx, treeHash, collection, fields)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	return treeHash, nil
+}
+
+func fetchOrigin(ctx context.Context, repo *git.Repository) (bool, error) {
+	auth, found, err := originAuth(repo)
+	if err != nil || !found {
+		return false, err
+	}
+
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs: []config.RefSpec{
+			config.RefSpec("+" + mainRefName + ":" + remoteMainRefName),
+		},
+		Auth: auth,
+	})
+	if err == nil ||
+		errors.Is(err, git.NoErrAlreadyUpToDate) ||
+		errors.Is(err, git.NoMatchingRefSpecError{}) ||
+		errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return true, nil
+	}
+	return false, fmt.Errorf("fetch git repo: %w", err)
+}
+
+func originAuth(repo *git.Repository) (transport.AuthMethod, bool, error) {
+	remote, err := repo.Remote("origin")
+	if err != nil {
+		if errors.Is(err, git.ErrRemoteNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("read git remote: %w", err)
+	}
+	remoteURL := ""
+	if cfg := remote.Config(); cfg != nil && len(cfg.URLs) > 0 {
+		remoteURL = cfg.URLs[0]
+	}
+	auth, err := authForURL(remoteURL)
+	if err != nil {
+		return nil, false, err
+	}
+	return auth, true, nil
+}
+
+func referenceCommit(repo *git.Repository, name plumbing.ReferenceName) (*object.Commit, error) {
+	ref, err := repo.Reference(name, true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read %s: %w", name, err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("read commit %s: %w", name, err)
+	}
+	return commit, nil
+}
+
+func mergeBase(local, remote *object.Commit) (*object.Commit, error) {
+	bases, err := local.MergeBase(remote)
+	if err != nil {
+		return nil, fmt.Errorf("find merge base: %w", err)
+	}
+	if len(bases) == 0 {
+		return nil, nil
+	}
+	return bases[0], nil
+}
+
+// changesSince maps every file path that differs between base and head to
+// its blob hash at head; deleted paths map to the zero hash.
+func changesSince(ctx context.Context, base, head *object.Commit) (map[string]plumbing.Hash, error) {
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read commit tree: %w", err)
+	}
+	var baseTree *object.Tree
+	if base != nil {
+		baseTree, err = base.Tree()
+		if err != nil {
+			return nil, fmt.Errorf("read merge base tree: %w", err)
+		}
+	}
+
+	changes, err := object.DiffTreeContext(ctx, baseTree, headTree)
+	if err != nil {
+		return nil, fmt.Errorf("diff trees: %w", err)
+	}
+	out := make(map[string]plumbing.Hash, len(changes))
+	for _, change := range changes {
+		action, err := change.Action()
+		if err != nil {
+			return nil, fmt.Errorf("read change action: %w", err)
+		}
+		if action == merkletrie.Delete {
+			out[change.From.Name] = plumbing.ZeroHash
+			continue
+		}
+		out[change.To.Name] = change.To.TreeEntry.Hash
+	}
+	return out, nil
+}
+
+// streamRootOf returns the document stream that owns a path under the
+// documents or state roots. State paths map to their document stream.
+func streamRootOf(filePath string) (string, bool) {
+	parts := strings.Split(normalizeTreePath(filePath), "/")
+	if len(parts) < 3 {
+		return "", false
+	}
+	if parts[0] != domain.DocumentsRoot && parts[0] != domain.StateRoot {
+		return "", false
+	}
+	for i := 2; i < len(parts); i++ {
+		if strings.HasPrefix(parts[i], "DOC_") {
+			return path.Join(append([]string{domain.DocumentsRoot}, parts[1:i+1]...)...), true
+		}
+	}
+	return "", false
+}
+
+func isIndexPath(filePath string) bool {
+	return strings.HasPrefix(normalizeTreePath(filePath), domain.IndexesRoot+"/")
+}
+
+// indexedCollectionOf reports the collection whose index entries depend on a
+// changed path: its state, its index declarations or the entries themselves.
+func indexedCollectionOf(filePath string) (string, bool) {
+	parts := strings.Split(normalizeTreePath(filePath), "/")
+	if len(parts) < 3 {
+		return "", false
+	}
+	switch parts[0] {
+	case domain.StateRoot, domain.IndexesRoot:
+		return parts[1], true
+	case domain.CollectionsRoot:
+		return parts[1], parts[len(parts)-1] == domain.CollectionIndexesFile
+	}
+	return "", false
+}
+
+func setMainRef(repo *git.Repository, expected string, target plumbing.Hash) error {
+	refName := plumbing.ReferenceName(mainRefName)
+	newRef := plumbing.NewHashReference(refName, target)
+	var oldRef *plumbing.Reference
+	if expected != "" {
+		oldRef = plumbing.NewHashReference(refName, plumbing.NewHash(expected))
+	}
+	if err := repo.Storer.CheckAndSetReference(newRef, oldRef); err != nil {
+		if errors.Is(err, storage.ErrReferenceHasChanged) {
+			return domain.ErrHeadChanged
+		}
+		return fmt.Errorf("update main ref: %w", err)
+	}
+	return nil
+}
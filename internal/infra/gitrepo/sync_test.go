@@ -0,0 +1,196 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestCommitMergeCombinesDivergentHistories(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	origin, alice, bob := initSyncRepos(t, ctx, store)
+
+	base := domain.Transaction{TxID: "01HBASE", Timestamp: 1, Collection: "users", DocID: "shared", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	streamPath, baseHash, _ := writeTx(t, ctx, store, alice, base)
+	if err := store.Push(ctx, alice); err != nil {
+		t.Fatalf("Push returned error: %v", err)
+	}
+	if err := store.Fetch(ctx, bob); err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+
+	_, aliceHead, _ := writeTx(t, ctx, store, alice, domain.Transaction{TxID: "01HALICE", Timestamp: 2, Collection: "users", DocID: "shared", Op: domain.TxOpPut, Snapshot: []byte(`{"a":2}`), ParentHash: baseHash})
+	aliceOnly, _, _ := writeTx(t, ctx, store, alice, domain.Transaction{TxID: "01HALICE2", Timestamp: 3, Collection: "users", DocID: "alice", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	if err := store.Push(ctx, alice); err != nil {
+		t.Fatalf("Push returned error: %v", err)
+	}
+	_, bobHead, _ := writeTx(t, ctx, store, bob, domain.Transaction{TxID: "01HBOB", Timestamp: 4, Collection: "users", DocID: "shared", Op: domain.TxOpPut, Snapshot: []byte(`{"a":3}`), ParentHash: baseHash})
+	bobOnly, _, _ := writeTx(t, ctx, store, bob, domain.Transaction{TxID: "01HBOB2", Timestamp: 5, Collection: "users", DocID: "bob", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	if err := store.Push(ctx, bob); !errors.Is(err, domain.ErrSyncConflict) {
+		t.Fatalf("expected ErrSyncConflict, got %v", err)
+	}
+	if err := store.Fetch(ctx, bob); err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if head, _ := store.LoadStreamHead(ctx, bob, streamPath); head != bobHead {
+		t.Fatalf("expected fetch to keep local head %s, got %s", bobHead, head)
+	}
+
+	ref, err := store.FetchRemote(ctx, bob)
+	if err != nil {
+		t.Fatalf("FetchRemote returned error: %v", err)
+	}
+	divergence, err := store.CompareRemote(ctx, bob, ref)
+	if err != nil {
+		t.Fatalf("CompareRemote returned error: %v", err)
+	}
+	if !reflect.DeepEqual(divergence.Streams, []string{streamPath}) {
+		t.Fatalf("expected only the shared stream to diverge, got %v", divergence.Streams)
+	}
+	if divergence.BaseHead == "" || divergence.BaseHead == divergence.LocalHead || divergence.BaseHead == divergence.RemoteHead {
+		t.Fatalf("expected a distinct merge base, got %+v", divergence)
+	}
+
+	theirs, err := store.LoadStreamTxsAt(ctx, bob, ref, streamPath)
+	if err != nil {
+		t.Fatalf("LoadStreamTxsAt returned error: %v", err)
+	}
+	write := buildTxWrite(t, bob, streamPath, domain.Transaction{
+		TxID:         "01HMERGE",
+		Timestamp:    6,
+		Collection:   "users",
+		DocID:        "shared",
+		Op:           domain.TxOpMerge,
+		Snapshot:     []byte(`{"a":3}`),
+		ParentHash:   bobHead,
+		MergeParents: []string{aliceHead},
+	})
+	write.ImportTxs = theirs
+
+	commitHash, err := store.CommitMerge(ctx, bob, divergence, []doc.TxWrite{write})
+	if err != nil {
+		t.Fatalf("CommitMerge returned error: %v", err)
+	}
+
+	repo, err := git.PlainOpen(bob)
+	if err != nil {
+		t.Fatalf("open repo: %v", err)
+	}
+	commit, err := repo.CommitObject(plumbing.NewHash(commitHash))
+	if err != nil {
+		t.Fatalf("read merge commit: %v", err)
+	}
+	wantParents := []plumbing.Hash{plumbing.NewHash(divergence.LocalHead), plumbing.NewHash(divergence.RemoteHead)}
+	if !reflect.DeepEqual(commit.ParentHashes, wantParents) {
+		t.Fatalf("expected parents %v, got %v", wantParents, commit.ParentHashes)
+	}
+
+	if head, _ := store.LoadStreamHead(ctx, bob, streamPath); head != write.TxHash {
+		t.Fatalf("expected merged head %s, got %s", write.TxHash, head)
+	}
+	txs, err := store.LoadStreamTxs(ctx, bob, streamPath)
+	if err != nil {
+		t.Fatalf("LoadStreamTxs returned error: %v", err)
+	}
+	if len(txs) != 4 {
+		t.Fatalf("expected base, both sides and merge tx, got %d", len(txs))
+	}
+	for _, stream := range []string{aliceOnly, bobOnly} {
+		if head, _ := store.LoadStreamHead(ctx, bob, stream); head == "" {
+			t.Fatalf("expected stream %s in merged tree", stream)
+		}
+	}
+
+	indexed, err := store.CommitTxs(ctx, bob, commitHash)
+	if err != nil {
+		t.Fatalf("CommitTxs returned error: %v", err)
+	}
+	if len(indexed) != 3 {
+		t.Fatalf("expected alice's two txs and the merge tx, got %d", len(indexed))
+	}
+	commits, err := store.ListCommitHashes(ctx, bob, divergence.LocalHead)
+	if err != nil {
+		t.Fatalf("ListCommitHashes returned error: %v", err)
+	}
+	if !reflect.DeepEqual(commits, []string{commitHash}) {
+		t.Fatalf("expected only the merge commit after the local head, got %v", commits)
+	}
+
+	if err := store.Push(ctx, bob); err != nil {
+		t.Fatalf("Push after merge returned error: %v", err)
+	}
+	remoteHead, err := store.LoadStreamHeadAt(ctx, origin, mainRefName, streamPath)
+	if err != nil {
+		t.Fatalf("LoadStreamHeadAt returned error: %v", err)
+	}
+	if remoteHead != write.TxHash {
+		t.Fatalf("expected origin to hold merged head, got %s", remoteHead)
+	}
+}
+
+func TestCompareRemoteDetectsFastForward(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	_, alice, bob := initSyncRepos(t, ctx, store)
+
+	writeTx(t, ctx, store, alice, domain.Transaction{TxID: "01HA", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+	if err := store.Push(ctx, alice); err != nil {
+		t.Fatalf("Push returned error: %v", err)
+	}
+
+	ref, err := store.FetchRemote(ctx, bob)
+	if err != nil {
+		t.Fatalf("FetchRemote returned error: %v", err)
+	}
+	divergence, err := store.CompareRemote(ctx, bob, ref)
+	if err != nil {
+		t.Fatalf("CompareRemote returned error: %v", err)
+	}
+	if divergence.LocalHead != "" || divergence.RemoteHead == "" {
+		t.Fatalf("unexpected divergence: %+v", divergence)
+	}
+	if err := store.FastForward(ctx, bob, divergence); err != nil {
+		t.Fatalf("FastForward returned error: %v", err)
+	}
+
+	repo, err := git.PlainOpen(bob)
+	if err != nil {
+		t.Fatalf("open repo: %v", err)
+	}
+	ref2, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+	if err != nil {
+		t.Fatalf("read main: %v", err)
+	}
+	if ref2.Hash().String() != divergence.RemoteHead {
+		t.Fatalf("expected main at %s, got %s", divergence.RemoteHead, ref2.Hash())
+	}
+}
+
+func initSyncRepos(t *testing.T, ctx context.Context, store *Store) (string, string, string) {
+	t.Helper()
+
+	origin := t.TempDir()
+	if err := store.Init(ctx, origin); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	var clones []string
+	for i := 0; i < 2; i++ {
+		dir := t.TempDir()
+		if err := store.Init(ctx, dir); err != nil {
+			t.Fatalf("Init returned error: %v", err)
+		}
+		if err := store.SetRemote(ctx, dir, "origin", origin); err != nil {
+			t.Fatalf("SetRemote returned error: %v", err)
+		}
+		clones = append(clones, dir)
+	}
+	return origin, clones[0], clones[1]
+}
@@ -0,0 +1,149 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestPutTxsWritesSingleCommit(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	streamA := domain.StreamPath(domain.StreamLayoutFlat, "accounts", "a")
+	streamB := domain.StreamPath(domain.StreamLayoutFlat, "accounts", "b")
+	putA := buildTxWrite(t, repoDir, streamA, domain.Transaction{
+		TxID: "01HA1", Timestamp: 1, Collection: "accounts", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":10}`),
+	})
+	patchA := buildTxWrite(t, repoDir, streamA, domain.Transaction{
+		TxID: "01HA2", Timestamp: 2, Collection: "accounts", DocID: "a", Op: domain.TxOpPatch,
+		Patch: []byte(`[{"op":"replace","path":"/balance","value":5}]`), ParentHash: putA.TxHash,
+	})
+	putB := buildTxWrite(t, repoDir, streamB, domain.Transaction{
+		TxID: "01HB1", Timestamp: 3, Collection: "accounts", DocID: "b", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":5}`),
+	})
+
+	commitHash, err := store.PutTxs(ctx, repoDir, []doc.TxWrite{putA, patchA, putB})
+	if err != nil {
+		t.Fatalf("PutTxs returned error: %v", err)
+	}
+
+	repo, err := git.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("open repo: %v", err)
+	}
+	ref, err := repo.Reference(plumbing.ReferenceName(mainRefName), true)
+	if err != nil {
+		t.Fatalf("read main ref: %v", err)
+	}
+	if ref.Hash().String() != commitHash {
+		t.Fatalf("expected main at %s, got %s", commitHash, ref.Hash())
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("read commit: %v", err)
+	}
+	if commit.NumParents() != 0 {
+		t.Fatalf("expected a single root commit, got %d parents", commit.NumParents())
+	}
+
+	headA, err := store.LoadStreamHead(ctx, repoDir, streamA)
+	if err != nil {
+		t.Fatalf("LoadStreamHead returned error: %v", err)
+	}
+	if headA != patchA.TxHash {
+		t.Fatalf("expected head %s, got %s", patchA.TxHash, headA)
+	}
+	txs, err := store.LoadStreamTxs(ctx, repoDir, streamA)
+	if err != nil {
+		t.Fatalf("LoadStreamTxs returned error: %v", err)
+	}
+	if len(txs) != 2 {
+		t.Fatalf("expected 2 txs in stream a, got %d", len(txs))
+	}
+}
+
+func TestPutTxsRejectsWholeBatchOnStaleHead(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	streamA, headA, _ := writeTx(t, ctx, store, repoDir, domain.Transaction{
+		TxID: "01HA1", Timestamp: 1, Collection: "accounts", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":10}`),
+	})
+	streamB := domain.StreamPath(domain.StreamLayoutSharded, "accounts", "b")
+
+	putB := buildTxWrite(t, repoDir, streamB, domain.Transaction{
+		TxID: "01HB1", Timestamp: 2, Collection: "accounts", DocID: "b", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":5}`),
+	})
+	staleA := buildTxWrite(t, repoDir, streamA, domain.Transaction{
+		TxID: "01HA2", Timestamp: 3, Collection: "accounts", DocID: "a", Op: domain.TxOpPut,
+		Snapshot: []byte(`{"balance":5}`), ParentHash: "stale",
+	})
+
+	_, err := store.PutTxs(ctx, repoDir, []doc.TxWrite{putB, staleA})
+	if !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+
+	headB, err := store.LoadStreamHead(ctx, repoDir, streamB)
+	if err != nil {
+		t.Fatalf("LoadStreamHead returned error: %v", err)
+	}
+	if headB != "" {
+		t.Fatalf("expected stream b to be untouched, got head %s", headB)
+	}
+	current, err := store.LoadStreamHead(ctx, repoDir, streamA)
+	if err != nil {
+		t.Fatalf("LoadStreamHead returned error: %v", err)
+	}
+	if current != headA {
+		t.Fatalf("expected stream a head %s, got %s", headA, current)
+	}
+}
+
+func TestPutTxEnforcesIfMatchInAmendMode(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStoreWithOptions(StoreOptions{HistoryMode: domain.HistoryModeAmend})
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	streamA, headA, _ := writeTx(t, ctx, store, repoDir, domain.Transaction{
+		TxID: "01HA1", Timestamp: 1, Collection: "accounts", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":10}`),
+	})
+
+	stale := buildTxWrite(t, repoDir, streamA, domain.Transaction{
+		TxID: "01HA2", Timestamp: 2, Collection: "accounts", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{"balance":5}`),
+	})
+	stale.IfMatch = "stale"
+	if _, err := store.PutTx(ctx, stale); !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged, got %v", err)
+	}
+
+	create := stale
+	create.IfMatch = ""
+	create.IfNoneMatch = true
+	if _, err := store.PutTx(ctx, create); !errors.Is(err, domain.ErrHeadChanged) {
+		t.Fatalf("expected ErrHeadChanged for if-none-match, got %v", err)
+	}
+
+	current := stale
+	current.IfMatch = headA
+	if _, err := store.PutTx(ctx, current); err != nil {
+		t.Fatalf("PutTx with matching head returned error: %v", err)
+	}
+}
@@ -28,7 +28,10 @@ func (s *Store) LoadHeadTx(ctx context.Context, repoPath, streamPath string) (do
 		}
 		return doc.TxBlob{}, err
 	}
+	return readHeadTx(tree, streamPath)
+}
 
+func readHeadTx(tree *object.Tree, streamPath string) (doc.TxBlob, error) {
 	streamPath = normalizeTreePath(streamPath)
 	headPath := path.Join(streamPath, domain.StreamHeadFile)
 	headContent, err := readTreeFile(tree, headPath)
@@ -69,6 +72,10 @@ func (s *Store) LoadStreamTxs(ctx context.Context, repoPath, streamPath string)
 		return nil, err
 	}
 
+	return readStreamTxs(ctx, tree, streamPath)
+}
+
+func readStreamTxs(ctx context.Context, tree *object.Tree, streamPath string) ([]doc.TxBlob, error) {
 	streamPath = normalizeTreePath(streamPath)
 	streamTree, err := tree.Tree(streamPath)
 	if err != nil {
@@ -0,0 +1,95 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func (s *Store) LoadStreamHeadAt(ctx context.Context, repoPath, ref, streamPath string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	tree, err := loadRefTree(repoPath, ref)
+	if err != nil {
+		return "", err
+	}
+	return loadStreamHeadHash(tree, normalizeTreePath(streamPath))
+}
+
+func (s *Store) LoadStreamTxsAt(ctx context.Context, repoPath, ref, streamPath string) ([]doc.TxBlob, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadRefTree(repoPath, ref)
+	if err != nil {
+		return nil, err
+	}
+	return readStreamTxs(ctx, tree, streamPath)
+}
+
+// CommitAsOf returns the newest commit on main's first-parent history that was
+// committed at or before asOf, or "" when main is younger than asOf.
+func (s *Store) CommitAsOf(ctx context.Context, repoPath string, asOf time.Time) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("open git repo: %w", err)
+	}
+	commit, err := referenceCommit(repo, plumbing.ReferenceName(mainRefName))
+	if err != nil || commit == nil {
+		return "", err
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if !commit.Committer.When.After(asOf) {
+			return commit.Hash.String(), nil
+		}
+		if commit.NumParents() == 0 {
+			return "", nil
+		}
+		commit, err = commit.Parent(0)
+		if err != nil {
+			return "", fmt.Errorf("read git log: %w", err)
+		}
+	}
+}
+
+func loadRefTree(repoPath, ref string) (*object.Tree, error) {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("open git repo: %w", err)
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, fmt.Errorf("%w: %s", doc.ErrRefNotFound, ref)
+		}
+		return nil, fmt.Errorf("resolve %s: %w", ref, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("read commit %s: %w", ref, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("read commit tree: %w", err)
+	}
+	return tree, nil
+}
@@ -87,15 +87,160 @@ func (s *Store) LoadStreamHead(ctx context.Context, repoPath, streamPath string)
 }
 
 func (s *Store) PutTx(ctx context.Context, write doc.TxWrite) (doc.PutResult, error) {
-	if err := ctx.Err(); err != nil {
+	commitHash, err := s.putTxs(ctx, write.RepoPath, []doc.TxWrite{write}, fmt.Sprintf("ledgerdb tx %s", write.Tx.TxID))
+	if err != nil {
 		return doc.PutResult{}, err
 	}
 
-	repo, err := git.PlainOpen(write.RepoPath)
+	return doc.PutResult{
+		CommitHash: commitHash,
+		TxHash:     write.TxHash,
+		TxID:       write.Tx.TxID,
+	}, nil
+}
+
+func (s *Store) PutTxs(ctx context.Context, repoPath string, writes []doc.TxWrite) (string, error) {
+	if len(writes) == 0 {
+		return "", doc.ErrBatchEmpty
+	}
+	message := fmt.Sprintf("ledgerdb txn %s (%d txs)", writes[0].Tx.TxID, len(writes))
+	return s.putTxs(ctx, repoPath, writes, message)
+}
+
+type stagedTxWrite struct {
+	streamPath        string
+	relTxPath         string
+	txBlobHash        plumbing.Hash
+	headBlobHash      plumbing.Hash
+	statePath         string
+	stateCollection   string
+	stateDocID        string
+	stateDoc          []byte
+	stateTxBlobHash   plumbing.Hash
+	stateHeadBlobHash plumbing.Hash
+	parentHash        string
+	txHash            string
+	ifMatch           string
+	ifNoneMatch       bool
+	imports           []stagedImport
+	// tx is the decoded write, set when a decoder is configured so the
+	// collection's trusted keys can be enforced.
+	tx      domain.Transaction
+	decoded bool
+}
+
+type stagedImport struct {
+	path     string
+	blobHash plumbing.Hash
+}
+
+func (s *Store) putTxs(ctx context.Context, repoPath string, writes []doc.TxWrite, message string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	repo, err := git.PlainOpen(repoPath)
 	if err != nil {
-		return doc.PutResult{}, fmt.Errorf("open git repo: %w", err)
+		return "", fmt.Errorf("open git repo: %w", err)
 	}
 
+	staged := make([]stagedTxWrite, 0, len(writes))
+	for _, write := range writes {
+		item, err := s.stageTxWrite(repo, write)
+		if err != nil {
+			return "", err
+		}
+		staged = append(staged, item)
+	}
+
+	commitHash, err := s.commitTree(ctx, repoPath, repo, message, func(baseTree *object.Tree, baseTreeHash plumbing.Hash) (plumbing.Hash, error) {
+		heads := make(map[string]string, len(staged))
+		trusted := make(map[string][]string)
+		treeHash := baseTreeHash
+		indexes, err := s.newIndexWriter(repo.Storer, baseTree)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		for _, item := range staged {
+			currentHead, ok := heads[item.streamPath]
+			if !ok {
+				var err error
+				currentHead, err = loadStreamHeadHash(baseTree, item.streamPath)
+				if err != nil {
+					return plumbing.ZeroHash, err
+				}
+			}
+			if s.historyMode() != domain.HistoryModeAmend {
+				if currentHead != item.parentHash {
+					return plumbing.ZeroHash, domain.ErrHeadChanged
+				}
+			}
+			if item.ifMatch != "" && currentHead != item.ifMatch {
+				return plumbing.ZeroHash, domain.ErrHeadChanged
+			}
+			if item.ifNoneMatch && currentHead != "" {
+				return plumbing.ZeroHash, domain.ErrHeadChanged
+			}
+			if err := checkTrustedSigner(baseTree, item, trusted); err != nil {
+				return plumbing.ZeroHash, err
+			}
+			heads[item.streamPath] = item.txHash
+
+			treeHash, err = writeStagedTx(repo.Storer, baseTree, treeHash, item)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			if indexes != nil && item.statePath != "" {
+				treeHash, err = indexes.update(treeHash, item.statePath, item.stateCollection, item.stateDocID, item.stateDoc)
+				if err != nil {
+					return plumbing.ZeroHash, err
+				}
+			}
+		}
+		return treeHash, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return commitHash.String(), nil
+}
+
+func writeStagedTx(s storer.EncodedObjectStorer, baseTree *object.Tree, treeHash plumbing.Hash, item stagedTxWrite) (plumbing.Hash, error) {
+	var err error
+	for _, imported := range item.imports {
+		if baseTree != nil {
+			if _, err := baseTree.File(imported.path); err == nil {
+				continue
+			}
+		}
+		treeHash, err = updateTree(s, treeHash, imported.path, imported.blobHash, filemode.Regular)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	treeHash, err = updateTree(s, treeHash, path.Join(item.streamPath, item.relTxPath), item.txBlobHash, filemode.Regular)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	treeHash, err = updateTree(s, treeHash, path.Join(item.streamPath, domain.StreamHeadFile), item.headBlobHash, filemode.Regular)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if item.statePath != "" {
+		relStateTxPath := path.Join(domain.TxDirName, domain.TxCompactFile)
+		treeHash, err = updateTree(s, treeHash, path.Join(item.statePath, relStateTxPath), item.stateTxBlobHash, filemode.Regular)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		treeHash, err = updateTree(s, treeHash, path.Join(item.statePath, domain.StreamHeadFile), item.stateHeadBlobHash, filemode.Regular)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	return treeHash, nil
+}
+
+func (s *Store) stageTxWrite(repo *git.Repository, write doc.TxWrite) (stagedTxWrite, error) {
 	streamPath := normalizeTreePath(write.StreamPath)
 	txFileName := txFileName(write.Tx)
 	if s.historyMode() == domain.HistoryModeAmend {
@@ -105,99 +250,126 @@ func (s *Store) PutTx(ctx context.Context, write doc.TxWrite) (doc.PutResult, er
 
 	txBlobHash, err := writeBlob(repo.Storer, write.TxBytes)
 	if err != nil {
-		return doc.PutResult{}, err
+		return stagedTxWrite{}, err
 	}
 
 	headBlobHash, err := writeBlob(repo.Storer, []byte(relTxPath+"\n"))
 	if err != nil {
-		return doc.PutResult{}, err
+		return stagedTxWrite{}, err
 	}
 
-	statePath := ""
-	var stateTxBlobHash plumbing.Hash
-	var stateHeadBlobHash plumbing.Hash
+	item := stagedTxWrite{
+		streamPath:   streamPath,
+		relTxPath:    relTxPath,
+		txBlobHash:   txBlobHash,
+		headBlobHash: headBlobHash,
+		parentHash:   write.Tx.ParentHash,
+		txHash:       write.TxHash,
+		ifMatch:      write.IfMatch,
+		ifNoneMatch:  write.IfNoneMatch,
+	}
+	if item.txHash == "" {
+		item.txHash = hashBytes(write.TxBytes)
+	}
+	if s.options.TxDecoder != nil {
+		item.tx, err = s.options.TxDecoder.Decode(write.TxBytes)
+		if err != nil {
+			return stagedTxWrite{}, err
+		}
+		item.decoded = true
+	}
+	for _, blob := range write.ImportTxs {
+		blobHash, err := writeBlob(repo.Storer, blob.Bytes)
+		if err != nil {
+			return stagedTxWrite{}, err
+		}
+		item.imports = append(item.imports, stagedImport{path: normalizeTreePath(blob.Path), blobHash: blobHash})
+	}
 	if write.StatePath != "" && len(write.StateTxBytes) > 0 {
-		statePath = normalizeTreePath(write.StatePath)
-		stateTxBlobHash, err = writeBlob(repo.Storer, write.StateTxBytes)
+		item.statePath = normalizeTreePath(write.StatePath)
+		item.stateCollection = write.StateTx.Collection
+		item.stateDocID = write.StateTx.DocID
+		if write.StateTx.Op != domain.TxOpDelete {
+			item.stateDoc = write.StateTx.Snapshot
+		}
+		item.stateTxBlobHash, err = writeBlob(repo.Storer, write.StateTxBytes)
 		if err != nil {
-			return doc.PutResult{}, err
+			return stagedTxWrite{}, err
 		}
 		relStateTxPath := path.Join(domain.TxDirName, domain.TxCompactFile)
-		stateHeadBlobHash, err = writeBlob(repo.Storer, []byte(relStateTxPath+"\n"))
+		item.stateHeadBlobHash, err = writeBlob(repo.Storer, []byte(relStateTxPath+"\n"))
 		if err != nil {
-			return doc.PutResult{}, err
+			return stagedTxWrite{}, err
 		}
 	}
+	return item, nil
+}
 
+// checkTrustedSigner enforces the trusted keys declared in tree for the
+// collection of a staged write. trusted caches keys per collection.
+func checkTrustedSigner(tree *object.Tree, item stagedTxWrite, trusted map[string][]string) error {
+	if !item.decoded {
+		return nil
+	}
+	keys, ok := trusted[item.tx.Collection]
+	if !ok {
+		var err error
+		keys, err = readCollectionFields(tree, item.tx.Collection, domain.CollectionKeysFile)
+		if err != nil {
+			return err
+		}
+		trusted[item.tx.Collection] = keys
+	}
+	return domain.CheckSigner(keys, item.tx)
+}
+
+func (s *Store) commitTree(ctx context.Context, repoPath string, repo *git.Repository, message string, edit func(baseTree *object.Tree, baseTreeHash plumbing.Hash) (plumbing.Hash, error)) (plumbing.Hash, error) {
 	refName := plumbing.ReferenceName(mainRefName)
 	for attempt := 0; attempt < casMaxRetries; attempt++ {
 		if err := ctx.Err(); err != nil {
-			return doc.PutResult{}, err
+			return plumbing.ZeroHash, err
 		}
 
 		baseRef, baseTree, baseTreeHash, err := loadBaseTree(repo, refName)
 		if err != nil {
-			return doc.PutResult{}, err
+			return plumbing.ZeroHash, err
 		}
 
-		currentHead, err := loadStreamHeadHash(baseTree, streamPath)
+		treeHash, err := edit(baseTree, baseTreeHash)
 		if err != nil {
-			return doc.PutResult{}, err
+			return plumbing.ZeroHash, err
 		}
-		if s.historyMode() != domain.HistoryModeAmend {
-			if currentHead != write.Tx.ParentHash {
-				return doc.PutResult{}, domain.ErrHeadChanged
-			}
+		if baseRef != nil && treeHash == baseTreeHash {
+			return baseRef.Hash(), nil
 		}
 
-		treeHash := baseTreeHash
-		treeHash, err = updateTree(repo.Storer, treeHash, path.Join(streamPath, relTxPath), txBlobHash, filemode.Regular)
-		if err != nil {
-			return doc.PutResult{}, err
-		}
-		treeHash, err = updateTree(repo.Storer, treeHash, path.Join(streamPath, domain.StreamHeadFile), headBlobHash, filemode.Regular)
-		if err != nil {
-			return doc.PutResult{}, err
-		}
-		if statePath != "" {
-			relStateTxPath := path.Join(domain.TxDirName, domain.TxCompactFile)
-			treeHash, err = updateTree(repo.Storer, treeHash, path.Join(statePath, relStateTxPath), stateTxBlobHash, filemode.Regular)
-			if err != nil {
-				return doc.PutResult{}, err
-			}
-			treeHash, err = updateTree(repo.Storer, treeHash, path.Join(statePath, domain.StreamHeadFile), stateHeadBlobHash, filemode.Regular)
-			if err != nil {
-				return doc.PutResult{}, err
-			}
+		var parents []plumbing.Hash
+		if baseRef != nil && s.historyMode() != domain.HistoryModeAmend {
+			parents = []plumbing.Hash{baseRef.Hash()}
 		}
-
-		commitHash, err := s.writeCommit(ctx, write.RepoPath, repo, treeHash, baseRef, write.Tx.TxID)
+		commitHash, err := s.writeCommit(ctx, repoPath, repo, treeHash, parents, message)
 		if err != nil {
-			return doc.PutResult{}, err
+			return plumbing.ZeroHash, err
 		}
 
 		newRef := plumbing.NewHashReference(refName, commitHash)
 		if err := repo.Storer.CheckAndSetReference(newRef, baseRef); err != nil {
 			if errors.Is(err, storage.ErrReferenceHasChanged) {
 				if attempt == casMaxRetries-1 {
-					return doc.PutResult{}, domain.ErrHeadChanged
+					return plumbing.ZeroHash, domain.ErrHeadChanged
 				}
 				if err := sleepWithBackoff(ctx, attempt); err != nil {
-					return doc.PutResult{}, err
+					return plumbing.ZeroHash, err
 				}
 				continue
 			}
-			return doc.PutResult{}, fmt.Errorf("update main ref: %w", err)
+			return plumbing.ZeroHash, fmt.Errorf("update main ref: %w", err)
 		}
 
-		return doc.PutResult{
-			CommitHash: commitHash.String(),
-			TxHash:     write.TxHash,
-			TxID:       write.Tx.TxID,
-		}, nil
+		return commitHash, nil
 	}
 
-	return doc.PutResult{}, domain.ErrHeadChanged
+	return plumbing.ZeroHash, domain.ErrHeadChanged
 }
 
 func loadStreamHeadHash(tree *object.Tree, streamPath string) (string, error) {
@@ -370,6 +542,69 @@ func updateTreeRecursive(s storer.EncodedObjectStorer, tree *object.Tree, parts
 	return &object.Tree{Entries: entries}, nil
 }
 
+func removeTreePath(s storer.EncodedObjectStorer, baseHash plumbing.Hash, filePath string) (plumbing.Hash, error) {
+	if baseHash.IsZero() {
+		return baseHash, nil
+	}
+	baseTree, err := object.GetTree(s, baseHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("load tree: %w", err)
+	}
+
+	parts := strings.Split(strings.Trim(filePath, "/"), "/")
+	updatedTree, changed, err := removeTreeRecursive(s, baseTree, parts)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if !changed {
+		return baseHash, nil
+	}
+	return writeTree(s, updatedTree)
+}
+
+func removeTreeRecursive(s storer.EncodedObjectStorer, tree *object.Tree, parts []string) (*object.Tree, bool, error) {
+	name := parts[0]
+	changed := false
+	entries := make([]object.TreeEntry, 0, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		if entry.Name != name {
+			entries = append(entries, entry)
+			continue
+		}
+		if len(parts) == 1 {
+			changed = true
+			continue
+		}
+		if entry.Mode != filemode.Dir {
+			entries = append(entries, entry)
+			continue
+		}
+
+		childTree, err := object.GetTree(s, entry.Hash)
+		if err != nil {
+			return nil, false, fmt.Errorf("load tree %s: %w", name, err)
+		}
+		updatedChild, childChanged, err := removeTreeRecursive(s, childTree, parts[1:])
+		if err != nil {
+			return nil, false, err
+		}
+		if !childChanged {
+			entries = append(entries, entry)
+			continue
+		}
+		changed = true
+		if len(updatedChild.Entries) == 0 {
+			continue
+		}
+		childHash, err := writeTree(s, updatedChild)
+		if err != nil {
+			return nil, false, err
+		}
+		entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: childHash})
+	}
+	return &object.Tree{Entries: entries}, changed, nil
+}
+
 func writeTree(s storer.EncodedObjectStorer, tree *object.Tree) (plumbing.Hash, error) {
 	obj := s.NewEncodedObject()
 	if err := tree.Encode(obj); err != nil {
@@ -378,18 +613,14 @@ func writeTree(s storer.EncodedObjectStorer, tree *object.Tree) (plumbing.Hash,
 	return s.SetEncodedObject(obj)
 }
 
-func (s *Store) writeCommit(ctx context.Context, repoPath string, repo *git.Repository, treeHash plumbing.Hash, baseRef *plumbing.Reference, txID string) (plumbing.Hash, error) {
-	parentRef := baseRef
-	if s.historyMode() == domain.HistoryModeAmend {
-		parentRef = nil
-	}
+func (s *Store) writeCommit(ctx context.Context, repoPath string, repo *git.Repository, treeHash plumbing.Hash, parents []plumbing.Hash, message string) (plumbing.Hash, error) {
 	if s.options.SignCommits {
-		return s.writeSignedCommit(ctx, repoPath, treeHash, parentRef, txID)
+		return s.writeSignedCommit(ctx, repoPath, treeHash, parents, message)
 	}
-	return writeUnsignedCommit(repo.Storer, treeHash, parentRef, txID)
+	return writeUnsignedCommit(repo.Storer, treeHash, parents, message)
 }
 
-func writeUnsignedCommit(s storer.EncodedObjectStorer, treeHash plumbing.Hash, baseRef *plumbing.Reference, txID string) (plumbing.Hash, error) {
+func writeUnsignedCommit(s storer.EncodedObjectStorer, treeHash plumbing.Hash, parents []plumbing.Hash, message string) (plumbing.Hash, error) {
 	author := object.Signature{
 		Name:  "ledgerdb",
 		Email: "ledgerdb@local",
@@ -399,13 +630,9 @@ func writeUnsignedCommit(s storer.EncodedObjectStorer, treeHash plumbing.Hash, b
 	commit := &object.Commit{
 		Author:       author,
 		Committer:    author,
-		Message:      fmt.Sprintf("ledgerdb tx %s", txID),
+		Message:      message,
 		TreeHash:     treeHash,
-		ParentHashes: nil,
-	}
-
-	if baseRef != nil {
-		commit.ParentHashes = []plumbing.Hash{baseRef.Hash()}
+		ParentHashes: parents,
 	}
 
 	obj := s.NewEncodedObject()
@@ -419,15 +646,14 @@ func (s *Store) historyMode() domain.HistoryMode {
 	return domain.NormalizeHistoryMode(s.options.HistoryMode)
 }
 
-func (s *Store) writeSignedCommit(ctx context.Context, repoPath string, treeHash plumbing.Hash, baseRef *plumbing.Reference, txID string) (plumbing.Hash, error) {
+func (s *Store) writeSignedCommit(ctx context.Context, repoPath string, treeHash plumbing.Hash, parents []plumbing.Hash, message string) (plumbing.Hash, error) {
 	if err := ctx.Err(); err != nil {
 		return plumbing.ZeroHash, err
 	}
 
-	message := fmt.Sprintf("ledgerdb tx %s", txID)
 	args := []string{"-C", repoPath, "commit-tree", treeHash.String(), "-m", message}
-	if baseRef != nil {
-		args = append(args, "-p", baseRef.Hash().String())
+	for _, parent := range parents {
+		args = append(args, "-p", parent.String())
 	}
 	if s.options.SignKey != "" {
 		args = append(args, "-S"+s.options.SignKey)
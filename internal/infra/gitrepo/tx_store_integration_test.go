@@ -3,10 +3,14 @@ package gitrepo
 import (
 	"bytes"
 	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
 	"path"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
@@ -110,6 +114,45 @@ func TestListDocStreams(t *testing.T) {
 	}
 }
 
+func TestListStateStreams(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	tx := domain.Transaction{TxID: "01HINT1", Timestamp: 1, Collection: "users", DocID: "doc1", Op: domain.TxOpPut, Snapshot: []byte(`{"a":1}`)}
+	txBytes, err := txv3.Encoder{}.Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	statePath := domain.StatePath(domain.StreamLayoutSharded, "users", "doc1")
+	_, err = store.PutTx(ctx, doc.TxWrite{
+		RepoPath:     repoDir,
+		StreamPath:   domain.StreamPath(domain.StreamLayoutSharded, "users", "doc1"),
+		TxBytes:      txBytes,
+		TxHash:       hash.SHA256{}.SumHex(txBytes),
+		Tx:           tx,
+		StatePath:    statePath,
+		StateTxBytes: txBytes,
+		StateTxHash:  hash.SHA256{}.SumHex(txBytes),
+		StateTx:      tx,
+	})
+	if err != nil {
+		t.Fatalf("PutTx returned error: %v", err)
+	}
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HINT2", Timestamp: 2, Collection: "users", DocID: "doc2", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	states, err := store.ListStateStreams(ctx, repoDir)
+	if err != nil {
+		t.Fatalf("ListStateStreams returned error: %v", err)
+	}
+	if !reflect.DeepEqual(states, []string{statePath}) {
+		t.Fatalf("expected state streams %v, got %v", []string{statePath}, states)
+	}
+}
+
 func TestReadBlob(t *testing.T) {
 	ctx := context.Background()
 	repoDir := t.TempDir()
@@ -148,6 +191,37 @@ func TestReadBlob(t *testing.T) {
 	}
 }
 
+func TestCommitAsOf(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+
+	streamPath, txHash, _ := writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HASOF", Timestamp: 1, Collection: "users", DocID: "doc", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	commit, err := store.CommitAsOf(ctx, repoDir, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("CommitAsOf returned error: %v", err)
+	}
+	head, err := store.LoadStreamHeadAt(ctx, repoDir, commit, streamPath)
+	if err != nil {
+		t.Fatalf("LoadStreamHeadAt returned error: %v", err)
+	}
+	if head != txHash {
+		t.Fatalf("expected head %s at %s, got %s", txHash, commit, head)
+	}
+
+	commit, err = store.CommitAsOf(ctx, repoDir, time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("CommitAsOf returned error: %v", err)
+	}
+	if commit != "" {
+		t.Fatalf("expected no commit before the repo existed, got %s", commit)
+	}
+}
+
 func writeTx(t *testing.T, ctx context.Context, store *Store, repoPath string, tx domain.Transaction) (string, string, []byte) {
 	t.Helper()
 
@@ -173,3 +247,98 @@ func writeTx(t *testing.T, ctx context.Context, store *Store, repoPath string, t
 
 	return streamPath, txHash, txBytes
 }
+
+func TestPutTxEnforcesTrustedKeys(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStoreWithOptions(StoreOptions{TxDecoder: txv3.Decoder{}})
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	trustedPub, trustedKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey returned error: %v", err)
+	}
+	if err := store.WriteSchema(ctx, repoDir, "users", []byte(`{}`), nil, nil, []string{hex.EncodeToString(trustedPub)}); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	keys, err := store.LoadTrustedKeys(ctx, repoDir, "users")
+	if err != nil || !reflect.DeepEqual(keys, []string{hex.EncodeToString(trustedPub)}) {
+		t.Fatalf("unexpected trusted keys %v (%v)", keys, err)
+	}
+
+	put := func(encoder doc.Encoder, docID string) error {
+		tx := domain.Transaction{TxID: "tx-" + docID, Timestamp: 1, Collection: "users", DocID: docID, Op: domain.TxOpPut, Snapshot: []byte(`{}`)}
+		txBytes, err := encoder.Encode(tx)
+		if err != nil {
+			t.Fatalf("Encode returned error: %v", err)
+		}
+		_, err = store.PutTx(ctx, doc.TxWrite{
+			RepoPath:   repoDir,
+			StreamPath: domain.StreamPath(domain.StreamLayoutFlat, "users", docID),
+			TxBytes:    txBytes,
+			TxHash:     hash.SHA256{}.SumHex(txBytes),
+			Tx:         tx,
+		})
+		return err
+	}
+	if err := put(txv3.Encoder{}, "a"); !errors.Is(err, domain.ErrUnsignedTx) {
+		t.Fatalf("expected unsigned write to be rejected, got %v", err)
+	}
+	if err := put(txv3.SigningEncoder{Key: otherKey}, "a"); !errors.Is(err, domain.ErrUntrustedSigner) {
+		t.Fatalf("expected untrusted signer to be rejected, got %v", err)
+	}
+	if err := put(txv3.SigningEncoder{Key: trustedKey}, "a"); err != nil {
+		t.Fatalf("expected trusted write to succeed, got %v", err)
+	}
+
+	if err := store.WriteSchema(ctx, repoDir, "users", []byte(`{"type":"object"}`), []string{"name"}, nil, nil); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	if err := put(txv3.Encoder{}, "b"); !errors.Is(err, domain.ErrUnsignedTx) {
+		t.Fatalf("expected re-applying without keys to keep them, got %v", err)
+	}
+	if err := store.WriteSchema(ctx, repoDir, "users", []byte(`{}`), nil, nil, []string{}); err != nil {
+		t.Fatalf("WriteSchema returned error: %v", err)
+	}
+	if err := put(txv3.Encoder{}, "b"); err != nil {
+		t.Fatalf("expected an empty key list to clear the keys, got %v", err)
+	}
+}
+
+func TestListTrustChangesReportsClearedAndRestoredKeys(t *testing.T) {
+	ctx := context.Background()
+	repoDir := t.TempDir()
+	store := NewStore()
+	if err := store.Init(ctx, repoDir); err != nil {
+		t.Fatalf("Init returned error: %v", err)
+	}
+	for _, keys := range [][]string{{"aa"}, nil, {}, {"aa"}} {
+		if err := store.WriteSchema(ctx, repoDir, "users", []byte(`{}`), nil, nil, keys); err != nil {
+			t.Fatalf("WriteSchema returned error: %v", err)
+		}
+	}
+	writeTx(t, ctx, store, repoDir, domain.Transaction{TxID: "01HTRUST", Timestamp: 1, Collection: "users", DocID: "a", Op: domain.TxOpPut, Snapshot: []byte(`{}`)})
+
+	changes, err := store.ListTrustChanges(ctx, repoDir, "")
+	if err != nil {
+		t.Fatalf("ListTrustChanges returned error: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected the apply, clear and restore to be listed, got %+v", changes)
+	}
+	restored, cleared, applied := changes[0], changes[1], changes[2]
+	if !reflect.DeepEqual(restored.Before, []string(nil)) || !reflect.DeepEqual(restored.After, []string{"aa"}) {
+		t.Fatalf("unexpected restore %+v", restored)
+	}
+	if !reflect.DeepEqual(cleared.Before, []string{"aa"}) || cleared.After != nil || cleared.Collection != "users" {
+		t.Fatalf("unexpected clear %+v", cleared)
+	}
+	if applied.Before != nil || !reflect.DeepEqual(applied.After, []string{"aa"}) {
+		t.Fatalf("unexpected apply %+v", applied)
+	}
+}
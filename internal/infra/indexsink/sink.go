@@ -0,0 +1,98 @@
+package indexsink
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/changelog"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/pgindex"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/sqliteindex"
+)
+
+// Sink is an index store the sync service can checkpoint into.
+type Sink interface {
+	indexapp.Store
+	Close() error
+}
+
+type Options struct {
+	Fast bool
+}
+
+// Opener receives the full sink URI and the part after "<scheme>://".
+type Opener func(uri, target string, opts Options) (Sink, error)
+
+var openers = map[string]Opener{
+	"sqlite":     openSQLite,
+	"postgres":   openPostgres,
+	"postgresql": openPostgres,
+	"file":       openNDJSON,
+	"parquet":    openParquet,
+}
+
+// Open selects a sink by URI scheme: sqlite:///path/index.db,
+// postgres://user@host/db, file:///path/out.ndjson or parquet:///path/dir.
+// Paths after "<scheme>://" are used as written, so file://out.ndjson is
+// relative to the working directory.
+func Open(uri string, opts Options) (Sink, error) {
+	scheme, target, ok := strings.Cut(strings.TrimSpace(uri), "://")
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (expected <scheme>://..., one of %s)", indexapp.ErrUnsupportedSink, uri, strings.Join(Schemes(), ", "))
+	}
+	opener, ok := openers[strings.ToLower(scheme)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (expected one of %s)", indexapp.ErrUnsupportedSink, scheme, strings.Join(Schemes(), ", "))
+	}
+	if target == "" {
+		return nil, fmt.Errorf("%w: %q has no target", indexapp.ErrUnsupportedSink, uri)
+	}
+	return opener(uri, target, opts)
+}
+
+// OpenSQLite opens the default sink for a plain database path.
+func OpenSQLite(path string, opts Options) (Sink, error) {
+	return openSQLite("", path, opts)
+}
+
+func Schemes() []string {
+	schemes := make([]string, 0, len(openers))
+	for scheme := range openers {
+		schemes = append(schemes, scheme)
+	}
+	sort.Strings(schemes)
+	return schemes
+}
+
+func openSQLite(_, target string, opts Options) (Sink, error) {
+	store, err := sqliteindex.OpenWithOptions(target, sqliteindex.OpenOptions{Fast: opts.Fast})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func openPostgres(uri, _ string, _ Options) (Sink, error) {
+	store, err := pgindex.Open(uri)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func openNDJSON(_, target string, _ Options) (Sink, error) {
+	store, err := changelog.OpenNDJSON(target)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func openParquet(_, target string, _ Options) (Sink, error) {
+	store, err := changelog.OpenParquet(target)
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
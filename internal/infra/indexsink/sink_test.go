@@ -0,0 +1,49 @@
+package indexsink
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/changelog"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/sqliteindex"
+)
+
+func TestOpenSelectsSinkByScheme(t *testing.T) {
+	dir := t.TempDir()
+	cases := []struct {
+		uri    string
+		sqlite bool
+	}{
+		{uri: "sqlite://" + filepath.Join(dir, "index.db"), sqlite: true},
+		{uri: "file://" + filepath.Join(dir, "out.ndjson")},
+		{uri: "PARQUET://" + filepath.Join(dir, "parquet")},
+	}
+	for _, tc := range cases {
+		sink, err := Open(tc.uri, Options{})
+		if err != nil {
+			t.Fatalf("Open(%q) returned error: %v", tc.uri, err)
+		}
+		_, isSQLite := sink.(*sqliteindex.Store)
+		_, isChangelog := sink.(*changelog.Store)
+		if isSQLite != tc.sqlite || isChangelog == tc.sqlite {
+			t.Fatalf("Open(%q) returned %T", tc.uri, sink)
+		}
+		if _, err := sink.GetState(context.Background()); err != nil {
+			t.Fatalf("GetState on %q returned error: %v", tc.uri, err)
+		}
+		if err := sink.Close(); err != nil {
+			t.Fatalf("Close on %q returned error: %v", tc.uri, err)
+		}
+	}
+}
+
+func TestOpenRejectsUnknownSinks(t *testing.T) {
+	for _, uri := range []string{"mysql://localhost/db", "index.db", "file://"} {
+		if _, err := Open(uri, Options{}); !errors.Is(err, indexapp.ErrUnsupportedSink) {
+			t.Fatalf("Open(%q) expected ErrUnsupportedSink, got %v", uri, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package jsonmerge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+)
+
+const conflictsField = "_conflicts"
+
+var errRootNotObject = errors.New("conflicting documents must be JSON objects")
+
+// Merger reconciles two descendants of a common base field by field.
+// Objects are merged recursively; arrays and scalars are compared as a whole.
+// When both sides changed a field to different values the merged document
+// keeps "ours" and records the field under _conflicts by JSON pointer.
+type Merger struct{}
+
+type absentValue struct{}
+
+var absent = absentValue{}
+
+func (Merger) Merge(ctx context.Context, base, ours, theirs []byte) (doc.MergeOutcome, error) {
+	if err := ctx.Err(); err != nil {
+		return doc.MergeOutcome{}, err
+	}
+
+	baseValue, err := decode(base)
+	if err != nil {
+		return doc.MergeOutcome{}, fmt.Errorf("decode base: %w", err)
+	}
+	oursValue, err := decode(ours)
+	if err != nil {
+		return doc.MergeOutcome{}, fmt.Errorf("decode ours: %w", err)
+	}
+	theirsValue, err := decode(theirs)
+	if err != nil {
+		return doc.MergeOutcome{}, fmt.Errorf("decode theirs: %w", err)
+	}
+
+	conflicts := make(map[string]map[string]any)
+	merged := mergeValue("", baseValue, oursValue, theirsValue, conflicts)
+	if len(conflicts) > 0 {
+		root, ok := merged.(map[string]any)
+		if !ok {
+			return doc.MergeOutcome{}, errRootNotObject
+		}
+		recorded, _ := root[conflictsField].(map[string]any)
+		if recorded == nil {
+			recorded = make(map[string]any, len(conflicts))
+		}
+		for pointer, entry := range conflicts {
+			recorded[pointer] = entry
+		}
+		root[conflictsField] = recorded
+	}
+
+	out, err := json.Marshal(merged)
+	if err != nil {
+		return doc.MergeOutcome{}, fmt.Errorf("encode merge: %w", err)
+	}
+
+	pointers := make([]string, 0, len(conflicts))
+	for pointer := range conflicts {
+		pointers = append(pointers, pointer)
+	}
+	sort.Strings(pointers)
+	return doc.MergeOutcome{Doc: out, Conflicts: pointers}, nil
+}
+
+func mergeValue(pointer string, base, ours, theirs any, conflicts map[string]map[string]any) any {
+	switch {
+	case equal(ours, theirs):
+		return ours
+	case equal(base, ours):
+		return theirs
+	case equal(base, theirs):
+		return ours
+	}
+
+	baseObj, baseIsObj := base.(map[string]any)
+	oursObj, oursIsObj := ours.(map[string]any)
+	theirsObj, theirsIsObj := theirs.(map[string]any)
+	if oursIsObj && theirsIsObj {
+		if !baseIsObj {
+			baseObj = map[string]any{}
+		}
+		merged := make(map[string]any, len(oursObj))
+		for _, key := range unionKeys(baseObj, oursObj, theirsObj) {
+			if pointer == "" && key == conflictsField {
+				if value, ok := oursObj[key]; ok {
+					merged[key] = value
+				}
+				continue
+			}
+			value := mergeValue(pointer+"/"+escapePointer(key), field(baseObj, key), field(oursObj, key), field(theirsObj, key), conflicts)
+			if value != absent {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+
+	conflicts[pointer] = conflictEntry(base, ours, theirs)
+	return ours
+}
+
+func decode(data []byte) (any, error) {
+	if len(data) == 0 {
+		return absent, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func field(obj map[string]any, key string) any {
+	value, ok := obj[key]
+	if !ok {
+		return absent
+	}
+	return value
+}
+
+// conflictEntry leaves out the sides where the field does not exist.
+func conflictEntry(base, ours, theirs any) map[string]any {
+	entry := make(map[string]any, 3)
+	for name, value := range map[string]any{"base": base, "ours": ours, "theirs": theirs} {
+		if value != absent {
+			entry[name] = value
+		}
+	}
+	return entry
+}
+
+func equal(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func unionKeys(objs ...map[string]any) []string {
+	seen := make(map[string]struct{})
+	var keys []string
+	for _, obj := range objs {
+		for key := range obj {
+			if _, ok := seen[key]; ok {
+				continue
+			}
+			seen[key] = struct{}{}
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func escapePointer(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
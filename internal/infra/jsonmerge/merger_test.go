@@ -0,0 +1,71 @@
+package jsonmerge
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMergeCombinesIndependentChanges(t *testing.T) {
+	base := []byte(`{"name":"Ada","profile":{"city":"London","age":36},"tags":["a"]}`)
+	ours := []byte(`{"name":"Ada Lovelace","profile":{"city":"London","age":36},"tags":["a"]}`)
+	theirs := []byte(`{"name":"Ada","profile":{"city":"Paris","age":36},"tags":["a","b"],"active":true}`)
+
+	out, err := (Merger{}).Merge(context.Background(), base, ours, theirs)
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if len(out.Conflicts) != 0 {
+		t.Fatalf("expected no conflicts, got %v", out.Conflicts)
+	}
+	assertJSON(t, out.Doc, `{"active":true,"name":"Ada Lovelace","profile":{"age":36,"city":"Paris"},"tags":["a","b"]}`)
+}
+
+func TestMergeRecordsConflicts(t *testing.T) {
+	base := []byte(`{"status":"new","meta/x":{"n":1},"gone":1}`)
+	ours := []byte(`{"status":"active","meta/x":{"n":2}}`)
+	theirs := []byte(`{"status":"deleted","meta/x":{"n":3},"gone":2}`)
+
+	out, err := (Merger{}).Merge(context.Background(), base, ours, theirs)
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	want := []string{"/gone", "/meta~1x/n", "/status"}
+	if !reflect.DeepEqual(out.Conflicts, want) {
+		t.Fatalf("expected conflicts %v, got %v", want, out.Conflicts)
+	}
+	assertJSON(t, out.Doc, `{
+		"status":"active",
+		"meta/x":{"n":2},
+		"_conflicts":{
+			"/gone":{"base":1,"theirs":2},
+			"/meta~1x/n":{"base":1,"ours":2,"theirs":3},
+			"/status":{"base":"new","ours":"active","theirs":"deleted"}
+		}
+	}`)
+}
+
+func TestMergeWithoutBaseConflictsOnDifferences(t *testing.T) {
+	out, err := (Merger{}).Merge(context.Background(), nil, []byte(`{"a":1,"b":1}`), []byte(`{"a":1,"b":2}`))
+	if err != nil {
+		t.Fatalf("Merge returned error: %v", err)
+	}
+	if !reflect.DeepEqual(out.Conflicts, []string{"/b"}) {
+		t.Fatalf("expected conflict on /b, got %v", out.Conflicts)
+	}
+}
+
+func assertJSON(t *testing.T, got []byte, want string) {
+	t.Helper()
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("decode expectation: %v", err)
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Fatalf("unexpected merge result: %s", got)
+	}
+}
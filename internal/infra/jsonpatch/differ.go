@@ -0,0 +1,145 @@
+package jsonpatch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+)
+
+// Differ produces an RFC 6902 patch that turns one document into another.
+// Objects are compared key by key; arrays element by element, with trailing
+// elements added or removed. A nil document is treated as absent.
+type Differ struct{}
+
+func (Differ) Diff(ctx context.Context, from, to []byte) ([]doc.PatchOp, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case from == nil && to == nil:
+		return nil, nil
+	case from == nil:
+		value, err := decode(to)
+		if err != nil {
+			return nil, fmt.Errorf("decode to: %w", err)
+		}
+		var d differ
+		d.add("add", "", nil, value)
+		return d.ops, d.err
+	case to == nil:
+		value, err := decode(from)
+		if err != nil {
+			return nil, fmt.Errorf("decode from: %w", err)
+		}
+		var d differ
+		d.add("remove", "", value, nil)
+		return d.ops, d.err
+	}
+
+	fromValue, err := decode(from)
+	if err != nil {
+		return nil, fmt.Errorf("decode from: %w", err)
+	}
+	toValue, err := decode(to)
+	if err != nil {
+		return nil, fmt.Errorf("decode to: %w", err)
+	}
+	var d differ
+	d.diff("", fromValue, toValue)
+	return d.ops, d.err
+}
+
+type differ struct {
+	ops []doc.PatchOp
+	err error
+}
+
+func (d *differ) diff(pointer string, from, to any) {
+	if reflect.DeepEqual(from, to) {
+		return
+	}
+
+	fromObj, fromIsObj := from.(map[string]any)
+	toObj, toIsObj := to.(map[string]any)
+	if fromIsObj && toIsObj {
+		keys := make([]string, 0, len(fromObj)+len(toObj))
+		for key := range fromObj {
+			keys = append(keys, key)
+		}
+		for key := range toObj {
+			if _, ok := fromObj[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			path := pointer + "/" + escapePointer(key)
+			fromField, inFrom := fromObj[key]
+			toField, inTo := toObj[key]
+			switch {
+			case !inTo:
+				d.add("remove", path, fromField, nil)
+			case !inFrom:
+				d.add("add", path, nil, toField)
+			default:
+				d.diff(path, fromField, toField)
+			}
+		}
+		return
+	}
+
+	fromArr, fromIsArr := from.([]any)
+	toArr, toIsArr := to.([]any)
+	if fromIsArr && toIsArr {
+		common := min(len(fromArr), len(toArr))
+		for i := 0; i < common; i++ {
+			d.diff(pointer+"/"+strconv.Itoa(i), fromArr[i], toArr[i])
+		}
+		for i := common; i < len(toArr); i++ {
+			d.add("add", pointer+"/"+strconv.Itoa(i), nil, toArr[i])
+		}
+		for i := len(fromArr) - 1; i >= common; i-- {
+			d.add("remove", pointer+"/"+strconv.Itoa(i), fromArr[i], nil)
+		}
+		return
+	}
+
+	d.add("replace", pointer, from, to)
+}
+
+func (d *differ) add(op, path string, old, value any) {
+	if d.err != nil {
+		return
+	}
+	entry := doc.PatchOp{Op: op, Path: path}
+	if op != "add" {
+		entry.Old, d.err = json.Marshal(old)
+	}
+	if op != "remove" && d.err == nil {
+		entry.Value, d.err = json.Marshal(value)
+	}
+	d.ops = append(d.ops, entry)
+}
+
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func escapePointer(token string) string {
+	token = strings.ReplaceAll(token, "~", "~0")
+	return strings.ReplaceAll(token, "/", "~1")
+}
@@ -2,6 +2,8 @@ package jsonpatch
 
 import (
 	"context"
+	"encoding/json"
+	"reflect"
 	"testing"
 )
 
@@ -17,3 +19,48 @@ func TestApplyPatch(t *testing.T) {
 		t.Fatalf("unexpected output: %s", string(out))
 	}
 }
+
+func TestDiffRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	from := []byte(`{"name":"Ada","tags":["a","b","c"],"meta":{"a/b":1,"gone":true},"n":1.50}`)
+	to := []byte(`{"name":"Grace","tags":["a","x"],"meta":{"a/b":2},"n":1.50,"new":null}`)
+
+	ops, err := (Differ{}).Diff(ctx, from, to)
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		t.Fatalf("marshal patch: %v", err)
+	}
+	want := `[{"op":"replace","path":"/meta/a~1b","value":2},{"op":"remove","path":"/meta/gone"},` +
+		`{"op":"replace","path":"/name","value":"Grace"},{"op":"add","path":"/new","value":null},` +
+		`{"op":"replace","path":"/tags/1","value":"x"},{"op":"remove","path":"/tags/2"}]`
+	if string(patch) != want {
+		t.Fatalf("unexpected patch:\n%s", patch)
+	}
+	if string(ops[0].Old) != "1" {
+		t.Fatalf("expected old value 1, got %s", ops[1].Old)
+	}
+
+	out, err := (Patcher{}).Apply(ctx, from, patch)
+	if err != nil {
+		t.Fatalf("Apply returned error: %v", err)
+	}
+	var got, expected any
+	_ = json.Unmarshal(out, &got)
+	_ = json.Unmarshal(to, &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("patch did not reproduce target: %s", out)
+	}
+}
+
+func TestDiffAgainstAbsentDocument(t *testing.T) {
+	ops, err := (Differ{}).Diff(context.Background(), nil, []byte(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("Diff returned error: %v", err)
+	}
+	if len(ops) != 1 || ops[0].Op != "add" || ops[0].Path != "" || string(ops[0].Value) != `{"a":1}` {
+		t.Fatalf("unexpected ops: %+v", ops)
+	}
+}
@@ -0,0 +1,407 @@
+package pgindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+const historyTableSuffix = "_history"
+
+// Store projects collections into PostgreSQL tables with JSONB payloads.
+// Statements stick to SQL that PostgreSQL and SQLite share, so any
+// database/sql handle speaking that dialect can back it.
+type Store struct {
+	db *sql.DB
+}
+
+func Open(dsn string) (*Store, error) {
+	if strings.TrimSpace(dsn) == "" {
+		return nil, errors.New("postgres dsn required")
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres: %w", err)
+	}
+	store, err := NewWithDB(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func NewWithDB(db *sql.DB) (*Store, error) {
+	store := &Store{db: db}
+	if err := store.initSchema(context.Background()); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func (s *Store) Close() error {
+	if s == nil || s.db == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+func (s *Store) DB() *sql.DB {
+	if s == nil {
+		return nil
+	}
+	return s.db
+}
+
+func (s *Store) GetState(ctx context.Context) (indexapp.State, error) {
+	var state indexapp.State
+	err := s.db.QueryRowContext(ctx, "SELECT last_commit, last_state_tree FROM ledger_index_state WHERE id = 1").Scan(&state.LastCommit, &state.LastStateTree)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return indexapp.State{}, nil
+		}
+		return indexapp.State{}, fmt.Errorf("read index state: %w", err)
+	}
+	return state, nil
+}
+
+func (s *Store) Begin(ctx context.Context) (indexapp.StoreTx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin index transaction: %w", err)
+	}
+	return &storeTx{tx: tx, tableCache: make(map[string]string), historyCache: make(map[string]bool)}, nil
+}
+
+func (s *Store) Reset(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin reset transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	rows, err := tx.QueryContext(ctx, "SELECT table_name FROM collection_registry")
+	if err != nil {
+		return fmt.Errorf("list collections: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var tableName string
+		if err := rows.Scan(&tableName); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("scan collection table: %w", err)
+		}
+		tables = append(tables, tableName)
+	}
+	if err := rows.Close(); err != nil {
+		return fmt.Errorf("close collection rows: %w", err)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate collection rows: %w", err)
+	}
+
+	for _, tableName := range tables {
+		for _, name := range []string{tableName + historyTableSuffix, tableName} {
+			if _, err := tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdent(name))); err != nil {
+				return fmt.Errorf("drop table %s: %w", name, err)
+			}
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM collection_registry"); err != nil {
+		return fmt.Errorf("clear collection registry: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE ledger_index_state SET last_commit = '', last_state_tree = '' WHERE id = 1"); err != nil {
+		return fmt.Errorf("reset index state: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit reset: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) initSchema(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS ledger_index_state (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			last_commit TEXT NOT NULL DEFAULT '',
+			last_state_tree TEXT NOT NULL DEFAULT ''
+		)
+	`); err != nil {
+		return fmt.Errorf("create state table: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS collection_registry (
+			collection TEXT PRIMARY KEY,
+			table_name TEXT NOT NULL UNIQUE
+		)
+	`); err != nil {
+		return fmt.Errorf("create collection registry: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, `
+		INSERT INTO ledger_index_state (id, last_commit, last_state_tree) VALUES (1, '', '')
+		ON CONFLICT (id) DO NOTHING
+	`); err != nil {
+		return fmt.Errorf("seed state table: %w", err)
+	}
+	return nil
+}
+
+type storeTx struct {
+	tx           *sql.Tx
+	tableCache   map[string]string
+	historyCache map[string]bool
+}
+
+func (s *storeTx) EnsureCollection(ctx context.Context, collection string) (string, error) {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err == nil {
+		return tableName, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("lookup collection: %w", err)
+	}
+
+	tableName = tableNameForCollection(collection)
+	stmt := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			doc_id TEXT PRIMARY KEY,
+			payload JSONB,
+			tx_hash TEXT NOT NULL,
+			tx_id TEXT NOT NULL,
+			op TEXT NOT NULL,
+			schema_version TEXT,
+			updated_at BIGINT NOT NULL,
+			deleted BOOLEAN NOT NULL
+		)
+	`, quoteIdent(tableName))
+	if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+		return "", fmt.Errorf("create collection table: %w", err)
+	}
+	if _, err := s.tx.ExecContext(ctx, "INSERT INTO collection_registry (collection, table_name) VALUES ($1, $2)", collection, tableName); err != nil {
+		return "", fmt.Errorf("register collection: %w", err)
+	}
+	s.tableCache[collection] = tableName
+	return tableName, nil
+}
+
+func (s *storeTx) GetDoc(ctx context.Context, collection, docID string) (indexapp.DocRecord, bool, error) {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return indexapp.DocRecord{}, false, nil
+		}
+		return indexapp.DocRecord{}, false, fmt.Errorf("lookup collection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT doc_id, payload, tx_hash, tx_id, op, schema_version, updated_at, deleted
+		FROM %s WHERE doc_id = $1
+	`, quoteIdent(tableName))
+	var record indexapp.DocRecord
+	var schemaVersion sql.NullString
+	if err := s.tx.QueryRowContext(ctx, query, docID).Scan(
+		&record.DocID,
+		&record.Payload,
+		&record.TxHash,
+		&record.TxID,
+		&record.Op,
+		&schemaVersion,
+		&record.UpdatedAt,
+		&record.Deleted,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return indexapp.DocRecord{}, false, nil
+		}
+		return indexapp.DocRecord{}, false, fmt.Errorf("read doc: %w", err)
+	}
+	record.SchemaVersion = schemaVersion.String
+	return record, true, nil
+}
+
+func (s *storeTx) UpsertDoc(ctx context.Context, collection string, record indexapp.DocRecord) error {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("collection not initialized: %s", collection)
+		}
+		return fmt.Errorf("lookup collection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (doc_id, payload, tx_hash, tx_id, op, schema_version, updated_at, deleted)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (doc_id) DO UPDATE SET
+			payload = EXCLUDED.payload,
+			tx_hash = EXCLUDED.tx_hash,
+			tx_id = EXCLUDED.tx_id,
+			op = EXCLUDED.op,
+			schema_version = EXCLUDED.schema_version,
+			updated_at = EXCLUDED.updated_at,
+			deleted = EXCLUDED.deleted
+	`, quoteIdent(tableName))
+	if _, err := s.tx.ExecContext(ctx, query,
+		record.DocID,
+		jsonValue(record.Payload),
+		record.TxHash,
+		record.TxID,
+		record.Op,
+		record.SchemaVersion,
+		record.UpdatedAt,
+		record.Deleted,
+	); err != nil {
+		return fmt.Errorf("upsert doc: %w", err)
+	}
+	return nil
+}
+
+// AppendHistory records one applied transaction in <table>_history, keyed by
+// tx_hash so replays do not duplicate rows.
+func (s *storeTx) AppendHistory(ctx context.Context, collection string, record indexapp.HistoryRecord) error {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("collection not initialized: %s", collection)
+		}
+		return fmt.Errorf("lookup collection: %w", err)
+	}
+	historyName := tableName + historyTableSuffix
+	if !s.historyCache[historyName] {
+		stmt := fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s (
+				tx_hash TEXT PRIMARY KEY,
+				tx_id TEXT NOT NULL,
+				doc_id TEXT NOT NULL,
+				parent_hash TEXT,
+				op TEXT NOT NULL,
+				timestamp BIGINT NOT NULL,
+				commit_hash TEXT NOT NULL,
+				patch JSONB,
+				payload JSONB
+			)
+		`, quoteIdent(historyName))
+		if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create history table: %w", err)
+		}
+		stmt = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (doc_id, timestamp)",
+			quoteIdent(historyName+"_doc"), quoteIdent(historyName))
+		if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("create history index: %w", err)
+		}
+		s.historyCache[historyName] = true
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (tx_hash, tx_id, doc_id, parent_hash, op, timestamp, commit_hash, patch, payload)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (tx_hash) DO NOTHING
+	`, quoteIdent(historyName))
+	if _, err := s.tx.ExecContext(ctx, query,
+		record.TxHash,
+		record.TxID,
+		record.DocID,
+		record.ParentHash,
+		record.Op,
+		record.Timestamp,
+		record.CommitHash,
+		jsonValue(record.Patch),
+		jsonValue(record.Payload),
+	); err != nil {
+		return fmt.Errorf("append history: %w", err)
+	}
+	return nil
+}
+
+func (s *storeTx) SetState(ctx context.Context, state indexapp.State) error {
+	if _, err := s.tx.ExecContext(ctx, `
+		INSERT INTO ledger_index_state (id, last_commit, last_state_tree) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET
+			last_commit = EXCLUDED.last_commit,
+			last_state_tree = EXCLUDED.last_state_tree
+	`, state.LastCommit, state.LastStateTree); err != nil {
+		return fmt.Errorf("update index state: %w", err)
+	}
+	return nil
+}
+
+func (s *storeTx) Collections(ctx context.Context) ([]string, error) {
+	rows, err := s.tx.QueryContext(ctx, "SELECT collection FROM collection_registry ORDER BY collection")
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []string
+	for rows.Next() {
+		var collection string
+		if err := rows.Scan(&collection); err != nil {
+			return nil, fmt.Errorf("scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate collections: %w", err)
+	}
+	return collections, nil
+}
+
+// Declared indexes and full-text fields are materialized by the SQLite sink
+// only; PostgreSQL users index the JSONB payload directly.
+func (s *storeTx) EnsureIndexes(ctx context.Context, collection string, fields []string) (indexapp.IndexChanges, error) {
+	return indexapp.IndexChanges{}, nil
+}
+
+func (s *storeTx) EnsureFullText(ctx context.Context, collection string, fields []string) (bool, error) {
+	return false, nil
+}
+
+func (s *storeTx) UpdateFullText(ctx context.Context, collection, docID string) error {
+	return nil
+}
+
+func (s *storeTx) Commit() error {
+	return s.tx.Commit()
+}
+
+func (s *storeTx) Rollback() error {
+	return s.tx.Rollback()
+}
+
+func (s *storeTx) lookupCollection(ctx context.Context, collection string) (string, error) {
+	if tableName, ok := s.tableCache[collection]; ok {
+		return tableName, nil
+	}
+	var tableName string
+	if err := s.tx.QueryRowContext(ctx, "SELECT table_name FROM collection_registry WHERE collection = $1", collection).Scan(&tableName); err != nil {
+		return "", err
+	}
+	s.tableCache[collection] = tableName
+	return tableName, nil
+}
+
+// jsonValue binds JSON as text, which PostgreSQL casts to JSONB; empty
+// values become NULL.
+func jsonValue(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
+
+func tableNameForCollection(collection string) string {
+	return "collection_" + collection
+}
+
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
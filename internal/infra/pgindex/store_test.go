@@ -0,0 +1,138 @@
+package pgindex
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+	_ "modernc.org/sqlite"
+)
+
+// openTestStore runs against LEDGERDB_TEST_POSTGRES_DSN when it is set and
+// otherwise uses SQLite as a stand-in for the shared SQL dialect.
+func openTestStore(t *testing.T) *Store {
+	t.Helper()
+	if dsn := os.Getenv("LEDGERDB_TEST_POSTGRES_DSN"); dsn != "" {
+		store, err := Open(dsn)
+		if err != nil {
+			t.Fatalf("Open returned error: %v", err)
+		}
+		if err := store.Reset(context.Background()); err != nil {
+			t.Fatalf("Reset returned error: %v", err)
+		}
+		t.Cleanup(func() { _ = store.Close() })
+		return store
+	}
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "standin.db"))
+	if err != nil {
+		t.Fatalf("open stand-in: %v", err)
+	}
+	store, err := NewWithDB(db)
+	if err != nil {
+		t.Fatalf("NewWithDB returned error: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	return store
+}
+
+func TestStoreProjectsDocumentsAndCheckpoints(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+		t.Fatalf("EnsureCollection returned error: %v", err)
+	}
+	if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+		t.Fatalf("EnsureCollection should be idempotent: %v", err)
+	}
+	records := []indexapp.DocRecord{
+		{DocID: "t1", Payload: []byte(`{"status":"open"}`), TxHash: "h1", TxID: "1", Op: "put", SchemaVersion: "v1", UpdatedAt: 10},
+		{DocID: "t1", Payload: []byte(`{"status":"done"}`), TxHash: "h2", TxID: "2", Op: "patch", SchemaVersion: "v1", UpdatedAt: 20},
+		{DocID: "t2", TxHash: "h3", TxID: "3", Op: "delete", UpdatedAt: 30, Deleted: true},
+	}
+	for _, record := range records {
+		if err := tx.UpsertDoc(ctx, "tasks", record); err != nil {
+			t.Fatalf("UpsertDoc returned error: %v", err)
+		}
+	}
+	if err := tx.AppendHistory(ctx, "tasks", indexapp.HistoryRecord{TxHash: "h2", TxID: "2", DocID: "t1", ParentHash: "h1", Op: "patch", Timestamp: 20, CommitHash: "c1", Patch: []byte(`[]`), Payload: []byte(`{"status":"done"}`)}); err != nil {
+		t.Fatalf("AppendHistory returned error: %v", err)
+	}
+	if err := tx.SetState(ctx, indexapp.State{LastCommit: "c1", LastStateTree: "s1"}); err != nil {
+		t.Fatalf("SetState returned error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+
+	state, err := store.GetState(ctx)
+	if err != nil {
+		t.Fatalf("GetState returned error: %v", err)
+	}
+	if state.LastCommit != "c1" || state.LastStateTree != "s1" {
+		t.Fatalf("unexpected state: %+v", state)
+	}
+
+	tx, err = store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	record, found, err := tx.GetDoc(ctx, "tasks", "t1")
+	if err != nil || !found {
+		t.Fatalf("GetDoc returned %v, found=%v", err, found)
+	}
+	if record.TxHash != "h2" || record.Deleted || record.SchemaVersion != "v1" || len(record.Payload) == 0 {
+		t.Fatalf("unexpected record: %+v", record)
+	}
+	deleted, found, err := tx.GetDoc(ctx, "tasks", "t2")
+	if err != nil || !found || !deleted.Deleted || deleted.Payload != nil {
+		t.Fatalf("expected a tombstone for t2, got %+v (found=%v, err=%v)", deleted, found, err)
+	}
+	collections, err := tx.Collections(ctx)
+	if err != nil || len(collections) != 1 || collections[0] != "tasks" {
+		t.Fatalf("unexpected collections: %v (%v)", collections, err)
+	}
+}
+
+func TestStoreResetDropsTables(t *testing.T) {
+	ctx := context.Background()
+	store := openTestStore(t)
+
+	tx, err := store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+		t.Fatalf("EnsureCollection returned error: %v", err)
+	}
+	if err := tx.SetState(ctx, indexapp.State{LastCommit: "c1"}); err != nil {
+		t.Fatalf("SetState returned error: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit returned error: %v", err)
+	}
+
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset returned error: %v", err)
+	}
+	state, err := store.GetState(ctx)
+	if err != nil || state.LastCommit != "" {
+		t.Fatalf("expected an empty checkpoint, got %+v (%v)", state, err)
+	}
+	tx, err = store.Begin(ctx)
+	if err != nil {
+		t.Fatalf("Begin returned error: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if _, found, err := tx.GetDoc(ctx, "tasks", "t1"); err != nil || found {
+		t.Fatalf("expected the collection to be gone, found=%v err=%v", found, err)
+	}
+}
@@ -3,8 +3,11 @@ package schema
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/santhosh-tekuri/jsonschema/v5"
 )
 
@@ -15,14 +18,58 @@ func (JSONSchemaValidator) Validate(ctx context.Context, schema []byte) error {
 		return err
 	}
 
+	_, err := compileSchema(schema)
+	return err
+}
+
+func (JSONSchemaValidator) ValidateDocument(ctx context.Context, schema, doc []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	compiled, err := compileSchema(schema)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("decode document: %w", err)
+	}
+
+	if err := compiled.Validate(value); err != nil {
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return fmt.Errorf("validate document: %w", err)
+		}
+		return &domain.SchemaViolationError{Violations: collectViolations(validationErr)}
+	}
+
+	return nil
+}
+
+func compileSchema(schema []byte) (*jsonschema.Schema, error) {
 	compiler := jsonschema.NewCompiler()
 	if err := compiler.AddResource("schema.json", bytes.NewReader(schema)); err != nil {
-		return fmt.Errorf("load schema: %w", err)
+		return nil, fmt.Errorf("load schema: %w", err)
 	}
 
-	if _, err := compiler.Compile("schema.json"); err != nil {
-		return fmt.Errorf("compile schema: %w", err)
+	compiled, err := compiler.Compile("schema.json")
+	if err != nil {
+		return nil, fmt.Errorf("compile schema: %w", err)
 	}
+	return compiled, nil
+}
 
-	return nil
+func collectViolations(err *jsonschema.ValidationError) []domain.SchemaViolation {
+	if len(err.Causes) == 0 {
+		return []domain.SchemaViolation{{Pointer: err.InstanceLocation, Reason: err.Message}}
+	}
+	var violations []domain.SchemaViolation
+	for _, cause := range err.Causes {
+		violations = append(violations, collectViolations(cause)...)
+	}
+	return violations
 }
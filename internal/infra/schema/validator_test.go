@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+)
+
+const taskSchema = `{
+  "type": "object",
+  "required": ["title"],
+  "properties": {
+    "title": {"type": "string"},
+    "priority": {"type": "integer", "minimum": 1}
+  }
+}`
+
+func TestValidateDocumentAccepts(t *testing.T) {
+	err := JSONSchemaValidator{}.ValidateDocument(context.Background(), []byte(taskSchema), []byte(`{"title":"Ship","priority":2}`))
+	if err != nil {
+		t.Fatalf("expected document to validate, got %v", err)
+	}
+}
+
+func TestValidateDocumentReportsViolations(t *testing.T) {
+	err := JSONSchemaValidator{}.ValidateDocument(context.Background(), []byte(taskSchema), []byte(`{"priority":0}`))
+	if !errors.Is(err, domain.ErrSchemaViolation) {
+		t.Fatalf("expected ErrSchemaViolation, got %v", err)
+	}
+
+	var violationErr *domain.SchemaViolationError
+	if !errors.As(err, &violationErr) {
+		t.Fatalf("expected SchemaViolationError, got %T", err)
+	}
+	pointers := map[string]bool{}
+	for _, violation := range violationErr.Violations {
+		pointers[violation.Pointer] = true
+		if violation.Reason == "" {
+			t.Fatalf("expected violation reason for %q", violation.Pointer)
+		}
+	}
+	if !pointers["/priority"] || !pointers[""] {
+		t.Fatalf("unexpected violations: %+v", violationErr.Violations)
+	}
+}
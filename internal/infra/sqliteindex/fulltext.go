@@ -0,0 +1,200 @@
+package sqliteindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+const fullTextTableSuffix = "_fts"
+
+// EnsureFullText keeps an FTS5 table <table>_fts with one column per
+// full-text field, keyed by the rowid of the collection row. The table is
+// rebuilt from the live rows whenever the declared fields change.
+func (s *storeTx) EnsureFullText(ctx context.Context, collection string, fields []string) (bool, error) {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, fmt.Errorf("collection not initialized: %s", collection)
+		}
+		return false, fmt.Errorf("lookup collection: %w", err)
+	}
+
+	existing, err := s.fullTextColumns(ctx, tableName)
+	if err != nil {
+		return false, err
+	}
+	if slices.Equal(existing, fields) {
+		return false, nil
+	}
+
+	ftsName := tableName + fullTextTableSuffix
+	if _, err := s.tx.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdent(ftsName))); err != nil {
+		return false, fmt.Errorf("drop full-text table: %w", err)
+	}
+	s.fullTextCache[tableName] = fields
+	if len(fields) == 0 {
+		return true, nil
+	}
+
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, quoteIdent(field))
+	}
+	create := fmt.Sprintf("CREATE VIRTUAL TABLE %s USING fts5(%s)", quoteIdent(ftsName), strings.Join(columns, ", "))
+	if _, err := s.tx.ExecContext(ctx, create); err != nil {
+		return false, fmt.Errorf("create full-text table: %w", err)
+	}
+	populate := fmt.Sprintf("INSERT INTO %s (rowid, %s) SELECT rowid, %s FROM %s WHERE deleted = 0",
+		quoteIdent(ftsName), strings.Join(columns, ", "), fullTextValues(fields), quoteIdent(tableName))
+	if _, err := s.tx.ExecContext(ctx, populate); err != nil {
+		return false, fmt.Errorf("populate full-text table: %w", err)
+	}
+	return true, nil
+}
+
+// UpdateFullText re-derives the full-text row of one document from its
+// collection row, dropping it when the document is deleted.
+func (s *storeTx) UpdateFullText(ctx context.Context, collection, docID string) error {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("lookup collection: %w", err)
+	}
+	fields, ok := s.fullTextCache[tableName]
+	if !ok {
+		fields, err = s.fullTextColumns(ctx, tableName)
+		if err != nil {
+			return err
+		}
+		s.fullTextCache[tableName] = fields
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	ftsName := quoteIdent(tableName + fullTextTableSuffix)
+	remove := fmt.Sprintf("DELETE FROM %s WHERE rowid = (SELECT rowid FROM %s WHERE doc_id = ?)", ftsName, quoteIdent(tableName))
+	if _, err := s.tx.ExecContext(ctx, remove, docID); err != nil {
+		return fmt.Errorf("remove full-text row: %w", err)
+	}
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, quoteIdent(field))
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (rowid, %s) SELECT rowid, %s FROM %s WHERE doc_id = ? AND deleted = 0",
+		ftsName, strings.Join(columns, ", "), fullTextValues(fields), quoteIdent(tableName))
+	if _, err := s.tx.ExecContext(ctx, insert, docID); err != nil {
+		return fmt.Errorf("insert full-text row: %w", err)
+	}
+	return nil
+}
+
+func (s *storeTx) fullTextColumns(ctx context.Context, tableName string) ([]string, error) {
+	ftsName := tableName + fullTextTableSuffix
+	var exists int
+	err := s.tx.QueryRowContext(ctx, "SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?", ftsName).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("lookup full-text table: %w", err)
+	}
+
+	rows, err := s.tx.QueryContext(ctx, fmt.Sprintf("PRAGMA table_info(%s)", quoteIdent(ftsName)))
+	if err != nil {
+		return nil, fmt.Errorf("read full-text table info: %w", err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var cid int
+		var name string
+		var colType string
+		var notNull int
+		var dflt sql.NullString
+		var pk int
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk); err != nil {
+			return nil, fmt.Errorf("scan full-text table info: %w", err)
+		}
+		columns = append(columns, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate full-text table info: %w", err)
+	}
+	return columns, nil
+}
+
+// Search ranks documents with bm25 over the collection's FTS5 table. Scores
+// are negated bm25 values, so higher means more relevant.
+func (s *Store) Search(ctx context.Context, collection, query string, limit int) ([]indexapp.SearchHit, error) {
+	var tableName string
+	err := s.db.QueryRowContext(ctx, "SELECT table_name FROM collection_registry WHERE collection = ?", collection).Scan(&tableName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, indexapp.ErrFullTextNotEnabled
+		}
+		return nil, fmt.Errorf("lookup collection: %w", err)
+	}
+	ftsName := tableName + fullTextTableSuffix
+	var exists int
+	err = s.db.QueryRowContext(ctx, "SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = ?", ftsName).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, indexapp.ErrFullTextNotEnabled
+		}
+		return nil, fmt.Errorf("lookup full-text table: %w", err)
+	}
+
+	fts := quoteIdent(ftsName)
+	stmt := fmt.Sprintf(`
+		SELECT t.doc_id, -bm25(%[1]s), snippet(%[1]s, -1, '[', ']', '...', 12)
+		FROM %[1]s JOIN %[2]s AS t ON t.rowid = %[1]s.rowid
+		WHERE %[1]s MATCH ?
+		ORDER BY bm25(%[1]s), t.doc_id
+		LIMIT ?
+	`, fts, quoteIdent(tableName))
+	rows, err := s.db.QueryContext(ctx, stmt, query, limit)
+	if err != nil {
+		return nil, searchError(err)
+	}
+	defer rows.Close()
+
+	var hits []indexapp.SearchHit
+	for rows.Next() {
+		var hit indexapp.SearchHit
+		if err := rows.Scan(&hit.DocID, &hit.Score, &hit.Snippet); err != nil {
+			return nil, fmt.Errorf("scan search hit: %w", err)
+		}
+		hits = append(hits, hit)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, searchError(err)
+	}
+	return hits, nil
+}
+
+func fullTextValues(fields []string) string {
+	values := make([]string, 0, len(fields))
+	for _, field := range fields {
+		values = append(values, fmt.Sprintf("json_extract(CAST(payload AS TEXT), %s)", quoteLiteral(jsonPath(field))))
+	}
+	return strings.Join(values, ", ")
+}
+
+// searchError reports SQLITE_ERROR from the prepared search as a bad match
+// expression: the statement itself is fixed, so only the query can be wrong.
+func searchError(err error) error {
+	if strings.Contains(err.Error(), "SQL logic error") {
+		return fmt.Errorf("%w: %v", indexapp.ErrInvalidSearchQuery, err)
+	}
+	return fmt.Errorf("search: %w", err)
+}
@@ -0,0 +1,96 @@
+package sqliteindex
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+func TestFullTextSearchFollowsDocuments(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	apply := func(fn func(tx indexapp.StoreTx) error) {
+		t.Helper()
+		tx, err := store.Begin(ctx)
+		if err != nil {
+			t.Fatalf("Begin returned error: %v", err)
+		}
+		if _, err := tx.EnsureCollection(ctx, "notes"); err != nil {
+			t.Fatalf("EnsureCollection returned error: %v", err)
+		}
+		if err := fn(tx); err != nil {
+			t.Fatalf("store tx returned error: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit returned error: %v", err)
+		}
+	}
+	upsert := func(tx indexapp.StoreTx, docID, payload string, deleted bool) error {
+		record := indexapp.DocRecord{DocID: docID, TxHash: "h", TxID: docID, Op: "put", Deleted: deleted}
+		if payload != "" {
+			record.Payload = []byte(payload)
+		}
+		if err := tx.UpsertDoc(ctx, "notes", record); err != nil {
+			return err
+		}
+		return tx.UpdateFullText(ctx, "notes", docID)
+	}
+
+	if _, err := store.Search(ctx, "notes", "disk", 10); !errors.Is(err, indexapp.ErrFullTextNotEnabled) {
+		t.Fatalf("expected ErrFullTextNotEnabled, got %v", err)
+	}
+
+	apply(func(tx indexapp.StoreTx) error {
+		if err := upsert(tx, "n1", `{"title":"Disk full","body":"the disk on db-1 is full"}`, false); err != nil {
+			return err
+		}
+		rebuilt, err := tx.EnsureFullText(ctx, "notes", []string{"body", "title"})
+		if err == nil && !rebuilt {
+			t.Fatalf("expected the full-text table to be created")
+		}
+		return err
+	})
+	apply(func(tx indexapp.StoreTx) error {
+		if err := upsert(tx, "n2", `{"title":"Network","body":"packet loss, disk fine"}`, false); err != nil {
+			return err
+		}
+		return upsert(tx, "n3", `{"title":"Disk","body":"disk alert"}`, false)
+	})
+
+	hits, err := store.Search(ctx, "notes", "disk", 10)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(hits) != 3 || hits[2].DocID != "n2" || hits[0].Score < hits[2].Score {
+		t.Fatalf("expected n2 to rank last of three hits, got %+v", hits)
+	}
+	if hits[0].Snippet == "" {
+		t.Fatalf("expected a snippet, got %+v", hits[0])
+	}
+
+	apply(func(tx indexapp.StoreTx) error {
+		if err := upsert(tx, "n1", "", true); err != nil {
+			return err
+		}
+		return upsert(tx, "n3", `{"title":"Resolved","body":"all good"}`, false)
+	})
+	hits, err = store.Search(ctx, "notes", "disk", 10)
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if len(hits) != 1 || hits[0].DocID != "n2" {
+		t.Fatalf("expected deleted and edited docs to drop out, got %+v", hits)
+	}
+
+	if _, err := store.Search(ctx, "notes", `"unterminated`, 10); !errors.Is(err, indexapp.ErrInvalidSearchQuery) {
+		t.Fatalf("expected ErrInvalidSearchQuery, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package sqliteindex
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+const historyTableSuffix = "_history"
+
+// AppendHistory records one applied transaction in <table>_history. Rows are
+// keyed by tx_hash, so replaying a commit does not duplicate them.
+func (s *storeTx) AppendHistory(ctx context.Context, collection string, record indexapp.HistoryRecord) error {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("collection not initialized: %s", collection)
+		}
+		return fmt.Errorf("lookup collection: %w", err)
+	}
+	historyName := tableName + historyTableSuffix
+	if !s.historyCache[historyName] {
+		if err := s.createHistoryTable(ctx, historyName); err != nil {
+			return err
+		}
+		s.historyCache[historyName] = true
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (tx_hash, tx_id, doc_id, parent_hash, op, timestamp, commit_hash, patch, payload)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(tx_hash) DO NOTHING
+	`, quoteIdent(historyName))
+	if _, err := s.tx.ExecContext(ctx, query,
+		record.TxHash,
+		record.TxID,
+		record.DocID,
+		record.ParentHash,
+		record.Op,
+		record.Timestamp,
+		record.CommitHash,
+		record.Patch,
+		record.Payload,
+	); err != nil {
+		return fmt.Errorf("append history: %w", err)
+	}
+	return nil
+}
+
+func (s *storeTx) createHistoryTable(ctx context.Context, historyName string) error {
+	stmt := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s (
+			tx_hash TEXT PRIMARY KEY,
+			tx_id TEXT NOT NULL,
+			doc_id TEXT NOT NULL,
+			parent_hash TEXT,
+			op TEXT NOT NULL,
+			timestamp INTEGER NOT NULL,
+			commit_hash TEXT NOT NULL,
+			patch BLOB,
+			payload BLOB
+		)
+	`, quoteIdent(historyName))
+	if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("create history table: %w", err)
+	}
+	stmt = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (doc_id, timestamp)",
+		quoteIdent(historyName+"_doc"), quoteIdent(historyName))
+	if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("create history index: %w", err)
+	}
+	stmt = fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (timestamp)",
+		quoteIdent(historyName+"_timestamp"), quoteIdent(historyName))
+	if _, err := s.tx.ExecContext(ctx, stmt); err != nil {
+		return fmt.Errorf("create history index: %w", err)
+	}
+	return nil
+}
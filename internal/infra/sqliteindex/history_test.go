@@ -0,0 +1,86 @@
+package sqliteindex
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+func TestAppendHistoryRecordsTransitions(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	records := []indexapp.HistoryRecord{
+		{TxHash: "h1", TxID: "t1", DocID: "d1", Op: "put", Timestamp: 10, CommitHash: "c1", Payload: []byte(`{"status":"open"}`)},
+		{TxHash: "h2", TxID: "t2", DocID: "d1", ParentHash: "h1", Op: "patch", Timestamp: 20, CommitHash: "c2", Patch: []byte(`[]`), Payload: []byte(`{"status":"done"}`)},
+		{TxHash: "h3", TxID: "t3", DocID: "d1", ParentHash: "h2", Op: "delete", Timestamp: 30, CommitHash: "c3"},
+	}
+	for i := 0; i < 2; i++ {
+		tx, err := store.Begin(ctx)
+		if err != nil {
+			t.Fatalf("Begin returned error: %v", err)
+		}
+		if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+			t.Fatalf("EnsureCollection returned error: %v", err)
+		}
+		for _, record := range records {
+			if err := tx.AppendHistory(ctx, "tasks", record); err != nil {
+				t.Fatalf("AppendHistory returned error: %v", err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit returned error: %v", err)
+		}
+	}
+
+	rows, err := store.DB().QueryContext(ctx, `
+		SELECT op, json_extract(payload, '$.status'), parent_hash
+		FROM collection_tasks_history WHERE doc_id = 'd1' ORDER BY timestamp
+	`)
+	if err != nil {
+		t.Fatalf("query history: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var op, parent string
+		var status *string
+		if err := rows.Scan(&op, &status, &parent); err != nil {
+			t.Fatalf("scan history: %v", err)
+		}
+		value := "<nil>"
+		if status != nil {
+			value = *status
+		}
+		got = append(got, op+":"+value+":"+parent)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("iterate history: %v", err)
+	}
+	want := []string{"put:open:", "patch:done:h1", "delete:<nil>:h2"}
+	if len(got) != len(want) {
+		t.Fatalf("expected replay to keep %d rows, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+
+	if err := store.Reset(ctx); err != nil {
+		t.Fatalf("Reset returned error: %v", err)
+	}
+	var count int
+	if err := store.DB().QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE name = 'collection_tasks_history'").Scan(&count); err != nil {
+		t.Fatalf("query sqlite_master: %v", err)
+	}
+	if count != 0 {
+		t.Fatalf("expected Reset to drop the history table")
+	}
+}
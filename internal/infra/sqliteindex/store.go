@@ -7,12 +7,18 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
 	_ "modernc.org/sqlite"
 )
 
+const (
+	indexColumnPrefix      = "idx_"
+	generatedVirtualColumn = 2
+)
+
 type Store struct {
 	db *sql.DB
 }
@@ -90,7 +96,12 @@ func (s *Store) Begin(ctx context.Context) (indexapp.StoreTx, error) {
 	if err != nil {
 		return nil, fmt.Errorf("begin index transaction: %w", err)
 	}
-	return &storeTx{tx: tx, tableCache: make(map[string]string)}, nil
+	return &storeTx{
+		tx:            tx,
+		tableCache:    make(map[string]string),
+		fullTextCache: make(map[string][]string),
+		historyCache:  make(map[string]bool),
+	}, nil
 }
 
 func (s *Store) Reset(ctx context.Context) error {
@@ -127,9 +138,11 @@ func (s *Store) Reset(ctx context.Context) error {
 	}
 
 	for _, tableName := range tables {
-		stmt := fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdent(tableName))
-		if _, err := tx.ExecContext(ctx, stmt); err != nil {
-			return fmt.Errorf("drop table %s: %w", tableName, err)
+		for _, name := range []string{tableName + fullTextTableSuffix, tableName + historyTableSuffix, tableName} {
+			stmt := fmt.Sprintf("DROP TABLE IF EXISTS %s", quoteIdent(name))
+			if _, err := tx.ExecContext(ctx, stmt); err != nil {
+				return fmt.Errorf("drop table %s: %w", name, err)
+			}
 		}
 	}
 	if _, err := tx.ExecContext(ctx, "DELETE FROM collection_registry"); err != nil {
@@ -231,8 +244,10 @@ func (s *Store) applyPragmas(ctx context.Context, opts OpenOptions) error {
 }
 
 type storeTx struct {
-	tx         *sql.Tx
-	tableCache map[string]string
+	tx            *sql.Tx
+	tableCache    map[string]string
+	fullTextCache map[string][]string
+	historyCache  map[string]bool
 }
 
 func (s *storeTx) EnsureCollection(ctx context.Context, collection string) (string, error) {
@@ -346,6 +361,112 @@ func (s *storeTx) SetState(ctx context.Context, state indexapp.State) error {
 	return nil
 }
 
+func (s *storeTx) Collections(ctx context.Context) ([]string, error) {
+	rows, err := s.tx.QueryContext(ctx, "SELECT collection FROM collection_registry ORDER BY collection")
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	defer rows.Close()
+
+	var collections []string
+	for rows.Next() {
+		var collection string
+		if err := rows.Scan(&collection); err != nil {
+			return nil, fmt.Errorf("scan collection: %w", err)
+		}
+		collections = append(collections, collection)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate collections: %w", err)
+	}
+	return collections, nil
+}
+
+// EnsureIndexes keeps one virtual generated column per declared field, named
+// idx_<field> and backed by json_extract over the payload, each with its own
+// index. Columns for fields no longer declared are dropped.
+func (s *storeTx) EnsureIndexes(ctx context.Context, collection string, fields []string) (indexapp.IndexChanges, error) {
+	tableName, err := s.lookupCollection(ctx, collection)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return indexapp.IndexChanges{}, fmt.Errorf("collection not initialized: %s", collection)
+		}
+		return indexapp.IndexChanges{}, fmt.Errorf("lookup collection: %w", err)
+	}
+
+	existing, err := s.indexColumns(ctx, tableName)
+	if err != nil {
+		return indexapp.IndexChanges{}, err
+	}
+
+	var changes indexapp.IndexChanges
+	wanted := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		column := indexColumnPrefix + field
+		wanted[column] = struct{}{}
+		if _, ok := existing[column]; ok {
+			continue
+		}
+		addColumn := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s GENERATED ALWAYS AS (json_extract(CAST(payload AS TEXT), %s)) VIRTUAL",
+			quoteIdent(tableName), quoteIdent(column), quoteLiteral(jsonPath(field)))
+		if _, err := s.tx.ExecContext(ctx, addColumn); err != nil {
+			return changes, fmt.Errorf("add index column %s: %w", field, err)
+		}
+		createIndex := fmt.Sprintf("CREATE INDEX IF NOT EXISTS %s ON %s (%s)",
+			quoteIdent(tableName+"_"+column), quoteIdent(tableName), quoteIdent(column))
+		if _, err := s.tx.ExecContext(ctx, createIndex); err != nil {
+			return changes, fmt.Errorf("create index %s: %w", field, err)
+		}
+		changes.Created = append(changes.Created, field)
+	}
+
+	for column := range existing {
+		if _, ok := wanted[column]; ok {
+			continue
+		}
+		dropIndex := fmt.Sprintf("DROP INDEX IF EXISTS %s", quoteIdent(tableName+"_"+column))
+		if _, err := s.tx.ExecContext(ctx, dropIndex); err != nil {
+			return changes, fmt.Errorf("drop index %s: %w", column, err)
+		}
+		dropColumn := fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", quoteIdent(tableName), quoteIdent(column))
+		if _, err := s.tx.ExecContext(ctx, dropColumn); err != nil {
+			return changes, fmt.Errorf("drop index column %s: %w", column, err)
+		}
+		changes.Dropped = append(changes.Dropped, strings.TrimPrefix(column, indexColumnPrefix))
+	}
+	sort.Strings(changes.Dropped)
+	return changes, nil
+}
+
+func (s *storeTx) indexColumns(ctx context.Context, tableName string) (map[string]struct{}, error) {
+	rows, err := s.tx.QueryContext(ctx, fmt.Sprintf("PRAGMA table_xinfo(%s)", quoteIdent(tableName)))
+	if err != nil {
+		return nil, fmt.Errorf("read table info: %w", err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]struct{})
+	for rows.Next() {
+		var cid int
+		var name string
+		var colType string
+		var notNull int
+		var dflt sql.NullString
+		var pk int
+		var hidden int
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dflt, &pk, &hidden); err != nil {
+			return nil, fmt.Errorf("scan table info: %w", err)
+		}
+		if hidden == generatedVirtualColumn && strings.HasPrefix(name, indexColumnPrefix) {
+			columns[name] = struct{}{}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate table info: %w", err)
+	}
+	return columns, nil
+}
+
 func (s *storeTx) Commit() error {
 	return s.tx.Commit()
 }
@@ -387,6 +508,41 @@ func (s *storeTx) createCollectionTable(ctx context.Context, tableName string) e
 	return nil
 }
 
+// jsonPath maps a dotted field such as "address.city" to $.address.city,
+// quoting segments that are not plain identifiers.
+func jsonPath(field string) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, segment := range strings.Split(field, ".") {
+		b.WriteString(".")
+		if isPlainIdent(segment) {
+			b.WriteString(segment)
+			continue
+		}
+		b.WriteString(`"` + strings.ReplaceAll(segment, `"`, `\"`) + `"`)
+	}
+	return b.String()
+}
+
+func isPlainIdent(value string) bool {
+	if value == "" {
+		return false
+	}
+	for i, r := range value {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func quoteLiteral(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
 func tableNameForCollection(collection string) string {
 	return "collection_" + collection
 }
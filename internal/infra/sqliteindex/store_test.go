@@ -0,0 +1,70 @@
+package sqliteindex
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
+)
+
+func TestEnsureIndexesMaintainsGeneratedColumns(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	ensure := func(fields ...string) indexapp.IndexChanges {
+		t.Helper()
+		tx, err := store.Begin(ctx)
+		if err != nil {
+			t.Fatalf("Begin returned error: %v", err)
+		}
+		if _, err := tx.EnsureCollection(ctx, "tasks"); err != nil {
+			t.Fatalf("EnsureCollection returned error: %v", err)
+		}
+		if err := tx.UpsertDoc(ctx, "tasks", indexapp.DocRecord{DocID: "t1", Payload: []byte(`{"status":"open","owner":{"first name":"Ada"}}`), TxHash: "h", TxID: "1", Op: "put"}); err != nil {
+			t.Fatalf("UpsertDoc returned error: %v", err)
+		}
+		changes, err := tx.EnsureIndexes(ctx, "tasks", fields)
+		if err != nil {
+			t.Fatalf("EnsureIndexes returned error: %v", err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatalf("Commit returned error: %v", err)
+		}
+		return changes
+	}
+
+	changes := ensure("owner.first name", "status")
+	if !reflect.DeepEqual(changes.Created, []string{"owner.first name", "status"}) {
+		t.Fatalf("unexpected created indexes: %+v", changes)
+	}
+	var status, owner string
+	if err := store.DB().QueryRowContext(ctx, `SELECT "idx_status", "idx_owner.first name" FROM collection_tasks`).Scan(&status, &owner); err != nil {
+		t.Fatalf("query generated columns: %v", err)
+	}
+	if status != "open" || owner != "Ada" {
+		t.Fatalf("unexpected generated values: %q %q", status, owner)
+	}
+	var plan string
+	var id, parent, notUsed int
+	if err := store.DB().QueryRowContext(ctx, `EXPLAIN QUERY PLAN SELECT doc_id FROM collection_tasks WHERE "idx_status" = 'open'`).Scan(&id, &parent, &notUsed, &plan); err != nil {
+		t.Fatalf("explain query: %v", err)
+	}
+	if !strings.Contains(plan, "collection_tasks_idx_status") {
+		t.Fatalf("expected the status index to be used, got %q", plan)
+	}
+
+	if changes := ensure("owner.first name", "status"); len(changes.Created)+len(changes.Dropped) != 0 {
+		t.Fatalf("expected no changes on a second pass, got %+v", changes)
+	}
+	changes = ensure("status")
+	if len(changes.Created) != 0 || !reflect.DeepEqual(changes.Dropped, []string{"owner.first name"}) {
+		t.Fatalf("unexpected changes after removing a declaration: %+v", changes)
+	}
+}
@@ -0,0 +1,69 @@
+package txkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const pemType = "PRIVATE KEY"
+
+var ErrInvalidKeyFile = errors.New("invalid ed25519 key file")
+
+// Load reads an Ed25519 private key stored as a PKCS#8 PEM block, the format
+// written by Generate and by `openssl genpkey -algorithm ed25519`.
+func Load(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read tx key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemType {
+		return nil, fmt.Errorf("%w: %s: expected a PEM %q block", ErrInvalidKeyFile, path, pemType)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidKeyFile, path, err)
+	}
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: not an ed25519 key", ErrInvalidKeyFile, path)
+	}
+	return key, nil
+}
+
+// Generate writes a new private key to path, refusing to overwrite an
+// existing file, and returns it.
+func Generate(path string) (ed25519.PrivateKey, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("generate tx key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("encode tx key: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("write tx key: %w", err)
+	}
+	if err := pem.Encode(file, &pem.Block{Type: pemType, Bytes: der}); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("write tx key: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("write tx key: %w", err)
+	}
+	return key, nil
+}
+
+// PublicKeyHex returns the public half of key in the hex form used by
+// collection trusted keys and envelope signers.
+func PublicKeyHex(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Public().(ed25519.PublicKey))
+}
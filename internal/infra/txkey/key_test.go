@@ -0,0 +1,40 @@
+package txkey
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "writer.key")
+	key, err := Generate(path)
+	if err != nil {
+		t.Fatalf("Generate returned error: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected a 0600 key file, got %v (%v)", info, err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if PublicKeyHex(loaded) != PublicKeyHex(key) || len(PublicKeyHex(key)) != 64 {
+		t.Fatalf("expected the same key back, got %s", PublicKeyHex(loaded))
+	}
+	if _, err := Generate(path); err == nil {
+		t.Fatalf("expected Generate to refuse overwriting %s", path)
+	}
+}
+
+func TestLoadRejectsOtherFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "writer.key")
+	if err := os.WriteFile(path, []byte("not a key\n"), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if _, err := Load(path); !errors.Is(err, ErrInvalidKeyFile) {
+		t.Fatalf("expected ErrInvalidKeyFile, got %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package txv3
 
 import (
+	"crypto/ed25519"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -13,6 +16,16 @@ func (Encoder) Encode(tx domain.Transaction) ([]byte, error) {
 	return Encode(tx)
 }
 
+// SigningEncoder wraps every encoded transaction in a SignedTransaction
+// envelope signed with Key.
+type SigningEncoder struct {
+	Key ed25519.PrivateKey
+}
+
+func (e SigningEncoder) Encode(tx domain.Transaction) ([]byte, error) {
+	return EncodeSigned(tx, e.Key)
+}
+
 type Decoder struct{}
 
 func (Decoder) Decode(data []byte) (domain.Transaction, error) {
@@ -32,7 +45,60 @@ func Encode(tx domain.Transaction) ([]byte, error) {
 	return proto.MarshalOptions{Deterministic: true}.Marshal(pb)
 }
 
+// EncodeSigned encodes tx and wraps it in an envelope carrying the Ed25519
+// signature of the encoded bytes and the signer's public key.
+func EncodeSigned(tx domain.Transaction, key ed25519.PrivateKey) ([]byte, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("encode txv3: invalid ed25519 private key")
+	}
+	payload, err := Encode(tx)
+	if err != nil {
+		return nil, err
+	}
+	envelope := &SignedTransaction{
+		TxPayload: payload,
+		Signature: ed25519.Sign(key, payload),
+		PublicKey: hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(envelope)
+}
+
+// Decode reads a bare transaction or a signed envelope. Envelopes must carry
+// a valid signature; the signer ends up in Transaction.Signer.
 func Decode(data []byte) (domain.Transaction, error) {
+	if isEnvelope(data) {
+		return decodeSigned(data)
+	}
+	return decodeBare(data)
+}
+
+func isEnvelope(data []byte) bool {
+	num, typ, n := protowire.ConsumeTag(data)
+	return n > 0 && typ == protowire.BytesType && num == 16
+}
+
+func decodeSigned(data []byte) (domain.Transaction, error) {
+	var envelope SignedTransaction
+	if err := proto.Unmarshal(data, &envelope); err != nil {
+		return domain.Transaction{}, fmt.Errorf("decode txv3 envelope: %w", err)
+	}
+	publicKey, err := domain.NormalizePublicKey(envelope.PublicKey)
+	if err != nil {
+		return domain.Transaction{}, fmt.Errorf("%w: %v", domain.ErrInvalidSignature, err)
+	}
+	raw, _ := hex.DecodeString(publicKey)
+	if !ed25519.Verify(raw, envelope.TxPayload, envelope.Signature) {
+		return domain.Transaction{}, fmt.Errorf("%w: signer %s", domain.ErrInvalidSignature, publicKey)
+	}
+	tx, err := decodeBare(envelope.TxPayload)
+	if err != nil {
+		return domain.Transaction{}, err
+	}
+	tx.Signer = publicKey
+	return tx, nil
+}
+
+func decodeBare(data []byte) (domain.Transaction, error) {
 	var pb Transaction
 	if err := proto.Unmarshal(data, &pb); err != nil {
 		return domain.Transaction{}, fmt.Errorf("decode txv3: %w", err)
@@ -55,6 +121,7 @@ func toProto(tx domain.Transaction) (*Transaction, error) {
 		Op:            op,
 		ParentHash:    tx.ParentHash,
 		SchemaVersion: tx.SchemaVersion,
+		MergeParents:  tx.MergeParents,
 	}
 
 	if len(tx.Snapshot) > 0 {
@@ -80,6 +147,7 @@ func fromProto(pb *Transaction) (domain.Transaction, error) {
 		Op:            op,
 		ParentHash:    pb.ParentHash,
 		SchemaVersion: pb.SchemaVersion,
+		MergeParents:  pb.MergeParents,
 	}
 
 	switch payload := pb.Payload.(type) {
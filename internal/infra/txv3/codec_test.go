@@ -2,6 +2,9 @@ package txv3
 
 import (
 	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
 	"testing"
 
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
@@ -66,3 +69,90 @@ func TestEncodeDeterministic(t *testing.T) {
 		t.Fatalf("expected deterministic encoding")
 	}
 }
+
+func TestEncodeDecodeMergeParents(t *testing.T) {
+	tx := domain.Transaction{
+		TxID:         "01H124",
+		Timestamp:    124,
+		Collection:   "users",
+		DocID:        "user_1",
+		Op:           domain.TxOpMerge,
+		Snapshot:     []byte(`{"name":"Ada"}`),
+		ParentHash:   "ours",
+		MergeParents: []string{"theirs"},
+	}
+
+	data, err := Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if len(decoded.MergeParents) != 1 || decoded.MergeParents[0] != "theirs" {
+		t.Fatalf("expected merge parents [theirs], got %v", decoded.MergeParents)
+	}
+}
+
+func TestEncodeSignedRoundTrip(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tx := domain.Transaction{
+		TxID:       "01H125",
+		Timestamp:  125,
+		Collection: "users",
+		DocID:      "user_1",
+		Op:         domain.TxOpPut,
+		Snapshot:   []byte(`{"name":"Ada"}`),
+	}
+
+	data, err := SigningEncoder{Key: privateKey}.Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	decoded, err := Decode(data)
+	if err != nil {
+		t.Fatalf("Decode returned error: %v", err)
+	}
+	if decoded.Signer != hex.EncodeToString(publicKey) || decoded.TxID != tx.TxID || !bytes.Equal(decoded.Snapshot, tx.Snapshot) {
+		t.Fatalf("unexpected decoded tx %+v", decoded)
+	}
+
+	bare, err := Encode(tx)
+	if err != nil {
+		t.Fatalf("Encode returned error: %v", err)
+	}
+	if decoded, err := Decode(bare); err != nil || decoded.Signer != "" {
+		t.Fatalf("expected an unsigned tx, got %+v (%v)", decoded, err)
+	}
+}
+
+func TestDecodeRejectsForgedEnvelope(t *testing.T) {
+	_, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	tx := domain.Transaction{
+		TxID:       "01H126",
+		Timestamp:  126,
+		Collection: "users",
+		DocID:      "user_1",
+		Op:         domain.TxOpPut,
+		Snapshot:   []byte(`{"balance":10}`),
+	}
+	data, err := EncodeSigned(tx, privateKey)
+	if err != nil {
+		t.Fatalf("EncodeSigned returned error: %v", err)
+	}
+
+	forged := bytes.Replace(data, []byte(`{"balance":10}`), []byte(`{"balance":99}`), 1)
+	if bytes.Equal(forged, data) {
+		t.Fatalf("expected the payload to be rewritten")
+	}
+	if _, err := Decode(forged); !errors.Is(err, domain.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
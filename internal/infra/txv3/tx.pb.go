@@ -90,6 +90,7 @@ type Transaction struct {
 	Payload       isTransaction_Payload `protobuf_oneof:"payload"`
 	ParentHash    string                `protobuf:"bytes,8,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
 	SchemaVersion string                `protobuf:"bytes,9,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	MergeParents  []string              `protobuf:"bytes,10,rep,name=merge_parents,json=mergeParents,proto3" json:"merge_parents,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -198,6 +199,13 @@ func (x *Transaction) GetSchemaVersion() string {
 	return ""
 }
 
+func (x *Transaction) GetMergeParents() []string {
+	if x != nil {
+		return x.MergeParents
+	}
+	return nil
+}
+
 type isTransaction_Payload interface {
 	isTransaction_Payload()
 }
@@ -214,11 +222,75 @@ func (*Transaction_Snapshot) isTransaction_Payload() {}
 
 func (*Transaction_Patch) isTransaction_Payload() {}
 
+// SignedTransaction wraps the encoded Transaction bytes with an Ed25519
+// signature over them. Field numbers start at 16 so an envelope never parses
+// as a bare Transaction and decoders can tell the two apart.
+type SignedTransaction struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	TxPayload []byte                 `protobuf:"bytes,16,opt,name=tx_payload,json=txPayload,proto3" json:"tx_payload,omitempty"`
+	Signature []byte                 `protobuf:"bytes,17,opt,name=signature,proto3" json:"signature,omitempty"`
+	// public_key is the signer's Ed25519 public key as lowercase hex.
+	PublicKey     string `protobuf:"bytes,18,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedTransaction) Reset() {
+	*x = SignedTransaction{}
+	mi := &file_internal_infra_txv3_tx_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedTransaction) ProtoMessage() {}
+
+func (x *SignedTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_infra_txv3_tx_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedTransaction.ProtoReflect.Descriptor instead.
+func (*SignedTransaction) Descriptor() ([]byte, []int) {
+	return file_internal_infra_txv3_tx_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignedTransaction) GetTxPayload() []byte {
+	if x != nil {
+		return x.TxPayload
+	}
+	return nil
+}
+
+func (x *SignedTransaction) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *SignedTransaction) GetPublicKey() string {
+	if x != nil {
+		return x.PublicKey
+	}
+	return ""
+}
+
 var File_internal_infra_txv3_tx_proto protoreflect.FileDescriptor
 
 const file_internal_infra_txv3_tx_proto_rawDesc = "" +
 	"\n" +
-	"\x1cinternal/infra/txv3/tx.proto\x12\vledgerdb.v3\"\xeb\x02\n" +
+	"\x1cinternal/infra/txv3/tx.proto\x12\vledgerdb.v3\"\x90\x03\n" +
 	"\vTransaction\x12\x13\n" +
 	"\x05tx_id\x18\x01 \x01(\tR\x04txId\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\x03R\ttimestamp\x12\x1e\n" +
@@ -231,7 +303,9 @@ const file_internal_infra_txv3_tx_proto_rawDesc = "" +
 	"\x05patch\x18\a \x01(\fH\x00R\x05patch\x12\x1f\n" +
 	"\vparent_hash\x18\b \x01(\tR\n" +
 	"parentHash\x12%\n" +
-	"\x0eschema_version\x18\t \x01(\tR\rschemaVersion\"<\n" +
+	"\x0eschema_version\x18\t \x01(\tR\rschemaVersion\x12#\n" +
+	"\rmerge_parents\x18\n" +
+	" \x03(\tR\fmergeParents\"<\n" +
 	"\x02Op\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\a\n" +
 	"\x03PUT\x10\x01\x12\t\n" +
@@ -239,7 +313,13 @@ const file_internal_infra_txv3_tx_proto_rawDesc = "" +
 	"\n" +
 	"\x06DELETE\x10\x03\x12\t\n" +
 	"\x05MERGE\x10\x04B\t\n" +
-	"\apayloadB=Z;github.com/osvaldoandrade/ledgerdb/internal/infra/txv3;txv3b\x06proto3"
+	"\apayload\"o\n" +
+	"\x11SignedTransaction\x12\x1d\n" +
+	"\n" +
+	"tx_payload\x18\x10 \x01(\fR\ttxPayload\x12\x1c\n" +
+	"\tsignature\x18\x11 \x01(\fR\tsignature\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x12 \x01(\tR\tpublicKeyB=Z;github.com/osvaldoandrade/ledgerdb/internal/infra/txv3;txv3b\x06proto3"
 
 var (
 	file_internal_infra_txv3_tx_proto_rawDescOnce sync.Once
@@ -254,10 +334,11 @@ func file_internal_infra_txv3_tx_proto_rawDescGZIP() []byte {
 }
 
 var file_internal_infra_txv3_tx_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_internal_infra_txv3_tx_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_internal_infra_txv3_tx_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_internal_infra_txv3_tx_proto_goTypes = []any{
-	(Transaction_Op)(0), // 0: ledgerdb.v3.Transaction.Op
-	(*Transaction)(nil), // 1: ledgerdb.v3.Transaction
+	(Transaction_Op)(0),       // 0: ledgerdb.v3.Transaction.Op
+	(*Transaction)(nil),       // 1: ledgerdb.v3.Transaction
+	(*SignedTransaction)(nil), // 2: ledgerdb.v3.SignedTransaction
 }
 var file_internal_infra_txv3_tx_proto_depIdxs = []int32{
 	0, // 0: ledgerdb.v3.Transaction.op:type_name -> ledgerdb.v3.Transaction.Op
@@ -283,7 +364,7 @@ func file_internal_infra_txv3_tx_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_infra_txv3_tx_proto_rawDesc), len(file_internal_infra_txv3_tx_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
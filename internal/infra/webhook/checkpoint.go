@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileCheckpoint stores the delivery cursor in a single file, replaced
+// atomically on every save.
+type FileCheckpoint struct {
+	path string
+}
+
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{path: path}
+}
+
+func (c *FileCheckpoint) Load(ctx context.Context) (string, error) {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read hook checkpoint: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (c *FileCheckpoint) Save(ctx context.Context, cursor string) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("create hook checkpoint dir: %w", err)
+	}
+	temp := c.path + ".tmp"
+	file, err := os.Create(temp)
+	if err != nil {
+		return fmt.Errorf("write hook checkpoint: %w", err)
+	}
+	if _, err := file.WriteString(cursor + "\n"); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("write hook checkpoint: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		_ = os.Remove(temp)
+		return fmt.Errorf("sync hook checkpoint: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("close hook checkpoint: %w", err)
+	}
+	if err := os.Rename(temp, c.path); err != nil {
+		_ = os.Remove(temp)
+		return fmt.Errorf("publish hook checkpoint: %w", err)
+	}
+	return nil
+}
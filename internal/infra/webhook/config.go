@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
+)
+
+const checkpointSuffix = ".cursor"
+
+type fileConfig struct {
+	Checkpoint  string     `json:"checkpoint"`
+	MaxAttempts int        `json:"max_attempts"`
+	Backoff     string     `json:"backoff"`
+	Timeout     string     `json:"timeout"`
+	Hooks       []fileHook `json:"hooks"`
+}
+
+type fileHook struct {
+	Name        string   `json:"name"`
+	Collections []string `json:"collections"`
+	Ops         []string `json:"ops"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret"`
+	SecretEnv   string   `json:"secret_env"`
+	Command     []string `json:"command"`
+}
+
+// Config is a hooks file: the hooks, where their delivery checkpoint lives
+// and the HTTP/command timeout.
+type Config struct {
+	Hooks      hooksapp.Config
+	Checkpoint string
+	Timeout    time.Duration
+}
+
+// LoadConfig reads a JSON hooks file. A relative checkpoint path is resolved
+// against the file's directory and defaults to <file>.cursor.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read hooks file: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var raw fileConfig
+	if err := decoder.Decode(&raw); err != nil {
+		return Config{}, fmt.Errorf("%w: %s: %v", hooksapp.ErrInvalidHook, path, err)
+	}
+
+	cfg := Config{
+		Hooks:      hooksapp.Config{MaxAttempts: raw.MaxAttempts},
+		Checkpoint: raw.Checkpoint,
+	}
+	if cfg.Hooks.Backoff, err = parseDuration("backoff", raw.Backoff); err != nil {
+		return Config{}, err
+	}
+	if cfg.Timeout, err = parseDuration("timeout", raw.Timeout); err != nil {
+		return Config{}, err
+	}
+	if cfg.Checkpoint == "" {
+		cfg.Checkpoint = filepath.Base(path) + checkpointSuffix
+	}
+	if !filepath.IsAbs(cfg.Checkpoint) {
+		cfg.Checkpoint = filepath.Join(filepath.Dir(path), cfg.Checkpoint)
+	}
+	for _, hook := range raw.Hooks {
+		secret := hook.Secret
+		if hook.SecretEnv != "" {
+			secret = os.Getenv(hook.SecretEnv)
+			if secret == "" {
+				return Config{}, fmt.Errorf("%w: %s: secret env %s is empty", hooksapp.ErrInvalidHook, hook.Name, hook.SecretEnv)
+			}
+		}
+		cfg.Hooks.Hooks = append(cfg.Hooks.Hooks, hooksapp.Hook{
+			Name:        hook.Name,
+			Collections: hook.Collections,
+			Ops:         hook.Ops,
+			URL:         hook.URL,
+			Secret:      secret,
+			Command:     hook.Command,
+		})
+	}
+	if err := cfg.Hooks.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+func parseDuration(field, value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("%w: invalid %s %q", hooksapp.ErrInvalidHook, field, value)
+	}
+	return parsed, nil
+}
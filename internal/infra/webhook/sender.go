@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
+)
+
+const (
+	HeaderHook      = "X-Ledgerdb-Hook"
+	HeaderDelivery  = "X-Ledgerdb-Delivery"
+	HeaderAttempt   = "X-Ledgerdb-Attempt"
+	HeaderTimestamp = "X-Ledgerdb-Timestamp"
+	HeaderSignature = "X-Ledgerdb-Signature"
+
+	DefaultTimeout = 10 * time.Second
+
+	maxErrorOutput = 512
+)
+
+// Sender posts deliveries to URL hooks and pipes them into Command hooks.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(timeout time.Duration) *Sender {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Sender{client: &http.Client{Timeout: timeout}}
+}
+
+func (s *Sender) Send(ctx context.Context, delivery hooksapp.Delivery) error {
+	if len(delivery.Hook.Command) > 0 {
+		return s.run(ctx, delivery)
+	}
+	return s.post(ctx, delivery)
+}
+
+func (s *Sender) post(ctx context.Context, delivery hooksapp.Delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Hook.URL, bytes.NewReader(delivery.Body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderHook, delivery.Hook.Name)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderAttempt, strconv.Itoa(delivery.Attempt))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(delivery.Timestamp, 10))
+	if delivery.Signature != "" {
+		req.Header.Set(HeaderSignature, "sha256="+delivery.Signature)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	output, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorOutput))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned %s: %s", delivery.Hook.Name, resp.Status, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+func (s *Sender) run(ctx context.Context, delivery hooksapp.Delivery) error {
+	runCtx, cancel := context.WithTimeout(ctx, s.client.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, delivery.Hook.Command[0], delivery.Hook.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(delivery.Body)
+	cmd.Env = append(os.Environ(),
+		"LEDGERDB_HOOK="+delivery.Hook.Name,
+		"LEDGERDB_DELIVERY="+delivery.ID,
+		"LEDGERDB_ATTEMPT="+strconv.Itoa(delivery.Attempt),
+		"LEDGERDB_TIMESTAMP="+strconv.FormatInt(delivery.Timestamp, 10),
+		"LEDGERDB_SIGNATURE="+delivery.Signature,
+	)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		output := stderr.String()
+		if len(output) > maxErrorOutput {
+			output = output[:maxErrorOutput]
+		}
+		if output = strings.TrimSpace(output); output != "" {
+			return fmt.Errorf("hook command %s failed: %w: %s", delivery.Hook.Name, err, output)
+		}
+		return fmt.Errorf("hook command %s failed: %w", delivery.Hook.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
+)
+
+func TestSenderPostsSignedBody(t *testing.T) {
+	var gotBody []byte
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header.Clone()
+		if r.Header.Get(HeaderAttempt) == "1" {
+			http.Error(w, "try later", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	body := []byte(`{"doc_id":"a"}`)
+	delivery := hooksapp.Delivery{
+		Hook:      hooksapp.Hook{Name: "audit", URL: server.URL},
+		ID:        "h1",
+		Body:      body,
+		Timestamp: 1700000000,
+		Signature: hooksapp.Sign("s3cret", 1700000000, body),
+		Attempt:   1,
+	}
+	sender := NewSender(time.Second)
+	err := sender.Send(context.Background(), delivery)
+	if err == nil || !strings.Contains(err.Error(), "try later") {
+		t.Fatalf("expected the 503 to fail the delivery, got %v", err)
+	}
+
+	delivery.Attempt = 2
+	if err := sender.Send(context.Background(), delivery); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	if string(gotBody) != string(body) {
+		t.Fatalf("unexpected body %s", gotBody)
+	}
+	if gotHeader.Get(HeaderSignature) != "sha256="+delivery.Signature || gotHeader.Get(HeaderTimestamp) != "1700000000" || gotHeader.Get(HeaderHook) != "audit" || gotHeader.Get(HeaderDelivery) != "h1" {
+		t.Fatalf("unexpected headers %v", gotHeader)
+	}
+}
+
+func TestSenderRunsCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	sender := NewSender(5 * time.Second)
+	delivery := hooksapp.Delivery{
+		Hook:      hooksapp.Hook{Name: "local", Command: []string{"sh", "-c", `cat > "$0"; echo "$LEDGERDB_HOOK $LEDGERDB_TIMESTAMP $LEDGERDB_SIGNATURE" >> "$0"`, out}},
+		ID:        "h1",
+		Body:      []byte(`{}`),
+		Timestamp: 42,
+		Signature: "abc",
+		Attempt:   1,
+	}
+	if err := sender.Send(context.Background(), delivery); err != nil {
+		t.Fatalf("Send returned error: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if string(data) != "{}local 42 abc\n" {
+		t.Fatalf("unexpected command output %q", data)
+	}
+
+	delivery.Hook.Command = []string{"sh", "-c", "echo boom >&2; exit 3"}
+	if err := sender.Send(context.Background(), delivery); err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Fatalf("expected the failing command to report stderr, got %v", err)
+	}
+}
+
+func TestFileCheckpointRoundTrip(t *testing.T) {
+	checkpoint := NewFileCheckpoint(filepath.Join(t.TempDir(), "state", "hooks.cursor"))
+	if cursor, err := checkpoint.Load(context.Background()); err != nil || cursor != "" {
+		t.Fatalf("expected an empty checkpoint, got %q (%v)", cursor, err)
+	}
+	if err := checkpoint.Save(context.Background(), "c1_abc"); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+	if cursor, err := checkpoint.Load(context.Background()); err != nil || cursor != "c1_abc" {
+		t.Fatalf("expected the saved cursor, got %q (%v)", cursor, err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hooks.json")
+	t.Setenv("TEST_HOOK_SECRET", "from-env")
+	content := `{
+  "backoff": "2s",
+  "hooks": [
+    {"name": "audit", "collections": ["tasks"], "url": "https://example.com/hook", "secret_env": "TEST_HOOK_SECRET"},
+    {"name": "local", "ops": ["delete"], "command": ["./notify.sh"]}
+  ]
+}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig returned error: %v", err)
+	}
+	if cfg.Checkpoint != filepath.Join(dir, "hooks.json.cursor") || cfg.Hooks.Backoff != 2*time.Second {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+	if len(cfg.Hooks.Hooks) != 2 || cfg.Hooks.Hooks[0].Secret != "from-env" || cfg.Hooks.Hooks[1].Ops[0] != "delete" {
+		t.Fatalf("unexpected hooks %+v", cfg.Hooks.Hooks)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"hooks": [{"name": "x", "url": "https://example.com", "retries": 3}]}`), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := LoadConfig(path); !errors.Is(err, hooksapp.ErrInvalidHook) {
+		t.Fatalf("expected unknown fields to be rejected, got %v", err)
+	}
+}
@@ -17,6 +17,8 @@ export interface IndexConfig {
 export interface ClientConfig {
   repoPath: string;
   binaryPath?: string;
+  /** Base URL of a running `ledgerdb serve`; when set, calls go over HTTP instead of spawning the binary. */
+  serverUrl?: string;
   env?: Record<string, string>;
   autoSync?: boolean;
   index?: IndexConfig;
@@ -70,6 +72,7 @@ export function resolveBinaryPath(): string {
 export class LedgerDBClient {
   private readonly repoPath: string;
   private readonly binaryPath: string;
+  private readonly serverUrl?: string;
   private readonly env: NodeJS.ProcessEnv;
   private readonly autoSync: boolean;
   private readonly index: Required<IndexConfig>;
@@ -80,6 +83,7 @@ export class LedgerDBClient {
     }
     this.repoPath = cfg.repoPath;
     this.binaryPath = cfg.binaryPath || resolveBinaryPath();
+    this.serverUrl = cfg.serverUrl ? cfg.serverUrl.replace(/\/+$/, "") : undefined;
     this.env = { ...process.env, ...(cfg.env || {}) };
     this.autoSync = cfg.autoSync !== false;
     this.index = {
@@ -99,29 +103,47 @@ export class LedgerDBClient {
   }
 
   async get(collection: string, docId: string): Promise<GetResult> {
+    if (this.serverUrl) {
+      return this.request<GetResult>("GET", docPath(collection, docId));
+    }
     return this.execJson<GetResult>(["doc", "get", collection, docId], false);
   }
 
   async put(collection: string, docId: string, payload: unknown): Promise<PutResult> {
     const data = normalizePayload(payload);
+    if (this.serverUrl) {
+      return this.request<PutResult>("PUT", docPath(collection, docId), data);
+    }
     return this.execJson<PutResult>(["doc", "put", collection, docId, "--payload", data], true);
   }
 
   async patch(collection: string, docId: string, ops: unknown): Promise<PutResult> {
     const data = normalizePayload(ops);
+    if (this.serverUrl) {
+      return this.request<PutResult>("PATCH", docPath(collection, docId), data);
+    }
     return this.execJson<PutResult>(["doc", "patch", collection, docId, "--ops", data], true);
   }
 
   async delete(collection: string, docId: string): Promise<PutResult> {
+    if (this.serverUrl) {
+      return this.request<PutResult>("DELETE", docPath(collection, docId));
+    }
     return this.execJson<PutResult>(["doc", "delete", collection, docId], true);
   }
 
   async log(collection: string, docId: string): Promise<LogEntry[]> {
-    const result = await this.execJson<{ entries: LogEntry[] }>(["doc", "log", collection, docId], false);
+    const result = this.serverUrl
+      ? await this.request<{ entries: LogEntry[] }>("GET", `${docPath(collection, docId)}/log`)
+      : await this.execJson<{ entries: LogEntry[] }>(["doc", "log", collection, docId], false);
     return result.entries || [];
   }
 
   async revert(collection: string, docId: string, opts: { txId?: string; txHash?: string }): Promise<PutResult> {
+    if (this.serverUrl) {
+      const body = JSON.stringify({ tx_id: opts.txId, tx_hash: opts.txHash });
+      return this.request<PutResult>("POST", `${docPath(collection, docId)}/revert`, body);
+    }
     const args = ["doc", "revert", collection, docId];
     if (opts.txId) {
       args.push("--tx-id", opts.txId);
@@ -133,6 +155,11 @@ export class LedgerDBClient {
   }
 
   async indexSync(overrides?: IndexConfig): Promise<IndexSyncResult> {
+    if (this.serverUrl) {
+      const cfg = { ...this.index, ...(overrides || {}) };
+      const body = JSON.stringify({ fetch: cfg.fetch, batch_commits: cfg.batchCommits, mode: cfg.mode });
+      return this.request<IndexSyncResult>("POST", "/v1/index/sync", body);
+    }
     return this.execJson<IndexSyncResult>(this.buildIndexArgs("sync", overrides), false);
   }
 
@@ -150,6 +177,25 @@ export class LedgerDBClient {
     await this.execPlain(["push"], false);
   }
 
+  private async request<T>(method: string, requestPath: string, body?: string): Promise<T> {
+    const response = await fetch(`${this.serverUrl}${requestPath}`, {
+      method,
+      headers: body === undefined ? undefined : { "Content-Type": "application/json" },
+      body,
+    });
+    const text = await response.text();
+    if (!response.ok) {
+      let message = text.trim();
+      try {
+        message = (JSON.parse(text) as { message?: string }).message || message;
+      } catch {
+        // keep the raw body
+      }
+      throw new Error(`ledgerdb ${method} ${requestPath} failed (${response.status}): ${message}`);
+    }
+    return JSON.parse(text) as T;
+  }
+
   private async execJson<T>(args: string[], writeOperation: boolean): Promise<T> {
     const fullArgs = [...this.baseArgs(writeOperation, true), ...args];
     const result = await execFileAsync(this.binaryPath, fullArgs, {
@@ -216,6 +262,10 @@ export class LedgerDBClient {
   }
 }
 
+function docPath(collection: string, docId: string): string {
+  return `/v1/collections/${encodeURIComponent(collection)}/docs/${encodeURIComponent(docId)}`;
+}
+
 function normalizePayload(payload: unknown): string {
   if (typeof payload === "string") {
     return payload;
@@ -0,0 +1,1938 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.2
+// source: pkg/ledgerdbpb/ledgerdb.proto
+
+package ledgerdbpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Preconditions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// if_match only writes when the stream head equals this tx hash.
+	IfMatch string `protobuf:"bytes,1,opt,name=if_match,json=ifMatch,proto3" json:"if_match,omitempty"`
+	// if_none_match only writes when the document stream does not exist yet.
+	IfNoneMatch   bool `protobuf:"varint,2,opt,name=if_none_match,json=ifNoneMatch,proto3" json:"if_none_match,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Preconditions) Reset() {
+	*x = Preconditions{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Preconditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Preconditions) ProtoMessage() {}
+
+func (x *Preconditions) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Preconditions.ProtoReflect.Descriptor instead.
+func (*Preconditions) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Preconditions) GetIfMatch() string {
+	if x != nil {
+		return x.IfMatch
+	}
+	return ""
+}
+
+func (x *Preconditions) GetIfNoneMatch() bool {
+	if x != nil {
+		return x.IfNoneMatch
+	}
+	return false
+}
+
+type GetDocumentRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Collection string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId      string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	AtTx       string                 `protobuf:"bytes,3,opt,name=at_tx,json=atTx,proto3" json:"at_tx,omitempty"`
+	AtCommit   string                 `protobuf:"bytes,4,opt,name=at_commit,json=atCommit,proto3" json:"at_commit,omitempty"`
+	// as_of is an RFC 3339 timestamp.
+	AsOf          string `protobuf:"bytes,5,opt,name=as_of,json=asOf,proto3" json:"as_of,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDocumentRequest) Reset() {
+	*x = GetDocumentRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDocumentRequest) ProtoMessage() {}
+
+func (x *GetDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDocumentRequest.ProtoReflect.Descriptor instead.
+func (*GetDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetAtTx() string {
+	if x != nil {
+		return x.AtTx
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetAtCommit() string {
+	if x != nil {
+		return x.AtCommit
+	}
+	return ""
+}
+
+func (x *GetDocumentRequest) GetAsOf() string {
+	if x != nil {
+		return x.AsOf
+	}
+	return ""
+}
+
+type Document struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	TxHash        string                 `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Op            string                 `protobuf:"bytes,4,opt,name=op,proto3" json:"op,omitempty"`
+	Commit        string                 `protobuf:"bytes,5,opt,name=commit,proto3" json:"commit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Document) Reset() {
+	*x = Document{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Document) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Document) ProtoMessage() {}
+
+func (x *Document) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Document.ProtoReflect.Descriptor instead.
+func (*Document) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Document) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Document) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *Document) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *Document) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *Document) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+type PutDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Preconditions *Preconditions         `protobuf:"bytes,4,opt,name=preconditions,proto3" json:"preconditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PutDocumentRequest) Reset() {
+	*x = PutDocumentRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PutDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutDocumentRequest) ProtoMessage() {}
+
+func (x *PutDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutDocumentRequest.ProtoReflect.Descriptor instead.
+func (*PutDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PutDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *PutDocumentRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *PutDocumentRequest) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *PutDocumentRequest) GetPreconditions() *Preconditions {
+	if x != nil {
+		return x.Preconditions
+	}
+	return nil
+}
+
+type PatchDocumentRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Collection string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId      string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	// patch is an RFC 6902 JSON Patch array.
+	Patch         []byte         `protobuf:"bytes,3,opt,name=patch,proto3" json:"patch,omitempty"`
+	Preconditions *Preconditions `protobuf:"bytes,4,opt,name=preconditions,proto3" json:"preconditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PatchDocumentRequest) Reset() {
+	*x = PatchDocumentRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PatchDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PatchDocumentRequest) ProtoMessage() {}
+
+func (x *PatchDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PatchDocumentRequest.ProtoReflect.Descriptor instead.
+func (*PatchDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PatchDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *PatchDocumentRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *PatchDocumentRequest) GetPatch() []byte {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+func (x *PatchDocumentRequest) GetPreconditions() *Preconditions {
+	if x != nil {
+		return x.Preconditions
+	}
+	return nil
+}
+
+type DeleteDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Preconditions *Preconditions         `protobuf:"bytes,3,opt,name=preconditions,proto3" json:"preconditions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteDocumentRequest) Reset() {
+	*x = DeleteDocumentRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteDocumentRequest) ProtoMessage() {}
+
+func (x *DeleteDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteDocumentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DeleteDocumentRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *DeleteDocumentRequest) GetPreconditions() *Preconditions {
+	if x != nil {
+		return x.Preconditions
+	}
+	return nil
+}
+
+type RevertDocumentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	TxHash        string                 `protobuf:"bytes,4,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevertDocumentRequest) Reset() {
+	*x = RevertDocumentRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevertDocumentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevertDocumentRequest) ProtoMessage() {}
+
+func (x *RevertDocumentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevertDocumentRequest.ProtoReflect.Descriptor instead.
+func (*RevertDocumentRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *RevertDocumentRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *RevertDocumentRequest) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type WriteResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commit        string                 `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	TxHash        string                 `protobuf:"bytes,2,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxId          string                 `protobuf:"bytes,3,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteResult) Reset() {
+	*x = WriteResult{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteResult) ProtoMessage() {}
+
+func (x *WriteResult) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteResult.ProtoReflect.Descriptor instead.
+func (*WriteResult) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WriteResult) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *WriteResult) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *WriteResult) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type ListDocumentsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Collection     string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Prefix         string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Cursor         string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit          int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	IncludeDeleted bool                   `protobuf:"varint,5,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListDocumentsRequest) Reset() {
+	*x = ListDocumentsRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsRequest) ProtoMessage() {}
+
+func (x *ListDocumentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsRequest.ProtoReflect.Descriptor instead.
+func (*ListDocumentsRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListDocumentsRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDocumentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDocumentsRequest) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+type ListEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DocId         string                 `protobuf:"bytes,1,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	TxId          string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Op            string                 `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"`
+	Deleted       bool                   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEntry) Reset() {
+	*x = ListEntry{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEntry) ProtoMessage() {}
+
+func (x *ListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEntry.ProtoReflect.Descriptor instead.
+func (*ListEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListEntry) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *ListEntry) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ListEntry) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ListEntry) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *ListEntry) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type ListDocumentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*ListEntry           `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDocumentsResponse) Reset() {
+	*x = ListDocumentsResponse{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDocumentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDocumentsResponse) ProtoMessage() {}
+
+func (x *ListDocumentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDocumentsResponse.ProtoReflect.Descriptor instead.
+func (*ListDocumentsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListDocumentsResponse) GetEntries() []*ListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListDocumentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type DocumentLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,2,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentLogRequest) Reset() {
+	*x = DocumentLogRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentLogRequest) ProtoMessage() {}
+
+func (x *DocumentLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentLogRequest.ProtoReflect.Descriptor instead.
+func (*DocumentLogRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DocumentLogRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *DocumentLogRequest) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+type LogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxId          string                 `protobuf:"bytes,2,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	ParentHash    string                 `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	MergeParents  []string               `protobuf:"bytes,4,rep,name=merge_parents,json=mergeParents,proto3" json:"merge_parents,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Op            string                 `protobuf:"bytes,6,opt,name=op,proto3" json:"op,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogEntry) Reset() {
+	*x = LogEntry{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogEntry) ProtoMessage() {}
+
+func (x *LogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
+func (*LogEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LogEntry) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *LogEntry) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *LogEntry) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *LogEntry) GetMergeParents() []string {
+	if x != nil {
+		return x.MergeParents
+	}
+	return nil
+}
+
+func (x *LogEntry) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *LogEntry) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+type DocumentLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LogEntry            `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DocumentLogResponse) Reset() {
+	*x = DocumentLogResponse{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DocumentLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DocumentLogResponse) ProtoMessage() {}
+
+func (x *DocumentLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DocumentLogResponse.ProtoReflect.Descriptor instead.
+func (*DocumentLogResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *DocumentLogResponse) GetEntries() []*LogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type BatchOp struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// op is put, patch or delete.
+	Op         string `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Collection string `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId      string `protobuf:"bytes,3,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	// payload is the document for put and the JSON Patch for patch.
+	Payload       []byte `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchOp) Reset() {
+	*x = BatchOp{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchOp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchOp) ProtoMessage() {}
+
+func (x *BatchOp) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchOp.ProtoReflect.Descriptor instead.
+func (*BatchOp) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchOp) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *BatchOp) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *BatchOp) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *BatchOp) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type ApplyBatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ops           []*BatchOp             `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBatchRequest) Reset() {
+	*x = ApplyBatchRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBatchRequest) ProtoMessage() {}
+
+func (x *ApplyBatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBatchRequest.ProtoReflect.Descriptor instead.
+func (*ApplyBatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ApplyBatchRequest) GetOps() []*BatchOp {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+type BatchTx struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Op            string                 `protobuf:"bytes,1,opt,name=op,proto3" json:"op,omitempty"`
+	Collection    string                 `protobuf:"bytes,2,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,3,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	TxHash        string                 `protobuf:"bytes,4,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxId          string                 `protobuf:"bytes,5,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchTx) Reset() {
+	*x = BatchTx{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTx) ProtoMessage() {}
+
+func (x *BatchTx) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTx.ProtoReflect.Descriptor instead.
+func (*BatchTx) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BatchTx) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *BatchTx) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *BatchTx) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *BatchTx) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *BatchTx) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+type ApplyBatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Commit        string                 `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	Txs           []*BatchTx             `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyBatchResponse) Reset() {
+	*x = ApplyBatchResponse{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyBatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyBatchResponse) ProtoMessage() {}
+
+func (x *ApplyBatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyBatchResponse.ProtoReflect.Descriptor instead.
+func (*ApplyBatchResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApplyBatchResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ApplyBatchResponse) GetTxs() []*BatchTx {
+	if x != nil {
+		return x.Txs
+	}
+	return nil
+}
+
+type ApplyCollectionRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Collection string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	Schema     []byte                 `protobuf:"bytes,2,opt,name=schema,proto3" json:"schema,omitempty"`
+	Indexes    []string               `protobuf:"bytes,3,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Fulltext   []string               `protobuf:"bytes,4,rep,name=fulltext,proto3" json:"fulltext,omitempty"`
+	// trusted_keys are hex Ed25519 public keys; when set, every write to the
+	// collection must be signed by one of them. An empty list keeps the
+	// current keys.
+	TrustedKeys []string `protobuf:"bytes,5,rep,name=trusted_keys,json=trustedKeys,proto3" json:"trusted_keys,omitempty"`
+	// clear_trusted_keys removes the current keys.
+	ClearTrustedKeys bool `protobuf:"varint,6,opt,name=clear_trusted_keys,json=clearTrustedKeys,proto3" json:"clear_trusted_keys,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *ApplyCollectionRequest) Reset() {
+	*x = ApplyCollectionRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCollectionRequest) ProtoMessage() {}
+
+func (x *ApplyCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ApplyCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApplyCollectionRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ApplyCollectionRequest) GetSchema() []byte {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+func (x *ApplyCollectionRequest) GetIndexes() []string {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *ApplyCollectionRequest) GetFulltext() []string {
+	if x != nil {
+		return x.Fulltext
+	}
+	return nil
+}
+
+func (x *ApplyCollectionRequest) GetTrustedKeys() []string {
+	if x != nil {
+		return x.TrustedKeys
+	}
+	return nil
+}
+
+func (x *ApplyCollectionRequest) GetClearTrustedKeys() bool {
+	if x != nil {
+		return x.ClearTrustedKeys
+	}
+	return false
+}
+
+type ApplyCollectionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyCollectionResponse) Reset() {
+	*x = ApplyCollectionResponse{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyCollectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyCollectionResponse) ProtoMessage() {}
+
+func (x *ApplyCollectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyCollectionResponse.ProtoReflect.Descriptor instead.
+func (*ApplyCollectionResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{19}
+}
+
+type CollectionLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collection    string                 `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionLogRequest) Reset() {
+	*x = CollectionLogRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionLogRequest) ProtoMessage() {}
+
+func (x *CollectionLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionLogRequest.ProtoReflect.Descriptor instead.
+func (*CollectionLogRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CollectionLogRequest) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+type CollectionLogEntry struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Commit string                 `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	// timestamp is RFC 3339.
+	Timestamp     string   `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	SchemaVersion string   `protobuf:"bytes,3,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Indexes       []string `protobuf:"bytes,4,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	Fulltext      []string `protobuf:"bytes,5,rep,name=fulltext,proto3" json:"fulltext,omitempty"`
+	Removed       bool     `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"`
+	TrustedKeys   []string `protobuf:"bytes,7,rep,name=trusted_keys,json=trustedKeys,proto3" json:"trusted_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionLogEntry) Reset() {
+	*x = CollectionLogEntry{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionLogEntry) ProtoMessage() {}
+
+func (x *CollectionLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionLogEntry.ProtoReflect.Descriptor instead.
+func (*CollectionLogEntry) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CollectionLogEntry) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *CollectionLogEntry) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *CollectionLogEntry) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *CollectionLogEntry) GetIndexes() []string {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+func (x *CollectionLogEntry) GetFulltext() []string {
+	if x != nil {
+		return x.Fulltext
+	}
+	return nil
+}
+
+func (x *CollectionLogEntry) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+func (x *CollectionLogEntry) GetTrustedKeys() []string {
+	if x != nil {
+		return x.TrustedKeys
+	}
+	return nil
+}
+
+type CollectionLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*CollectionLogEntry  `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionLogResponse) Reset() {
+	*x = CollectionLogResponse{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionLogResponse) ProtoMessage() {}
+
+func (x *CollectionLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionLogResponse.ProtoReflect.Descriptor instead.
+func (*CollectionLogResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CollectionLogResponse) GetEntries() []*CollectionLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type WatchRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// cursor resumes after a previous event; empty starts at the first commit.
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// collections restricts the stream; empty streams every collection.
+	Collections   []string `protobuf:"bytes,2,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *WatchRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *WatchRequest) GetCollections() []string {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type ChangeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Commit        string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
+	DocId         string                 `protobuf:"bytes,4,opt,name=doc_id,json=docId,proto3" json:"doc_id,omitempty"`
+	Op            string                 `protobuf:"bytes,5,opt,name=op,proto3" json:"op,omitempty"`
+	TxId          string                 `protobuf:"bytes,6,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	TxHash        string                 `protobuf:"bytes,7,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	ParentHash    string                 `protobuf:"bytes,8,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,9,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	SchemaVersion string                 `protobuf:"bytes,10,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,11,opt,name=payload,proto3" json:"payload,omitempty"`
+	Patch         []byte                 `protobuf:"bytes,12,opt,name=patch,proto3" json:"patch,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChangeEvent) Reset() {
+	*x = ChangeEvent{}
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChangeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChangeEvent) ProtoMessage() {}
+
+func (x *ChangeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChangeEvent.ProtoReflect.Descriptor instead.
+func (*ChangeEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChangeEvent) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTxId() string {
+	if x != nil {
+		return x.TxId
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ChangeEvent) GetSchemaVersion() string {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return ""
+}
+
+func (x *ChangeEvent) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ChangeEvent) GetPatch() []byte {
+	if x != nil {
+		return x.Patch
+	}
+	return nil
+}
+
+var File_pkg_ledgerdbpb_ledgerdb_proto protoreflect.FileDescriptor
+
+const file_pkg_ledgerdbpb_ledgerdb_proto_rawDesc = "" +
+	"\n" +
+	"\x1dpkg/ledgerdbpb/ledgerdb.proto\x12\vledgerdb.v1\"N\n" +
+	"\rPreconditions\x12\x19\n" +
+	"\bif_match\x18\x01 \x01(\tR\aifMatch\x12\"\n" +
+	"\rif_none_match\x18\x02 \x01(\bR\vifNoneMatch\"\x92\x01\n" +
+	"\x12GetDocumentRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\x12\x13\n" +
+	"\x05at_tx\x18\x03 \x01(\tR\x04atTx\x12\x1b\n" +
+	"\tat_commit\x18\x04 \x01(\tR\batCommit\x12\x13\n" +
+	"\x05as_of\x18\x05 \x01(\tR\x04asOf\"z\n" +
+	"\bDocument\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x13\n" +
+	"\x05tx_id\x18\x03 \x01(\tR\x04txId\x12\x0e\n" +
+	"\x02op\x18\x04 \x01(\tR\x02op\x12\x16\n" +
+	"\x06commit\x18\x05 \x01(\tR\x06commit\"\xa7\x01\n" +
+	"\x12PutDocumentRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\fR\apayload\x12@\n" +
+	"\rpreconditions\x18\x04 \x01(\v2\x1a.ledgerdb.v1.PreconditionsR\rpreconditions\"\xa5\x01\n" +
+	"\x14PatchDocumentRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\x12\x14\n" +
+	"\x05patch\x18\x03 \x01(\fR\x05patch\x12@\n" +
+	"\rpreconditions\x18\x04 \x01(\v2\x1a.ledgerdb.v1.PreconditionsR\rpreconditions\"\x90\x01\n" +
+	"\x15DeleteDocumentRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\x12@\n" +
+	"\rpreconditions\x18\x03 \x01(\v2\x1a.ledgerdb.v1.PreconditionsR\rpreconditions\"|\n" +
+	"\x15RevertDocumentRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\x12\x13\n" +
+	"\x05tx_id\x18\x03 \x01(\tR\x04txId\x12\x17\n" +
+	"\atx_hash\x18\x04 \x01(\tR\x06txHash\"S\n" +
+	"\vWriteResult\x12\x16\n" +
+	"\x06commit\x18\x01 \x01(\tR\x06commit\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x13\n" +
+	"\x05tx_id\x18\x03 \x01(\tR\x04txId\"\xa5\x01\n" +
+	"\x14ListDocumentsRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12'\n" +
+	"\x0finclude_deleted\x18\x05 \x01(\bR\x0eincludeDeleted\"{\n" +
+	"\tListEntry\x12\x15\n" +
+	"\x06doc_id\x18\x01 \x01(\tR\x05docId\x12\x13\n" +
+	"\x05tx_id\x18\x02 \x01(\tR\x04txId\x12\x0e\n" +
+	"\x02op\x18\x03 \x01(\tR\x02op\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12\x18\n" +
+	"\apayload\x18\x05 \x01(\fR\apayload\"j\n" +
+	"\x15ListDocumentsResponse\x120\n" +
+	"\aentries\x18\x01 \x03(\v2\x16.ledgerdb.v1.ListEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"K\n" +
+	"\x12DocumentLogRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x02 \x01(\tR\x05docId\"\xac\x01\n" +
+	"\bLogEntry\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12\x13\n" +
+	"\x05tx_id\x18\x02 \x01(\tR\x04txId\x12\x1f\n" +
+	"\vparent_hash\x18\x03 \x01(\tR\n" +
+	"parentHash\x12#\n" +
+	"\rmerge_parents\x18\x04 \x03(\tR\fmergeParents\x12\x1c\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\x12\x0e\n" +
+	"\x02op\x18\x06 \x01(\tR\x02op\"F\n" +
+	"\x13DocumentLogResponse\x12/\n" +
+	"\aentries\x18\x01 \x03(\v2\x15.ledgerdb.v1.LogEntryR\aentries\"j\n" +
+	"\aBatchOp\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x03 \x01(\tR\x05docId\x12\x18\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\";\n" +
+	"\x11ApplyBatchRequest\x12&\n" +
+	"\x03ops\x18\x01 \x03(\v2\x14.ledgerdb.v1.BatchOpR\x03ops\"~\n" +
+	"\aBatchTx\x12\x0e\n" +
+	"\x02op\x18\x01 \x01(\tR\x02op\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x02 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x03 \x01(\tR\x05docId\x12\x17\n" +
+	"\atx_hash\x18\x04 \x01(\tR\x06txHash\x12\x13\n" +
+	"\x05tx_id\x18\x05 \x01(\tR\x04txId\"T\n" +
+	"\x12ApplyBatchResponse\x12\x16\n" +
+	"\x06commit\x18\x01 \x01(\tR\x06commit\x12&\n" +
+	"\x03txs\x18\x02 \x03(\v2\x14.ledgerdb.v1.BatchTxR\x03txs\"\xd7\x01\n" +
+	"\x16ApplyCollectionRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\x12\x16\n" +
+	"\x06schema\x18\x02 \x01(\fR\x06schema\x12\x18\n" +
+	"\aindexes\x18\x03 \x03(\tR\aindexes\x12\x1a\n" +
+	"\bfulltext\x18\x04 \x03(\tR\bfulltext\x12!\n" +
+	"\ftrusted_keys\x18\x05 \x03(\tR\vtrustedKeys\x12,\n" +
+	"\x12clear_trusted_keys\x18\x06 \x01(\bR\x10clearTrustedKeys\"\x19\n" +
+	"\x17ApplyCollectionResponse\"6\n" +
+	"\x14CollectionLogRequest\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x01 \x01(\tR\n" +
+	"collection\"\xe4\x01\n" +
+	"\x12CollectionLogEntry\x12\x16\n" +
+	"\x06commit\x18\x01 \x01(\tR\x06commit\x12\x1c\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\x12%\n" +
+	"\x0eschema_version\x18\x03 \x01(\tR\rschemaVersion\x12\x18\n" +
+	"\aindexes\x18\x04 \x03(\tR\aindexes\x12\x1a\n" +
+	"\bfulltext\x18\x05 \x03(\tR\bfulltext\x12\x18\n" +
+	"\aremoved\x18\x06 \x01(\bR\aremoved\x12!\n" +
+	"\ftrusted_keys\x18\a \x03(\tR\vtrustedKeys\"R\n" +
+	"\x15CollectionLogResponse\x129\n" +
+	"\aentries\x18\x01 \x03(\v2\x1f.ledgerdb.v1.CollectionLogEntryR\aentries\"H\n" +
+	"\fWatchRequest\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12 \n" +
+	"\vcollections\x18\x02 \x03(\tR\vcollections\"\xc8\x02\n" +
+	"\vChangeEvent\x12\x16\n" +
+	"\x06cursor\x18\x01 \x01(\tR\x06cursor\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x15\n" +
+	"\x06doc_id\x18\x04 \x01(\tR\x05docId\x12\x0e\n" +
+	"\x02op\x18\x05 \x01(\tR\x02op\x12\x13\n" +
+	"\x05tx_id\x18\x06 \x01(\tR\x04txId\x12\x17\n" +
+	"\atx_hash\x18\a \x01(\tR\x06txHash\x12\x1f\n" +
+	"\vparent_hash\x18\b \x01(\tR\n" +
+	"parentHash\x12\x1c\n" +
+	"\ttimestamp\x18\t \x01(\x03R\ttimestamp\x12%\n" +
+	"\x0eschema_version\x18\n" +
+	" \x01(\tR\rschemaVersion\x12\x18\n" +
+	"\apayload\x18\v \x01(\fR\apayload\x12\x14\n" +
+	"\x05patch\x18\f \x01(\fR\x05patch2\xf6\x06\n" +
+	"\x06Ledger\x12E\n" +
+	"\vGetDocument\x12\x1f.ledgerdb.v1.GetDocumentRequest\x1a\x15.ledgerdb.v1.Document\x12H\n" +
+	"\vPutDocument\x12\x1f.ledgerdb.v1.PutDocumentRequest\x1a\x18.ledgerdb.v1.WriteResult\x12L\n" +
+	"\rPatchDocument\x12!.ledgerdb.v1.PatchDocumentRequest\x1a\x18.ledgerdb.v1.WriteResult\x12N\n" +
+	"\x0eDeleteDocument\x12\".ledgerdb.v1.DeleteDocumentRequest\x1a\x18.ledgerdb.v1.WriteResult\x12N\n" +
+	"\x0eRevertDocument\x12\".ledgerdb.v1.RevertDocumentRequest\x1a\x18.ledgerdb.v1.WriteResult\x12V\n" +
+	"\rListDocuments\x12!.ledgerdb.v1.ListDocumentsRequest\x1a\".ledgerdb.v1.ListDocumentsResponse\x12P\n" +
+	"\vDocumentLog\x12\x1f.ledgerdb.v1.DocumentLogRequest\x1a .ledgerdb.v1.DocumentLogResponse\x12M\n" +
+	"\n" +
+	"ApplyBatch\x12\x1e.ledgerdb.v1.ApplyBatchRequest\x1a\x1f.ledgerdb.v1.ApplyBatchResponse\x12\\\n" +
+	"\x0fApplyCollection\x12#.ledgerdb.v1.ApplyCollectionRequest\x1a$.ledgerdb.v1.ApplyCollectionResponse\x12V\n" +
+	"\rCollectionLog\x12!.ledgerdb.v1.CollectionLogRequest\x1a\".ledgerdb.v1.CollectionLogResponse\x12>\n" +
+	"\x05Watch\x12\x19.ledgerdb.v1.WatchRequest\x1a\x18.ledgerdb.v1.ChangeEvent0\x01Bf\n" +
+	"$io.github.osvaldoandrade.ledgerdb.v1P\x01Z<github.com/osvaldoandrade/ledgerdb/pkg/ledgerdbpb;ledgerdbpbb\x06proto3"
+
+var (
+	file_pkg_ledgerdbpb_ledgerdb_proto_rawDescOnce sync.Once
+	file_pkg_ledgerdbpb_ledgerdb_proto_rawDescData []byte
+)
+
+func file_pkg_ledgerdbpb_ledgerdb_proto_rawDescGZIP() []byte {
+	file_pkg_ledgerdbpb_ledgerdb_proto_rawDescOnce.Do(func() {
+		file_pkg_ledgerdbpb_ledgerdb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_ledgerdbpb_ledgerdb_proto_rawDesc), len(file_pkg_ledgerdbpb_ledgerdb_proto_rawDesc)))
+	})
+	return file_pkg_ledgerdbpb_ledgerdb_proto_rawDescData
+}
+
+var file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_pkg_ledgerdbpb_ledgerdb_proto_goTypes = []any{
+	(*Preconditions)(nil),           // 0: ledgerdb.v1.Preconditions
+	(*GetDocumentRequest)(nil),      // 1: ledgerdb.v1.GetDocumentRequest
+	(*Document)(nil),                // 2: ledgerdb.v1.Document
+	(*PutDocumentRequest)(nil),      // 3: ledgerdb.v1.PutDocumentRequest
+	(*PatchDocumentRequest)(nil),    // 4: ledgerdb.v1.PatchDocumentRequest
+	(*DeleteDocumentRequest)(nil),   // 5: ledgerdb.v1.DeleteDocumentRequest
+	(*RevertDocumentRequest)(nil),   // 6: ledgerdb.v1.RevertDocumentRequest
+	(*WriteResult)(nil),             // 7: ledgerdb.v1.WriteResult
+	(*ListDocumentsRequest)(nil),    // 8: ledgerdb.v1.ListDocumentsRequest
+	(*ListEntry)(nil),               // 9: ledgerdb.v1.ListEntry
+	(*ListDocumentsResponse)(nil),   // 10: ledgerdb.v1.ListDocumentsResponse
+	(*DocumentLogRequest)(nil),      // 11: ledgerdb.v1.DocumentLogRequest
+	(*LogEntry)(nil),                // 12: ledgerdb.v1.LogEntry
+	(*DocumentLogResponse)(nil),     // 13: ledgerdb.v1.DocumentLogResponse
+	(*BatchOp)(nil),                 // 14: ledgerdb.v1.BatchOp
+	(*ApplyBatchRequest)(nil),       // 15: ledgerdb.v1.ApplyBatchRequest
+	(*BatchTx)(nil),                 // 16: ledgerdb.v1.BatchTx
+	(*ApplyBatchResponse)(nil),      // 17: ledgerdb.v1.ApplyBatchResponse
+	(*ApplyCollectionRequest)(nil),  // 18: ledgerdb.v1.ApplyCollectionRequest
+	(*ApplyCollectionResponse)(nil), // 19: ledgerdb.v1.ApplyCollectionResponse
+	(*CollectionLogRequest)(nil),    // 20: ledgerdb.v1.CollectionLogRequest
+	(*CollectionLogEntry)(nil),      // 21: ledgerdb.v1.CollectionLogEntry
+	(*CollectionLogResponse)(nil),   // 22: ledgerdb.v1.CollectionLogResponse
+	(*WatchRequest)(nil),            // 23: ledgerdb.v1.WatchRequest
+	(*ChangeEvent)(nil),             // 24: ledgerdb.v1.ChangeEvent
+}
+var file_pkg_ledgerdbpb_ledgerdb_proto_depIdxs = []int32{
+	0,  // 0: ledgerdb.v1.PutDocumentRequest.preconditions:type_name -> ledgerdb.v1.Preconditions
+	0,  // 1: ledgerdb.v1.PatchDocumentRequest.preconditions:type_name -> ledgerdb.v1.Preconditions
+	0,  // 2: ledgerdb.v1.DeleteDocumentRequest.preconditions:type_name -> ledgerdb.v1.Preconditions
+	9,  // 3: ledgerdb.v1.ListDocumentsResponse.entries:type_name -> ledgerdb.v1.ListEntry
+	12, // 4: ledgerdb.v1.DocumentLogResponse.entries:type_name -> ledgerdb.v1.LogEntry
+	14, // 5: ledgerdb.v1.ApplyBatchRequest.ops:type_name -> ledgerdb.v1.BatchOp
+	16, // 6: ledgerdb.v1.ApplyBatchResponse.txs:type_name -> ledgerdb.v1.BatchTx
+	21, // 7: ledgerdb.v1.CollectionLogResponse.entries:type_name -> ledgerdb.v1.CollectionLogEntry
+	1,  // 8: ledgerdb.v1.Ledger.GetDocument:input_type -> ledgerdb.v1.GetDocumentRequest
+	3,  // 9: ledgerdb.v1.Ledger.PutDocument:input_type -> ledgerdb.v1.PutDocumentRequest
+	4,  // 10: ledgerdb.v1.Ledger.PatchDocument:input_type -> ledgerdb.v1.PatchDocumentRequest
+	5,  // 11: ledgerdb.v1.Ledger.DeleteDocument:input_type -> ledgerdb.v1.DeleteDocumentRequest
+	6,  // 12: ledgerdb.v1.Ledger.RevertDocument:input_type -> ledgerdb.v1.RevertDocumentRequest
+	8,  // 13: ledgerdb.v1.Ledger.ListDocuments:input_type -> ledgerdb.v1.ListDocumentsRequest
+	11, // 14: ledgerdb.v1.Ledger.DocumentLog:input_type -> ledgerdb.v1.DocumentLogRequest
+	15, // 15: ledgerdb.v1.Ledger.ApplyBatch:input_type -> ledgerdb.v1.ApplyBatchRequest
+	18, // 16: ledgerdb.v1.Ledger.ApplyCollection:input_type -> ledgerdb.v1.ApplyCollectionRequest
+	20, // 17: ledgerdb.v1.Ledger.CollectionLog:input_type -> ledgerdb.v1.CollectionLogRequest
+	23, // 18: ledgerdb.v1.Ledger.Watch:input_type -> ledgerdb.v1.WatchRequest
+	2,  // 19: ledgerdb.v1.Ledger.GetDocument:output_type -> ledgerdb.v1.Document
+	7,  // 20: ledgerdb.v1.Ledger.PutDocument:output_type -> ledgerdb.v1.WriteResult
+	7,  // 21: ledgerdb.v1.Ledger.PatchDocument:output_type -> ledgerdb.v1.WriteResult
+	7,  // 22: ledgerdb.v1.Ledger.DeleteDocument:output_type -> ledgerdb.v1.WriteResult
+	7,  // 23: ledgerdb.v1.Ledger.RevertDocument:output_type -> ledgerdb.v1.WriteResult
+	10, // 24: ledgerdb.v1.Ledger.ListDocuments:output_type -> ledgerdb.v1.ListDocumentsResponse
+	13, // 25: ledgerdb.v1.Ledger.DocumentLog:output_type -> ledgerdb.v1.DocumentLogResponse
+	17, // 26: ledgerdb.v1.Ledger.ApplyBatch:output_type -> ledgerdb.v1.ApplyBatchResponse
+	19, // 27: ledgerdb.v1.Ledger.ApplyCollection:output_type -> ledgerdb.v1.ApplyCollectionResponse
+	22, // 28: ledgerdb.v1.Ledger.CollectionLog:output_type -> ledgerdb.v1.CollectionLogResponse
+	24, // 29: ledgerdb.v1.Ledger.Watch:output_type -> ledgerdb.v1.ChangeEvent
+	19, // [19:30] is the sub-list for method output_type
+	8,  // [8:19] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_pkg_ledgerdbpb_ledgerdb_proto_init() }
+func file_pkg_ledgerdbpb_ledgerdb_proto_init() {
+	if File_pkg_ledgerdbpb_ledgerdb_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_ledgerdbpb_ledgerdb_proto_rawDesc), len(file_pkg_ledgerdbpb_ledgerdb_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pkg_ledgerdbpb_ledgerdb_proto_goTypes,
+		DependencyIndexes: file_pkg_ledgerdbpb_ledgerdb_proto_depIdxs,
+		MessageInfos:      file_pkg_ledgerdbpb_ledgerdb_proto_msgTypes,
+	}.Build()
+	File_pkg_ledgerdbpb_ledgerdb_proto = out.File
+	file_pkg_ledgerdbpb_ledgerdb_proto_goTypes = nil
+	file_pkg_ledgerdbpb_ledgerdb_proto_depIdxs = nil
+}
@@ -0,0 +1,515 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             v6.33.2
+// source: pkg/ledgerdbpb/ledgerdb.proto
+
+package ledgerdbpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Ledger_GetDocument_FullMethodName     = "/ledgerdb.v1.Ledger/GetDocument"
+	Ledger_PutDocument_FullMethodName     = "/ledgerdb.v1.Ledger/PutDocument"
+	Ledger_PatchDocument_FullMethodName   = "/ledgerdb.v1.Ledger/PatchDocument"
+	Ledger_DeleteDocument_FullMethodName  = "/ledgerdb.v1.Ledger/DeleteDocument"
+	Ledger_RevertDocument_FullMethodName  = "/ledgerdb.v1.Ledger/RevertDocument"
+	Ledger_ListDocuments_FullMethodName   = "/ledgerdb.v1.Ledger/ListDocuments"
+	Ledger_DocumentLog_FullMethodName     = "/ledgerdb.v1.Ledger/DocumentLog"
+	Ledger_ApplyBatch_FullMethodName      = "/ledgerdb.v1.Ledger/ApplyBatch"
+	Ledger_ApplyCollection_FullMethodName = "/ledgerdb.v1.Ledger/ApplyCollection"
+	Ledger_CollectionLog_FullMethodName   = "/ledgerdb.v1.Ledger/CollectionLog"
+	Ledger_Watch_FullMethodName           = "/ledgerdb.v1.Ledger/Watch"
+)
+
+// LedgerClient is the client API for Ledger service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Ledger exposes the document, batch and collection operations of
+// `ledgerdb serve --grpc`. Payloads, patches and schemas are JSON bytes.
+type LedgerClient interface {
+	GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error)
+	PutDocument(ctx context.Context, in *PutDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error)
+	PatchDocument(ctx context.Context, in *PatchDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error)
+	DeleteDocument(ctx context.Context, in *DeleteDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error)
+	RevertDocument(ctx context.Context, in *RevertDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error)
+	ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error)
+	DocumentLog(ctx context.Context, in *DocumentLogRequest, opts ...grpc.CallOption) (*DocumentLogResponse, error)
+	ApplyBatch(ctx context.Context, in *ApplyBatchRequest, opts ...grpc.CallOption) (*ApplyBatchResponse, error)
+	ApplyCollection(ctx context.Context, in *ApplyCollectionRequest, opts ...grpc.CallOption) (*ApplyCollectionResponse, error)
+	CollectionLog(ctx context.Context, in *CollectionLogRequest, opts ...grpc.CallOption) (*CollectionLogResponse, error)
+	// Watch streams every transaction after cursor in commit order and keeps
+	// the stream open, pushing new transactions as commits land on main.
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error)
+}
+
+type ledgerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewLedgerClient(cc grpc.ClientConnInterface) LedgerClient {
+	return &ledgerClient{cc}
+}
+
+func (c *ledgerClient) GetDocument(ctx context.Context, in *GetDocumentRequest, opts ...grpc.CallOption) (*Document, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Document)
+	err := c.cc.Invoke(ctx, Ledger_GetDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) PutDocument(ctx context.Context, in *PutDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteResult)
+	err := c.cc.Invoke(ctx, Ledger_PutDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) PatchDocument(ctx context.Context, in *PatchDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteResult)
+	err := c.cc.Invoke(ctx, Ledger_PatchDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) DeleteDocument(ctx context.Context, in *DeleteDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteResult)
+	err := c.cc.Invoke(ctx, Ledger_DeleteDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) RevertDocument(ctx context.Context, in *RevertDocumentRequest, opts ...grpc.CallOption) (*WriteResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WriteResult)
+	err := c.cc.Invoke(ctx, Ledger_RevertDocument_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) ListDocuments(ctx context.Context, in *ListDocumentsRequest, opts ...grpc.CallOption) (*ListDocumentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDocumentsResponse)
+	err := c.cc.Invoke(ctx, Ledger_ListDocuments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) DocumentLog(ctx context.Context, in *DocumentLogRequest, opts ...grpc.CallOption) (*DocumentLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DocumentLogResponse)
+	err := c.cc.Invoke(ctx, Ledger_DocumentLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) ApplyBatch(ctx context.Context, in *ApplyBatchRequest, opts ...grpc.CallOption) (*ApplyBatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyBatchResponse)
+	err := c.cc.Invoke(ctx, Ledger_ApplyBatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) ApplyCollection(ctx context.Context, in *ApplyCollectionRequest, opts ...grpc.CallOption) (*ApplyCollectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyCollectionResponse)
+	err := c.cc.Invoke(ctx, Ledger_ApplyCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) CollectionLog(ctx context.Context, in *CollectionLogRequest, opts ...grpc.CallOption) (*CollectionLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CollectionLogResponse)
+	err := c.cc.Invoke(ctx, Ledger_CollectionLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ledgerClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ChangeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Ledger_ServiceDesc.Streams[0], Ledger_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, ChangeEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Ledger_WatchClient = grpc.ServerStreamingClient[ChangeEvent]
+
+// LedgerServer is the server API for Ledger service.
+// All implementations must embed UnimplementedLedgerServer
+// for forward compatibility.
+//
+// Ledger exposes the document, batch and collection operations of
+// `ledgerdb serve --grpc`. Payloads, patches and schemas are JSON bytes.
+type LedgerServer interface {
+	GetDocument(context.Context, *GetDocumentRequest) (*Document, error)
+	PutDocument(context.Context, *PutDocumentRequest) (*WriteResult, error)
+	PatchDocument(context.Context, *PatchDocumentRequest) (*WriteResult, error)
+	DeleteDocument(context.Context, *DeleteDocumentRequest) (*WriteResult, error)
+	RevertDocument(context.Context, *RevertDocumentRequest) (*WriteResult, error)
+	ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error)
+	DocumentLog(context.Context, *DocumentLogRequest) (*DocumentLogResponse, error)
+	ApplyBatch(context.Context, *ApplyBatchRequest) (*ApplyBatchResponse, error)
+	ApplyCollection(context.Context, *ApplyCollectionRequest) (*ApplyCollectionResponse, error)
+	CollectionLog(context.Context, *CollectionLogRequest) (*CollectionLogResponse, error)
+	// Watch streams every transaction after cursor in commit order and keeps
+	// the stream open, pushing new transactions as commits land on main.
+	Watch(*WatchRequest, grpc.ServerStreamingServer[ChangeEvent]) error
+	mustEmbedUnimplementedLedgerServer()
+}
+
+// UnimplementedLedgerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedLedgerServer struct{}
+
+func (UnimplementedLedgerServer) GetDocument(context.Context, *GetDocumentRequest) (*Document, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDocument not implemented")
+}
+func (UnimplementedLedgerServer) PutDocument(context.Context, *PutDocumentRequest) (*WriteResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method PutDocument not implemented")
+}
+func (UnimplementedLedgerServer) PatchDocument(context.Context, *PatchDocumentRequest) (*WriteResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method PatchDocument not implemented")
+}
+func (UnimplementedLedgerServer) DeleteDocument(context.Context, *DeleteDocumentRequest) (*WriteResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteDocument not implemented")
+}
+func (UnimplementedLedgerServer) RevertDocument(context.Context, *RevertDocumentRequest) (*WriteResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevertDocument not implemented")
+}
+func (UnimplementedLedgerServer) ListDocuments(context.Context, *ListDocumentsRequest) (*ListDocumentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDocuments not implemented")
+}
+func (UnimplementedLedgerServer) DocumentLog(context.Context, *DocumentLogRequest) (*DocumentLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DocumentLog not implemented")
+}
+func (UnimplementedLedgerServer) ApplyBatch(context.Context, *ApplyBatchRequest) (*ApplyBatchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApplyBatch not implemented")
+}
+func (UnimplementedLedgerServer) ApplyCollection(context.Context, *ApplyCollectionRequest) (*ApplyCollectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApplyCollection not implemented")
+}
+func (UnimplementedLedgerServer) CollectionLog(context.Context, *CollectionLogRequest) (*CollectionLogResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CollectionLog not implemented")
+}
+func (UnimplementedLedgerServer) Watch(*WatchRequest, grpc.ServerStreamingServer[ChangeEvent]) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedLedgerServer) mustEmbedUnimplementedLedgerServer() {}
+func (UnimplementedLedgerServer) testEmbeddedByValue()                {}
+
+// UnsafeLedgerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to LedgerServer will
+// result in compilation errors.
+type UnsafeLedgerServer interface {
+	mustEmbedUnimplementedLedgerServer()
+}
+
+func RegisterLedgerServer(s grpc.ServiceRegistrar, srv LedgerServer) {
+	// If the following call panics, it indicates UnimplementedLedgerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Ledger_ServiceDesc, srv)
+}
+
+func _Ledger_GetDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).GetDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_GetDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).GetDocument(ctx, req.(*GetDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_PutDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PutDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).PutDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_PutDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).PutDocument(ctx, req.(*PutDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_PatchDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PatchDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).PatchDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_PatchDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).PatchDocument(ctx, req.(*PatchDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_DeleteDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).DeleteDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_DeleteDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).DeleteDocument(ctx, req.(*DeleteDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_RevertDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevertDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).RevertDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_RevertDocument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).RevertDocument(ctx, req.(*RevertDocumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_ListDocuments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDocumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).ListDocuments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_ListDocuments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).ListDocuments(ctx, req.(*ListDocumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_DocumentLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DocumentLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).DocumentLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_DocumentLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).DocumentLog(ctx, req.(*DocumentLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_ApplyBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).ApplyBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_ApplyBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).ApplyBatch(ctx, req.(*ApplyBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_ApplyCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyCollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).ApplyCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_ApplyCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).ApplyCollection(ctx, req.(*ApplyCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_CollectionLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LedgerServer).CollectionLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ledger_CollectionLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LedgerServer).CollectionLog(ctx, req.(*CollectionLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ledger_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LedgerServer).Watch(m, &grpc.GenericServerStream[WatchRequest, ChangeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Ledger_WatchServer = grpc.ServerStreamingServer[ChangeEvent]
+
+// Ledger_ServiceDesc is the grpc.ServiceDesc for Ledger service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ledger_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ledgerdb.v1.Ledger",
+	HandlerType: (*LedgerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDocument",
+			Handler:    _Ledger_GetDocument_Handler,
+		},
+		{
+			MethodName: "PutDocument",
+			Handler:    _Ledger_PutDocument_Handler,
+		},
+		{
+			MethodName: "PatchDocument",
+			Handler:    _Ledger_PatchDocument_Handler,
+		},
+		{
+			MethodName: "DeleteDocument",
+			Handler:    _Ledger_DeleteDocument_Handler,
+		},
+		{
+			MethodName: "RevertDocument",
+			Handler:    _Ledger_RevertDocument_Handler,
+		},
+		{
+			MethodName: "ListDocuments",
+			Handler:    _Ledger_ListDocuments_Handler,
+		},
+		{
+			MethodName: "DocumentLog",
+			Handler:    _Ledger_DocumentLog_Handler,
+		},
+		{
+			MethodName: "ApplyBatch",
+			Handler:    _Ledger_ApplyBatch_Handler,
+		},
+		{
+			MethodName: "ApplyCollection",
+			Handler:    _Ledger_ApplyCollection_Handler,
+		},
+		{
+			MethodName: "CollectionLog",
+			Handler:    _Ledger_CollectionLog_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _Ledger_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/ledgerdbpb/ledgerdb.proto",
+}
@@ -0,0 +1,73 @@
+package ledgerdbsdk
+
+import (
+	"context"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+)
+
+// ChangeEvent is one transaction applied on main. Payload is set for puts and
+// Patch for patches. Cursor resumes the stream right after this event.
+type ChangeEvent struct {
+	Cursor        string
+	Commit        string
+	Collection    string
+	DocID         string
+	Op            string
+	TxID          string
+	TxHash        string
+	ParentHash    string
+	Timestamp     int64
+	SchemaVersion string
+	Payload       []byte
+	Patch         []byte
+}
+
+// Changes streams every transaction after cursor in commit order and keeps
+// polling main every Index.Interval until ctx is cancelled. An empty cursor
+// starts from the first commit; a commit hash starts right after it. Both
+// channels are closed when the stream stops; at most one error is sent.
+func (c *Client) Changes(ctx context.Context, cursor string) (<-chan ChangeEvent, <-chan error) {
+	events := make(chan ChangeEvent)
+	errs := make(chan error, 1)
+
+	service := changesapp.NewService(c.store, c.store, txv3.Decoder{}, hash.SHA256{})
+	opts := changesapp.FollowOptions{
+		Since:    cursor,
+		Fetch:    c.cfg.Index.Fetch,
+		Interval: c.cfg.Index.Interval,
+	}
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		err := service.Follow(ctx, c.cfg.RepoPath, opts, func(event changesapp.Event) error {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case events <- ChangeEvent{
+				Cursor:        event.Cursor,
+				Commit:        event.Commit,
+				Collection:    event.Collection,
+				DocID:         event.DocID,
+				Op:            event.Op,
+				TxID:          event.TxID,
+				TxHash:        event.TxHash,
+				ParentHash:    event.ParentHash,
+				Timestamp:     event.Timestamp,
+				SchemaVersion: event.SchemaVersion,
+				Payload:       event.Payload,
+				Patch:         event.Patch,
+			}:
+				return nil
+			}
+		})
+		if err != nil {
+			errs <- err
+		}
+	}()
+	return events, errs
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/gitrepo"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/sqliteindex"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
 )
 
 // Client provides direct access to LedgerDB core services.
@@ -54,6 +55,7 @@ func New(cfg Config) (*Client, error) {
 		SignCommits: normalized.SignCommits,
 		SignKey:     normalized.SignKey,
 		HistoryMode: historyMode,
+		TxDecoder:   txv3.Decoder{},
 	})
 
 	return &Client{
@@ -114,6 +116,7 @@ func (c *Client) syncOptions() (indexapp.SyncOptions, error) {
 		AllowReset:   c.historyMode == domain.HistoryModeAmend,
 		BatchCommits: c.cfg.Index.BatchCommits,
 		Mode:         mode,
+		History:      c.cfg.Index.History,
 	}, nil
 }
 
@@ -1,6 +1,7 @@
 package ledgerdbsdk
 
 import (
+	"crypto/ed25519"
 	"path/filepath"
 	"strings"
 	"time"
@@ -27,22 +28,36 @@ const (
 	IndexModeState   IndexMode = "state"
 )
 
+const defaultPatchRetries = 5
+
 // Config defines the SDK behavior for direct core access.
 type Config struct {
-	RepoPath     string
-	AutoSync     bool
-	AutoWatch    bool
-	SignCommits  bool
-	SignKey      string
+	RepoPath    string
+	AutoSync    bool
+	AutoWatch   bool
+	SignCommits bool
+	SignKey     string
+	// TxKey, when set, signs every transaction the client writes. Collections
+	// with trusted keys reject writes not signed by one of them.
+	TxKey        ed25519.PrivateKey
 	StreamLayout StreamLayout
 	HistoryMode  HistoryMode
 	Index        IndexConfig
+	Hooks        HooksConfig
+	// PatchRetries bounds how often Patch re-reads the document and re-applies
+	// its operations when another writer moved the stream head. Zero uses the
+	// default budget; a negative value disables retries.
+	PatchRetries      int
+	PatchRetryBackoff time.Duration
 }
 
-// IndexConfig configures the SQLite sidecar and watch behavior.
+// IndexConfig configures the SQLite sidecar and watch behavior. History
+// records every applied transaction in collection_<name>_history and always
+// replays the commit log, whatever Mode says.
 type IndexConfig struct {
 	DBPath       string
 	Mode         IndexMode
+	History      bool
 	Interval     time.Duration
 	Jitter       time.Duration
 	BatchCommits int
@@ -87,5 +102,11 @@ func normalizeConfig(cfg Config) (Config, error) {
 	if cfg.Index.BatchCommits <= 0 {
 		cfg.Index.BatchCommits = 1
 	}
+	if cfg.Hooks.Checkpoint == "" {
+		cfg.Hooks.Checkpoint = cfg.Index.DBPath + ".hooks.cursor"
+	}
+	if cfg.PatchRetries == 0 {
+		cfg.PatchRetries = defaultPatchRetries
+	}
 	return cfg, nil
 }
@@ -0,0 +1,66 @@
+package ledgerdbsdk
+
+import (
+	"context"
+	"encoding/json"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+)
+
+// DiffOptions selects the versions to compare. Both accept a tx hash or tx id;
+// To defaults to the stream head and From to the parent of To.
+type DiffOptions struct {
+	From string
+	To   string
+}
+
+// PatchOp is one RFC 6902 operation. Old holds the value that was replaced or
+// removed and is not part of the patch itself.
+type PatchOp struct {
+	Op    string
+	Path  string
+	Value json.RawMessage
+	Old   json.RawMessage
+}
+
+// DiffResult describes the changes between two document versions. Patch is
+// the RFC 6902 JSON Patch that turns the From state into the To state.
+type DiffResult struct {
+	FromTxHash string
+	FromTxID   string
+	ToTxHash   string
+	ToTxID     string
+	Ops        []PatchOp
+	Patch      json.RawMessage
+}
+
+// Diff compares a document between two transactions of its stream.
+func (c *Client) Diff(ctx context.Context, collection, docID string, opts DiffOptions) (DiffResult, error) {
+	service := docapp.NewDiffService(c.store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, jsonpatch.Differ{}, c.layout)
+	result, err := service.Diff(ctx, c.cfg.RepoPath, collection, docID, docapp.DiffOptions{From: opts.From, To: opts.To})
+	if err != nil {
+		return DiffResult{}, mapDocErr(err)
+	}
+	ops := result.Ops
+	if ops == nil {
+		ops = []docapp.PatchOp{}
+	}
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return DiffResult{}, err
+	}
+	out := DiffResult{
+		FromTxHash: result.FromHash,
+		FromTxID:   result.FromTxID,
+		ToTxHash:   result.ToHash,
+		ToTxID:     result.ToTxID,
+		Patch:      patch,
+	}
+	for _, op := range result.Ops {
+		out.Ops = append(out.Ops, PatchOp{Op: op.Op, Path: op.Path, Value: op.Value, Old: op.Old})
+	}
+	return out, nil
+}
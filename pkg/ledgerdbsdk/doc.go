@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"time"
 
 	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
 	"github.com/osvaldoandrade/ledgerdb/internal/platform"
 )
@@ -21,10 +25,20 @@ type PutResult struct {
 }
 
 type Doc struct {
-	Payload json.RawMessage
-	TxHash  string
-	TxID    string
-	Op      string
+	Payload    json.RawMessage
+	TxHash     string
+	TxID       string
+	Op         string
+	CommitHash string
+}
+
+// GetOptions selects a past read point for GetAt. At most one field may be
+// set: AtTx takes a tx hash or tx id from the document stream, AtCommit a
+// repository commit, and AsOf the newest commit at or before that time.
+type GetOptions struct {
+	AtTx     string
+	AtCommit string
+	AsOf     time.Time
 }
 
 type DocMeta struct {
@@ -34,11 +48,20 @@ type DocMeta struct {
 }
 
 type LogEntry struct {
-	TxID       string
-	TxHash     string
-	ParentHash string
-	Timestamp  int64
-	Op         string
+	TxID         string
+	TxHash       string
+	ParentHash   string
+	MergeParents []string
+	Timestamp    int64
+	Op           string
+}
+
+// PutOptions sets write preconditions checked against the stream head.
+// IfMatch requires the head to equal the given tx hash (as returned by Get);
+// IfNoneMatch requires the document stream to not exist yet.
+type PutOptions struct {
+	IfMatch     string
+	IfNoneMatch bool
 }
 
 type RevertOptions struct {
@@ -48,16 +71,27 @@ type RevertOptions struct {
 
 // Get reads a document directly from the ledger (key-value path).
 func (c *Client) Get(ctx context.Context, collection, docID string) (Doc, error) {
-	service := docapp.NewGetService(c.store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, c.layout)
-	result, err := service.Get(ctx, c.cfg.RepoPath, collection, docID)
+	return c.GetAt(ctx, collection, docID, GetOptions{})
+}
+
+// GetAt reads a document as it was at a past transaction, commit or time.
+// CommitHash is set on the result when the read was resolved to a commit.
+func (c *Client) GetAt(ctx context.Context, collection, docID string, opts GetOptions) (Doc, error) {
+	service := docapp.NewGetService(c.store, c.store, txv3.Decoder{}, hash.SHA256{}, jsonpatch.Patcher{}, c.store, c.layout)
+	result, err := service.GetAt(ctx, c.cfg.RepoPath, collection, docID, docapp.ReadOptions{
+		AtTx:     opts.AtTx,
+		AtCommit: opts.AtCommit,
+		AsOf:     opts.AsOf,
+	})
 	if err != nil {
 		return Doc{}, mapDocErr(err)
 	}
 	return Doc{
-		Payload: result.Payload,
-		TxHash:  result.TxHash,
-		TxID:    result.TxID,
-		Op:      result.Op.String(),
+		Payload:    result.Payload,
+		TxHash:     result.TxHash,
+		TxID:       result.TxID,
+		Op:         result.Op.String(),
+		CommitHash: result.CommitHash,
 	}, nil
 }
 
@@ -77,19 +111,26 @@ func (c *Client) GetInto(ctx context.Context, collection, docID string, target a
 
 // Put writes a full snapshot payload (JSON bytes).
 func (c *Client) Put(ctx context.Context, collection, docID string, payload []byte) (PutResult, error) {
+	return c.PutWithOptions(ctx, collection, docID, payload, PutOptions{})
+}
+
+// PutWithOptions writes a full snapshot payload guarded by write preconditions.
+func (c *Client) PutWithOptions(ctx context.Context, collection, docID string, payload []byte, opts PutOptions) (PutResult, error) {
 	idGen := ident.NewULIDGenerator()
 	service := docapp.NewPutService(
 		c.store,
 		canonicaljson.Canonicalizer{},
-		txv3.Encoder{},
+		c.txEncoder(),
 		hash.SHA256{},
 		platform.RealClock{},
 		idGen,
+		c.store,
+		schema.JSONSchemaValidator{},
 		c.layout,
 		c.historyMode,
 	)
 	result, err := c.withAutoSync(ctx, func() (docapp.PutResult, error) {
-		return service.Put(ctx, c.cfg.RepoPath, collection, docID, payload)
+		return service.Put(ctx, c.cfg.RepoPath, collection, docID, payload, opts.toWriteOptions())
 	})
 	if err != nil {
 		return PutResult{}, mapDocErr(err)
@@ -108,22 +149,30 @@ func (c *Client) PutJSON(ctx context.Context, collection, docID string, value an
 
 // Patch applies JSON Patch operations (RFC 6902).
 func (c *Client) Patch(ctx context.Context, collection, docID string, ops []byte) (PutResult, error) {
+	return c.PatchWithOptions(ctx, collection, docID, ops, PutOptions{})
+}
+
+// PatchWithOptions applies JSON Patch operations guarded by write preconditions.
+func (c *Client) PatchWithOptions(ctx context.Context, collection, docID string, ops []byte, opts PutOptions) (PutResult, error) {
 	idGen := ident.NewULIDGenerator()
 	service := docapp.NewPatchService(
 		c.store,
 		c.store,
 		canonicaljson.Canonicalizer{},
-		txv3.Encoder{},
+		c.txEncoder(),
 		txv3.Decoder{},
 		jsonpatch.Patcher{},
 		hash.SHA256{},
 		platform.RealClock{},
 		idGen,
+		c.store,
+		schema.JSONSchemaValidator{},
+		c.patchRetryPolicy(),
 		c.layout,
 		c.historyMode,
 	)
 	result, err := c.withAutoSync(ctx, func() (docapp.PutResult, error) {
-		return service.Patch(ctx, c.cfg.RepoPath, collection, docID, ops)
+		return service.Patch(ctx, c.cfg.RepoPath, collection, docID, ops, opts.toWriteOptions())
 	})
 	if err != nil {
 		return PutResult{}, mapDocErr(err)
@@ -142,11 +191,16 @@ func (c *Client) PatchJSON(ctx context.Context, collection, docID string, ops an
 
 // Delete marks a document as deleted (tombstone).
 func (c *Client) Delete(ctx context.Context, collection, docID string) (PutResult, error) {
+	return c.DeleteWithOptions(ctx, collection, docID, PutOptions{})
+}
+
+// DeleteWithOptions marks a document as deleted, guarded by write preconditions.
+func (c *Client) DeleteWithOptions(ctx context.Context, collection, docID string, opts PutOptions) (PutResult, error) {
 	idGen := ident.NewULIDGenerator()
 	service := docapp.NewDeleteService(
 		c.store,
 		c.store,
-		txv3.Encoder{},
+		c.txEncoder(),
 		txv3.Decoder{},
 		hash.SHA256{},
 		platform.RealClock{},
@@ -155,7 +209,7 @@ func (c *Client) Delete(ctx context.Context, collection, docID string) (PutResul
 		c.historyMode,
 	)
 	result, err := c.withAutoSync(ctx, func() (docapp.PutResult, error) {
-		return service.Delete(ctx, c.cfg.RepoPath, collection, docID)
+		return service.Delete(ctx, c.cfg.RepoPath, collection, docID, opts.toWriteOptions())
 	})
 	if err != nil {
 		return PutResult{}, mapDocErr(err)
@@ -170,12 +224,14 @@ func (c *Client) Revert(ctx context.Context, collection, docID string, opts Reve
 		c.store,
 		c.store,
 		canonicaljson.Canonicalizer{},
-		txv3.Encoder{},
+		c.txEncoder(),
 		txv3.Decoder{},
 		jsonpatch.Patcher{},
 		hash.SHA256{},
 		platform.RealClock{},
 		idGen,
+		c.store,
+		schema.JSONSchemaValidator{},
 		c.layout,
 		c.historyMode,
 	)
@@ -201,11 +257,12 @@ func (c *Client) Log(ctx context.Context, collection, docID string) ([]LogEntry,
 	out := make([]LogEntry, 0, len(entries))
 	for _, entry := range entries {
 		out = append(out, LogEntry{
-			TxID:       entry.TxID,
-			TxHash:     entry.TxHash,
-			ParentHash: entry.ParentHash,
-			Timestamp:  entry.Timestamp,
-			Op:         entry.Op.String(),
+			TxID:         entry.TxID,
+			TxHash:       entry.TxHash,
+			ParentHash:   entry.ParentHash,
+			MergeParents: entry.MergeParents,
+			Timestamp:    entry.Timestamp,
+			Op:           entry.Op.String(),
 		})
 	}
 	return out, nil
@@ -222,21 +279,53 @@ func (c *Client) Push(ctx context.Context) error {
 }
 
 func (c *Client) withAutoSync(ctx context.Context, fn func() (docapp.PutResult, error)) (docapp.PutResult, error) {
+	var result docapp.PutResult
+	err := c.autoSync(ctx, func() error {
+		var err error
+		result, err = fn()
+		return err
+	})
+	if err != nil {
+		return docapp.PutResult{}, err
+	}
+	return result, nil
+}
+
+func (c *Client) autoSync(ctx context.Context, fn func() error) error {
 	if c.cfg.AutoSync {
 		if err := c.store.Fetch(ctx, c.cfg.RepoPath); err != nil {
-			return docapp.PutResult{}, err
+			return err
 		}
 	}
-	result, err := fn()
-	if err != nil {
-		return docapp.PutResult{}, err
+	if err := fn(); err != nil {
+		return err
 	}
 	if c.cfg.AutoSync {
 		if err := c.store.Push(ctx, c.cfg.RepoPath); err != nil {
-			return docapp.PutResult{}, err
+			return err
 		}
 	}
-	return result, nil
+	return nil
+}
+
+func (c *Client) txEncoder() docapp.Encoder {
+	if c.cfg.TxKey != nil {
+		return txv3.SigningEncoder{Key: c.cfg.TxKey}
+	}
+	return txv3.Encoder{}
+}
+
+func (c *Client) patchRetryPolicy() docapp.RetryPolicy {
+	policy := docapp.DefaultRetryPolicy()
+	policy.MaxRetries = c.cfg.PatchRetries
+	if c.cfg.PatchRetryBackoff > 0 {
+		policy.BaseDelay = c.cfg.PatchRetryBackoff
+	}
+	return policy
+}
+
+func (o PutOptions) toWriteOptions() docapp.WriteOptions {
+	return docapp.WriteOptions{IfMatch: o.IfMatch, IfNoneMatch: o.IfNoneMatch}
 }
 
 func mapDocErr(err error) error {
@@ -246,5 +335,19 @@ func mapDocErr(err error) error {
 	if errors.Is(err, docapp.ErrDocNotFound) {
 		return ErrNotFound
 	}
+	if errors.Is(err, domain.ErrHeadChanged) {
+		return ErrHeadChanged
+	}
+	if errors.Is(err, docapp.ErrPatchConflict) {
+		return fmt.Errorf("%w: %w", ErrPatchConflict, err)
+	}
+	var violationErr *domain.SchemaViolationError
+	if errors.As(err, &violationErr) {
+		mapped := &SchemaViolationError{Collection: violationErr.Collection}
+		for _, violation := range violationErr.Violations {
+			mapped.Violations = append(mapped.Violations, SchemaViolation{Pointer: violation.Pointer, Reason: violation.Reason})
+		}
+		return mapped
+	}
 	return err
 }
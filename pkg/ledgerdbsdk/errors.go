@@ -1,6 +1,10 @@
 package ledgerdbsdk
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrRepoPathRequired = errors.New("ledgerdb-sdk: repo path required")
@@ -8,4 +12,35 @@ var (
 	ErrWatchRunning     = errors.New("ledgerdb-sdk: index watch already running")
 	ErrNotFound         = errors.New("ledgerdb-sdk: document not found")
 	ErrManifestMismatch = errors.New("ledgerdb-sdk: config does not match repository manifest")
+	ErrSchemaViolation  = errors.New("ledgerdb-sdk: document violates collection schema")
+	ErrHeadChanged      = errors.New("ledgerdb-sdk: stream head changed")
+	ErrPatchConflict    = errors.New("ledgerdb-sdk: patch no longer applies after rebase")
 )
+
+// SchemaViolation describes a single schema failure at a JSON pointer.
+type SchemaViolation struct {
+	Pointer string
+	Reason  string
+}
+
+// SchemaViolationError is returned when a write is rejected by the collection schema.
+type SchemaViolationError struct {
+	Collection string
+	Violations []SchemaViolation
+}
+
+func (e *SchemaViolationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		pointer := violation.Pointer
+		if pointer == "" {
+			pointer = "/"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %s", pointer, violation.Reason))
+	}
+	return fmt.Sprintf("%s %s (%s)", ErrSchemaViolation, e.Collection, strings.Join(parts, "; "))
+}
+
+func (e *SchemaViolationError) Unwrap() error {
+	return ErrSchemaViolation
+}
@@ -0,0 +1,64 @@
+package ledgerdbsdk
+
+import (
+	"time"
+
+	changesapp "github.com/osvaldoandrade/ledgerdb/internal/app/changes"
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/webhook"
+)
+
+// Hook delivers matching transactions as a JSON POST to URL, signed with an
+// HMAC-SHA256 of Secret, or on the stdin of Command. Empty Collections or Ops
+// match everything.
+type Hook struct {
+	Name        string
+	Collections []string
+	Ops         []string
+	URL         string
+	Secret      string
+	Command     []string
+}
+
+// HooksConfig configures hook dispatch from the index watch. Each delivery is
+// retried MaxAttempts times with exponential Backoff; Checkpoint is the file
+// that records the cursor of the last delivered transaction (defaults to
+// <Index.DBPath>.hooks.cursor, next to the index it is consumed with).
+type HooksConfig struct {
+	Hooks       []Hook
+	Checkpoint  string
+	MaxAttempts int
+	Backoff     time.Duration
+	Timeout     time.Duration
+}
+
+func (c *Client) hookDispatcher() (*hooksapp.Dispatcher, hooksapp.Config, error) {
+	cfg := hooksapp.Config{
+		MaxAttempts: c.cfg.Hooks.MaxAttempts,
+		Backoff:     c.cfg.Hooks.Backoff,
+	}
+	for _, hook := range c.cfg.Hooks.Hooks {
+		cfg.Hooks = append(cfg.Hooks, hooksapp.Hook{
+			Name:        hook.Name,
+			Collections: hook.Collections,
+			Ops:         hook.Ops,
+			URL:         hook.URL,
+			Secret:      hook.Secret,
+			Command:     hook.Command,
+		})
+	}
+	if len(cfg.Hooks) == 0 {
+		return nil, cfg, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, cfg, err
+	}
+	dispatcher := hooksapp.NewDispatcher(
+		changesapp.NewService(c.store, c.store, txv3.Decoder{}, hash.SHA256{}),
+		webhook.NewSender(c.cfg.Hooks.Timeout),
+		webhook.NewFileCheckpoint(c.cfg.Hooks.Checkpoint),
+	)
+	return dispatcher, cfg, nil
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	hooksapp "github.com/osvaldoandrade/ledgerdb/internal/app/hooks"
 	indexapp "github.com/osvaldoandrade/ledgerdb/internal/app/index"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
 	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
@@ -19,14 +20,25 @@ import (
 )
 
 type IndexSyncResult struct {
-	Reset        bool
-	Fetched      bool
-	Commits      int
-	TxsApplied   int
-	DocsUpserted int
-	DocsDeleted  int
-	Collections  int
-	LastCommit   string
+	Reset           bool
+	Fetched         bool
+	Commits         int
+	TxsApplied      int
+	DocsUpserted    int
+	DocsDeleted     int
+	Collections     int
+	LastCommit      string
+	IndexesCreated  int
+	IndexesDropped  int
+	FullTextRebuilt int
+}
+
+// SearchHit is a full-text match. Higher scores are more relevant; Snippet
+// brackets the matched terms.
+type SearchHit struct {
+	DocID   string
+	Score   float64
+	Snippet string
 }
 
 type IndexedDoc struct {
@@ -145,18 +157,42 @@ func (c *Client) SyncIndex(ctx context.Context) (IndexSyncResult, error) {
 		return IndexSyncResult{}, err
 	}
 	return IndexSyncResult{
-		Reset:        result.Reset,
-		Fetched:      result.Fetched,
-		Commits:      result.Commits,
-		TxsApplied:   result.TxsApplied,
-		DocsUpserted: result.DocsUpserted,
-		DocsDeleted:  result.DocsDeleted,
-		Collections:  result.Collections,
-		LastCommit:   result.LastCommit,
+		Reset:           result.Reset,
+		Fetched:         result.Fetched,
+		Commits:         result.Commits,
+		TxsApplied:      result.TxsApplied,
+		DocsUpserted:    result.DocsUpserted,
+		DocsDeleted:     result.DocsDeleted,
+		Collections:     result.Collections,
+		LastCommit:      result.LastCommit,
+		IndexesCreated:  result.IndexesCreated,
+		IndexesDropped:  result.IndexesDropped,
+		FullTextRebuilt: result.FullTextRebuilt,
 	}, nil
 }
 
-// StartIndexWatch starts a polling loop to keep SQLite in sync.
+// Search runs an FTS5 query over the full-text fields of a collection in the
+// SQLite index and returns up to limit ranked hits (0 uses the default).
+func (c *Client) Search(ctx context.Context, collection, query string, limit int) ([]SearchHit, error) {
+	store, err := c.ensureIndexStore()
+	if err != nil {
+		return nil, err
+	}
+	service := indexapp.NewSearchService(store)
+	hits, err := service.Search(ctx, collection, query, indexapp.SearchOptions{Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SearchHit, 0, len(hits))
+	for _, hit := range hits {
+		out = append(out, SearchHit{DocID: hit.DocID, Score: hit.Score, Snippet: hit.Snippet})
+	}
+	return out, nil
+}
+
+// StartIndexWatch starts a polling loop to keep SQLite in sync. With Hooks
+// configured, each cycle also delivers new transactions; failed deliveries
+// are reported on WatchErrors and retried on the next cycle.
 func (c *Client) StartIndexWatch(ctx context.Context) error {
 	if c.cfg.Index.Interval <= 0 {
 		return fmt.Errorf("index watch interval must be > 0")
@@ -175,6 +211,10 @@ func (c *Client) StartIndexWatch(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	dispatcher, hookCfg, err := c.hookDispatcher()
+	if err != nil {
+		return err
+	}
 
 	watchCtx, cancel := context.WithCancel(ctx)
 	results := make(chan IndexSyncResult, 1)
@@ -196,14 +236,32 @@ func (c *Client) StartIndexWatch(ctx context.Context) error {
 
 			if c.cfg.Index.EmitResults && (!c.cfg.Index.OnlyChanges || hasIndexChanges(result)) {
 				results <- IndexSyncResult{
-					Reset:        result.Reset,
-					Fetched:      result.Fetched,
-					Commits:      result.Commits,
-					TxsApplied:   result.TxsApplied,
-					DocsUpserted: result.DocsUpserted,
-					DocsDeleted:  result.DocsDeleted,
-					Collections:  result.Collections,
-					LastCommit:   result.LastCommit,
+					Reset:           result.Reset,
+					Fetched:         result.Fetched,
+					Commits:         result.Commits,
+					TxsApplied:      result.TxsApplied,
+					DocsUpserted:    result.DocsUpserted,
+					DocsDeleted:     result.DocsDeleted,
+					Collections:     result.Collections,
+					LastCommit:      result.LastCommit,
+					IndexesCreated:  result.IndexesCreated,
+					IndexesDropped:  result.IndexesDropped,
+					FullTextRebuilt: result.FullTextRebuilt,
+				}
+			}
+
+			if dispatcher != nil {
+				if _, err := dispatcher.Dispatch(watchCtx, c.cfg.RepoPath, hookCfg); err != nil {
+					if !errors.Is(err, hooksapp.ErrDeliveryFailed) {
+						if !errors.Is(err, context.Canceled) {
+							errs <- err
+						}
+						return
+					}
+					select {
+					case errs <- err:
+					default:
+					}
 				}
 			}
 
@@ -274,6 +332,7 @@ func (c *Client) indexSyncService() (*indexapp.SyncService, indexapp.SyncOptions
 		return nil, indexapp.SyncOptions{}, err
 	}
 	service := indexapp.NewSyncService(
+		c.store,
 		c.store,
 		c.store,
 		store,
@@ -281,12 +340,14 @@ func (c *Client) indexSyncService() (*indexapp.SyncService, indexapp.SyncOptions
 		txv3.Decoder{},
 		jsonpatch.Patcher{},
 		hash.SHA256{},
+		c.store,
 	)
 	return service, opts, nil
 }
 
 func hasIndexChanges(result indexapp.SyncResult) bool {
-	return result.Commits > 0 || result.TxsApplied > 0 || result.DocsUpserted > 0 || result.DocsDeleted > 0
+	return result.Commits > 0 || result.TxsApplied > 0 || result.DocsUpserted > 0 || result.DocsDeleted > 0 ||
+		result.IndexesCreated > 0 || result.IndexesDropped > 0 || result.FullTextRebuilt > 0
 }
 
 func tableNameForCollection(collection string) string {
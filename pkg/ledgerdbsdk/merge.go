@@ -0,0 +1,71 @@
+package ledgerdbsdk
+
+import (
+	"context"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonmerge"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/platform"
+)
+
+// MergeResult describes the outcome of a three-way document merge.
+// Conflicts lists the JSON pointers recorded under the document's
+// "_conflicts" field; UpToDate is set when no merge commit was needed.
+type MergeResult struct {
+	PutResult
+	BaseHash   string
+	OursHash   string
+	TheirsHash string
+	Conflicts  []string
+	UpToDate   bool
+}
+
+// Merge reconciles a document's local head with the head found at theirsRef
+// (for example "refs/remotes/origin/main"), writing a merge transaction.
+func (c *Client) Merge(ctx context.Context, collection, docID, theirsRef string) (MergeResult, error) {
+	idGen := ident.NewULIDGenerator()
+	service := docapp.NewMergeService(
+		c.store,
+		c.store,
+		c.store,
+		canonicaljson.Canonicalizer{},
+		c.txEncoder(),
+		txv3.Decoder{},
+		jsonpatch.Patcher{},
+		jsonmerge.Merger{},
+		hash.SHA256{},
+		platform.RealClock{},
+		idGen,
+		c.store,
+		schema.JSONSchemaValidator{},
+		c.layout,
+		c.historyMode,
+	)
+	var result docapp.MergeResult
+	err := c.autoSync(ctx, func() error {
+		var err error
+		result, err = service.Merge(ctx, c.cfg.RepoPath, collection, docID, docapp.MergeOptions{Theirs: theirsRef})
+		return err
+	})
+	if err != nil {
+		return MergeResult{}, mapDocErr(err)
+	}
+	return MergeResult{
+		PutResult: PutResult{
+			CommitHash: result.CommitHash,
+			TxHash:     result.TxHash,
+			TxID:       result.TxID,
+		},
+		BaseHash:   result.BaseHash,
+		OursHash:   result.OursHash,
+		TheirsHash: result.TheirsHash,
+		Conflicts:  result.Conflicts,
+		UpToDate:   result.UpToDate,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package ledgerdbsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	queryapp "github.com/osvaldoandrade/ledgerdb/internal/app/query"
+)
+
+// Where returns the ids of documents whose declared index field equals value,
+// answered from the git-native index without the SQLite sidecar. Value must be
+// a string, number or bool.
+func (c *Client) Where(ctx context.Context, collection, field string, value any) ([]string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode query value: %w", err)
+	}
+	service := queryapp.NewService(c.store, c.store)
+	result, err := service.Where(ctx, c.cfg.RepoPath, collection, field+"="+string(encoded))
+	if err != nil {
+		return nil, err
+	}
+	return result.DocIDs, nil
+}
@@ -0,0 +1,64 @@
+package ledgerdbsdk
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+)
+
+// ScanOptions filters a collection scan. After takes the Cursor of a
+// previously yielded entry to resume from it.
+type ScanOptions struct {
+	Prefix         string
+	After          string
+	IncludeDeleted bool
+}
+
+// ScanEntry is a document yielded by Scan. Payload is empty for deleted
+// documents.
+type ScanEntry struct {
+	DocID   string
+	TxID    string
+	Op      string
+	Deleted bool
+	Payload json.RawMessage
+	Cursor  string
+}
+
+// Scan iterates over the live documents of a collection, reading the ledger
+// directly without the SQLite index. Stopping the loop stops the scan.
+func (c *Client) Scan(ctx context.Context, collection string) iter.Seq2[ScanEntry, error] {
+	return c.ScanWithOptions(ctx, collection, ScanOptions{})
+}
+
+// ScanWithOptions iterates over a collection with prefix, cursor and deleted
+// document filtering.
+func (c *Client) ScanWithOptions(ctx context.Context, collection string, opts ScanOptions) iter.Seq2[ScanEntry, error] {
+	service := docapp.NewListService(c.store, txv3.Decoder{})
+	listOpts := docapp.ListOptions{
+		Prefix:         opts.Prefix,
+		After:          opts.After,
+		IncludeDeleted: opts.IncludeDeleted,
+	}
+	return func(yield func(ScanEntry, error) bool) {
+		for entry, err := range service.Scan(ctx, c.cfg.RepoPath, collection, listOpts) {
+			if err != nil {
+				yield(ScanEntry{}, mapDocErr(err))
+				return
+			}
+			if !yield(ScanEntry{
+				DocID:   entry.DocID,
+				TxID:    entry.TxID,
+				Op:      entry.Op.String(),
+				Deleted: entry.Deleted,
+				Payload: entry.Payload,
+				Cursor:  entry.Cursor,
+			}, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package ledgerdbsdk
+
+import (
+	"context"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonmerge"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/platform"
+)
+
+type SyncAction string
+
+const (
+	SyncActionNoRemote    SyncAction = "no_remote"
+	SyncActionUpToDate    SyncAction = "up_to_date"
+	SyncActionFastForward SyncAction = "fast_forward"
+	SyncActionPush        SyncAction = "push"
+	SyncActionMerge       SyncAction = "merge"
+)
+
+// SyncResult reports how the local main branch was reconciled with origin.
+type SyncResult struct {
+	Action     SyncAction
+	CommitHash string
+	LocalHead  string
+	RemoteHead string
+	Merged     []SyncMerge
+}
+
+// SyncMerge describes one document that received a merge transaction.
+type SyncMerge struct {
+	Collection string
+	DocID      string
+	TxHash     string
+	Conflicts  []string
+}
+
+// Sync fetches origin, fast-forwards or merges divergent histories stream by
+// stream, and pushes the result.
+func (c *Client) Sync(ctx context.Context) (SyncResult, error) {
+	merges := docapp.NewMergeService(
+		c.store,
+		c.store,
+		c.store,
+		canonicaljson.Canonicalizer{},
+		c.txEncoder(),
+		txv3.Decoder{},
+		jsonpatch.Patcher{},
+		jsonmerge.Merger{},
+		hash.SHA256{},
+		platform.RealClock{},
+		ident.NewULIDGenerator(),
+		c.store,
+		schema.JSONSchemaValidator{},
+		c.layout,
+		c.historyMode,
+	)
+	service := docapp.NewSyncService(c.store, c.store, txv3.Decoder{}, merges, c.historyMode)
+	result, err := service.Sync(ctx, c.cfg.RepoPath)
+	if err != nil {
+		return SyncResult{}, mapDocErr(err)
+	}
+	out := SyncResult{
+		Action:     SyncAction(result.Action),
+		CommitHash: result.CommitHash,
+		LocalHead:  result.LocalHead,
+		RemoteHead: result.RemoteHead,
+	}
+	for _, merged := range result.Merged {
+		out.Merged = append(out.Merged, SyncMerge{
+			Collection: merged.Collection,
+			DocID:      merged.DocID,
+			TxHash:     merged.TxHash,
+			Conflicts:  merged.Conflicts,
+		})
+	}
+	return out, nil
+}
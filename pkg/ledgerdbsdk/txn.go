@@ -0,0 +1,103 @@
+package ledgerdbsdk
+
+import (
+	"context"
+	"encoding/json"
+
+	docapp "github.com/osvaldoandrade/ledgerdb/internal/app/doc"
+	"github.com/osvaldoandrade/ledgerdb/internal/domain"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/canonicaljson"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/hash"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/ident"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/jsonpatch"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/schema"
+	"github.com/osvaldoandrade/ledgerdb/internal/infra/txv3"
+	"github.com/osvaldoandrade/ledgerdb/internal/platform"
+)
+
+// Txn stages writes that are committed together by Client.Txn.
+type Txn struct {
+	ops []docapp.BatchOp
+}
+
+// TxnResult describes the single commit produced by a transaction.
+type TxnResult struct {
+	CommitHash string
+	Results    []PutResult
+}
+
+// Put stages a full snapshot payload (JSON bytes).
+func (t *Txn) Put(collection, docID string, payload []byte) {
+	t.ops = append(t.ops, docapp.BatchOp{Op: domain.TxOpPut, Collection: collection, DocID: docID, Payload: payload})
+}
+
+// PutJSON marshals a Go value and stages it as a document snapshot.
+func (t *Txn) PutJSON(collection, docID string, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	t.Put(collection, docID, payload)
+	return nil
+}
+
+// Patch stages JSON Patch operations (RFC 6902).
+func (t *Txn) Patch(collection, docID string, ops []byte) {
+	t.ops = append(t.ops, docapp.BatchOp{Op: domain.TxOpPatch, Collection: collection, DocID: docID, Payload: ops})
+}
+
+// PatchJSON marshals patch operations and stages them.
+func (t *Txn) PatchJSON(collection, docID string, ops any) error {
+	payload, err := json.Marshal(ops)
+	if err != nil {
+		return err
+	}
+	t.Patch(collection, docID, payload)
+	return nil
+}
+
+// Delete stages a tombstone for a document.
+func (t *Txn) Delete(collection, docID string) {
+	t.ops = append(t.ops, docapp.BatchOp{Op: domain.TxOpDelete, Collection: collection, DocID: docID})
+}
+
+// Txn runs fn to stage writes and lands them all in one commit, or none at all.
+// Writes staged in the same transaction see each other, so a document can be
+// patched twice. Nothing is written when fn returns an error.
+func (c *Client) Txn(ctx context.Context, fn func(tx *Txn) error) (TxnResult, error) {
+	tx := &Txn{}
+	if err := fn(tx); err != nil {
+		return TxnResult{}, err
+	}
+
+	service := docapp.NewBatchService(
+		c.store,
+		c.store,
+		canonicaljson.Canonicalizer{},
+		c.txEncoder(),
+		txv3.Decoder{},
+		jsonpatch.Patcher{},
+		hash.SHA256{},
+		platform.RealClock{},
+		ident.NewULIDGenerator(),
+		c.store,
+		schema.JSONSchemaValidator{},
+		c.layout,
+		c.historyMode,
+	)
+	var result docapp.BatchResult
+	err := c.autoSync(ctx, func() error {
+		var err error
+		result, err = service.Apply(ctx, c.cfg.RepoPath, tx.ops)
+		return err
+	})
+	if err != nil {
+		return TxnResult{}, mapDocErr(err)
+	}
+
+	out := TxnResult{CommitHash: result.CommitHash, Results: make([]PutResult, 0, len(result.Txs))}
+	for _, res := range result.Txs {
+		out.Results = append(out.Results, PutResult{CommitHash: res.CommitHash, TxHash: res.TxHash, TxID: res.TxID})
+	}
+	return out, nil
+}